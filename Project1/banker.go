@@ -0,0 +1,327 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/banker"
+)
+
+// bankerAvailable names the row of a banker's state file holding the available resources.
+const bankerAvailable = "available"
+
+// bankerRequest is a request given with -request.
+type bankerRequest struct {
+	process int
+	request []int64
+}
+
+//region Avoiding deadlock
+
+// runBanker runs the banker subcommand: it loads a resource allocation state from a CSV file, writes its
+// allocation, need and whether it is safe, with a safe sequence if there is one, to w, then evaluates each request
+// given with -request in order, each granted one changing the state for the next.
+func runBanker(args []string, w io.Writer) error {
+	var (
+		fs       = flag.NewFlagSet("banker", flag.ContinueOnError)
+		requests string
+	)
+	fs.StringVar(&requests, "request", "", `semicolon separated requests to evaluate in order, each a process's name or index, a colon and the amount of each resource, e.g. "P1:1,0,2;P4:3,3,0"`)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	f, closeFile, err := openProcessingFile(append([]string{"banker"}, fs.Args()...)...)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+	state, err := loadBankerState(f)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseBankerRequests(requests, state)
+	if err != nil {
+		return err
+	}
+
+	outputTitle(w, "Banker's algorithm")
+	outputBankerState(w, state)
+	outputSafety(w, state, state.Safety())
+	if len(parsed) > 0 {
+		outputBankerRequests(w, state, parsed)
+	}
+
+	return nil
+}
+
+// parseBankerRequests parses the requests given with -request for processes in state.
+func parseBankerRequests(list string, state banker.State) ([]bankerRequest, error) {
+	var requests []bankerRequest
+	for _, text := range strings.Split(list, ";") {
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+		name, amounts, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: request %q: want a process, a colon and the amounts", ErrInvalidArgs, text)
+		}
+		name = strings.TrimSpace(name)
+		process := state.Process(name)
+		if i, err := strconv.Atoi(name); process < 0 && err == nil && i >= 0 && i < len(state.Processes) {
+			process = i
+		}
+		if process < 0 {
+			return nil, fmt.Errorf("%w: request %q: no process %q", ErrInvalidArgs, text, name)
+		}
+		fields := strings.Split(amounts, ",")
+		if len(fields) != len(state.Resources) {
+			return nil, fmt.Errorf("%w: request %q: want an amount for each of %d resources", ErrInvalidArgs, text, len(state.Resources))
+		}
+		request := make([]int64, len(fields))
+		for j, field := range fields {
+			var err error
+			if request[j], err = strconv.ParseInt(strings.TrimSpace(field), 10, 64); err != nil || request[j] < 0 {
+				return nil, fmt.Errorf("%w: request %q: amount %q is not a whole number", ErrInvalidArgs, text, field)
+			}
+		}
+		requests = append(requests, bankerRequest{process: process, request: request})
+	}
+
+	return requests, nil
+}
+
+//endregion
+
+//region Loading banker's states
+
+// loadBankerState reads a resource allocation state from CSV, with a row per process of its name, then its
+// allocation of each resource, then its maximum of each, and a row named "available" of the amount of each
+// resource available, e.g. "P0,0,1,0,7,5,3" and "available,3,3,2". An optional header row names the resources,
+// with "alloc_" and "max_" columns for each, in any order; without one they are named A, B, C and so on.
+func loadBankerState(r io.Reader) (banker.State, error) {
+	var (
+		reader    = csv.NewReader(r)
+		state     banker.State
+		allocCols []int // the column of each resource's allocation
+		maxCols   []int // the column of each resource's maximum
+		available bool
+	)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return banker.State{}, fmt.Errorf("%w: reading CSV", err)
+		}
+		line, _ := reader.FieldPos(0)
+		row[0] = strings.TrimSpace(strings.TrimPrefix(row[0], "\uFEFF"))
+		if allocCols == nil {
+			if len(row) > 1 && isHeader(row[1:]) {
+				if state.Resources, allocCols, maxCols, err = bankerHeader(row); err != nil {
+					return banker.State{}, fmt.Errorf("%w: line %d: %v", banker.ErrInvalidState, line, err)
+				}
+				continue
+			}
+			resources := len(row) / 2
+			if strings.EqualFold(row[0], bankerAvailable) {
+				resources = len(row) - 1
+			} else if len(row)%2 == 0 {
+				return banker.State{}, fmt.Errorf("%w: line %d: want a name, then an allocation and maximum for each resource", banker.ErrInvalidState, line)
+			}
+			for j := 0; j < resources; j++ {
+				state.Resources = append(state.Resources, resourceName(j))
+				allocCols, maxCols = append(allocCols, 1+j), append(maxCols, 1+resources+j)
+			}
+		}
+		values := make([]int64, len(row)-1)
+		for c := 1; c < len(row); c++ {
+			if values[c-1], err = strconv.ParseInt(strings.TrimSpace(row[c]), 10, 64); err != nil {
+				return banker.State{}, fmt.Errorf("%w: line %d: %q is not a whole number", banker.ErrInvalidState, line, row[c])
+			}
+		}
+		if strings.EqualFold(row[0], bankerAvailable) {
+			if available {
+				return banker.State{}, fmt.Errorf("%w: line %d: a second available row", banker.ErrInvalidState, line)
+			}
+			state.Available, available = values, true
+			continue
+		}
+		if len(row) != 1+2*len(state.Resources) {
+			return banker.State{}, fmt.Errorf("%w: line %d: want a name, then an allocation and maximum for each of %d resources", banker.ErrInvalidState, line, len(state.Resources))
+		}
+		if state.Process(row[0]) >= 0 {
+			return banker.State{}, fmt.Errorf("%w: line %d: process %q is named twice", banker.ErrInvalidState, line, row[0])
+		}
+		allocation, maximum := make([]int64, len(allocCols)), make([]int64, len(maxCols))
+		for j := range allocCols {
+			allocation[j], maximum[j] = values[allocCols[j]-1], values[maxCols[j]-1]
+		}
+		state.Processes = append(state.Processes, row[0])
+		state.Allocation = append(state.Allocation, allocation)
+		state.Max = append(state.Max, maximum)
+	}
+	if !available {
+		return banker.State{}, fmt.Errorf("%w: no %s row", banker.ErrInvalidState, bankerAvailable)
+	}
+	if err := state.Check(); err != nil {
+		return banker.State{}, err
+	}
+
+	return state, nil
+}
+
+// bankerHeader returns the resources named by a header row, and the columns of their allocations and maximums.
+func bankerHeader(row []string) (resources []string, allocCols, maxCols []int, err error) {
+	maxColumns := make(map[string]int)
+	for c := 1; c < len(row); c++ {
+		prefix, resource, ok := strings.Cut(strings.TrimSpace(row[c]), "_")
+		switch prefix = strings.ToLower(prefix); {
+		case !ok || resource == "":
+			return nil, nil, nil, fmt.Errorf("column %q is not alloc_ or max_ and a resource", row[c])
+		case prefix == "alloc" || prefix == "allocation":
+			resources, allocCols = append(resources, resource), append(allocCols, c)
+		case prefix == "max":
+			maxColumns[resource] = c
+		default:
+			return nil, nil, nil, fmt.Errorf("column %q is not alloc_ or max_ and a resource", row[c])
+		}
+	}
+	if len(resources) != len(maxColumns) {
+		return nil, nil, nil, fmt.Errorf("want an alloc_ and max_ column for each resource")
+	}
+	for _, r := range resources {
+		c, ok := maxColumns[r]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("no max_%s column", r)
+		}
+		maxCols = append(maxCols, c)
+	}
+
+	return resources, allocCols, maxCols, nil
+}
+
+// resourceName returns the default name of resource j: A, B, ..., Z, then R26, R27 and so on.
+func resourceName(j int) string {
+	if j < 26 {
+		return string(rune('A' + j))
+	}
+	return "R" + strconv.Itoa(j)
+}
+
+//endregion
+
+//region Banker's output
+
+// outputBankerState outputs a table of each process's allocation, maximum and need, and the resources available.
+func outputBankerState(w io.Writer, state banker.State) {
+	_, _ = fmt.Fprintln(w, "Allocation table")
+	table := tablewriter.NewWriter(w)
+	resources := " (" + strings.Join(state.Resources, " ") + ")"
+	table.SetHeader([]string{"Process", "Allocation" + resources, "Max" + resources, "Need" + resources})
+	need := state.Need()
+	for i, p := range state.Processes {
+		table.Append([]string{p, joinVector(state.Allocation[i]), joinVector(state.Max[i]), joinVector(need[i])})
+	}
+	table.SetFooter([]string{"Available", joinVector(state.Available), "", ""})
+	table.Render()
+}
+
+// outputSafety outputs the steps of the safety algorithm, and the safe sequence or the processes that can't
+// finish.
+func outputSafety(w io.Writer, state banker.State, safety banker.Safety) {
+	_, _ = fmt.Fprintln(w, "Safety check")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"#", "Process", "Work", "Need", "Work After"})
+	need := state.Need()
+	for i, p := range safety.Sequence {
+		table.Append([]string{
+			strconv.Itoa(i + 1),
+			state.Processes[p],
+			joinVector(safety.Work[i]),
+			joinVector(need[p]),
+			joinVector(safety.Work[i+1]),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "%s\n\n", safetySummary(state, safety))
+}
+
+// outputBankerRequests evaluates the requests in order and outputs a table of their outcomes.
+func outputBankerRequests(w io.Writer, state banker.State, requests []bankerRequest) {
+	_, _ = fmt.Fprintln(w, "Requests")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"#", "Process", "Request", "Outcome", "Available After", "Safety"})
+	table.SetAutoWrapText(false)
+	for i, r := range requests {
+		var outcome, safety string
+		decision, err := state.Request(r.process, r.request)
+		switch {
+		case errors.Is(err, banker.ErrExceedsClaim):
+			outcome = "exceeds claim"
+		case decision.Outcome == banker.Wait:
+			outcome = "wait: not available"
+		case decision.Outcome == banker.Unsafe:
+			outcome = "wait: unsafe"
+			safety = safetySummary(state, decision.Safety)
+		default:
+			outcome = decision.Outcome
+			safety = safetySummary(decision.State, decision.Safety)
+			state = decision.State
+		}
+		table.Append([]string{
+			strconv.Itoa(i + 1),
+			state.Processes[r.process],
+			joinVector(r.request),
+			outcome,
+			joinVector(state.Available),
+			safety,
+		})
+	}
+	table.Render()
+}
+
+// safetySummary describes the outcome of the safety algorithm: the safe sequence, or the processes that can't
+// finish.
+func safetySummary(state banker.State, safety banker.Safety) string {
+	if safety.Safe {
+		sequence := make([]string, len(safety.Sequence))
+		for i, p := range safety.Sequence {
+			sequence[i] = state.Processes[p]
+		}
+		return "Safe sequence: " + strings.Join(sequence, ", ")
+	}
+	finished := make([]bool, len(state.Processes))
+	for _, p := range safety.Sequence {
+		finished[p] = true
+	}
+	var stuck []string
+	for i, p := range state.Processes {
+		if !finished[i] {
+			stuck = append(stuck, p)
+		}
+	}
+
+	return "Unsafe: " + strings.Join(stuck, ", ") + " can't finish"
+}
+
+// joinVector joins a vector of resources with spaces.
+func joinVector(v []int64) string {
+	text := make([]string, len(v))
+	for i, n := range v {
+		text[i] = strconv.FormatInt(n, 10)
+	}
+
+	return strings.Join(text, " ")
+}
+
+//endregion
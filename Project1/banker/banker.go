@@ -0,0 +1,209 @@
+// Package banker implements the banker's algorithm for deadlock avoidance: every process declares the most of
+// each resource it may ever hold, and a request is only granted if the system is left in a safe state, one in
+// which every process can still run to completion in some order.
+package banker
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Outcomes of a request.
+const (
+	// Granted means the request was allocated, leaving the system safe.
+	Granted = "granted"
+	// Wait means there isn't enough of a resource available, so the process must wait for it.
+	Wait = "wait"
+	// Unsafe means granting the request would leave the system unsafe, so the process must wait though there's
+	// enough available.
+	Unsafe = "unsafe"
+)
+
+var (
+	ErrInvalidState = errors.New("invalid banker's state")
+	// ErrExceedsClaim is returned for a request for more than the process declared it would need.
+	ErrExceedsClaim = errors.New("request exceeds the process's maximum claim")
+)
+
+type (
+	// State is the resource allocation state of a system, with a row per process and a column per resource.
+	State struct {
+		// Processes and Resources name the rows and columns.
+		Processes []string
+		Resources []string
+		// Available is how much of each resource is free.
+		Available []int64
+		// Max is the most of each resource each process may hold, and Allocation how much it holds.
+		Max        [][]int64
+		Allocation [][]int64
+	}
+	// Safety is the outcome of the safety algorithm.
+	Safety struct {
+		Safe bool
+		// Sequence holds, in order, the processes that can run to completion, each with the resources released by
+		// the ones before it. It holds every process when the state is safe.
+		Sequence []int
+		// Work holds the resources available before each process in Sequence runs, and a last row with the
+		// resources available once they all have.
+		Work [][]int64
+	}
+	// Decision is the outcome of a request.
+	Decision struct {
+		Outcome string
+		// State is the state after the request: the state requested from when the request isn't granted.
+		State State
+		// Safety is the safety of the state the request would leave, unless the process must Wait.
+		Safety Safety
+	}
+)
+
+// Need returns how much more of each resource each process may request: its Max less its Allocation.
+func (s State) Need() [][]int64 {
+	need := make([][]int64, len(s.Max))
+	for i := range s.Max {
+		need[i] = make([]int64, len(s.Max[i]))
+		for j := range s.Max[i] {
+			need[i][j] = s.Max[i][j] - s.Allocation[i][j]
+		}
+	}
+
+	return need
+}
+
+// Safety runs the safety algorithm: it repeatedly picks the first process whose need can be met from the work
+// available, and lets it finish and release its allocation, until every process has or none can.
+func (s State) Safety() Safety {
+	var (
+		need     = s.Need()
+		work     = append([]int64(nil), s.Available...)
+		finished = make([]bool, len(s.Processes))
+		safety   Safety
+	)
+	for len(safety.Sequence) < len(s.Processes) {
+		next := -1
+		for i := range s.Processes {
+			if !finished[i] && fits(need[i], work) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		safety.Sequence = append(safety.Sequence, next)
+		safety.Work = append(safety.Work, append([]int64(nil), work...))
+		for j := range work {
+			work[j] += s.Allocation[next][j]
+		}
+		finished[next] = true
+	}
+	safety.Work = append(safety.Work, work)
+	safety.Safe = len(safety.Sequence) == len(s.Processes)
+
+	return safety
+}
+
+// Request runs the resource-request algorithm for process asking for request: the process must Wait if there
+// isn't enough available, and otherwise the request is Granted if the state it leaves is safe, or Unsafe if not.
+// It returns an ErrExceedsClaim error if the request exceeds the process's need, and an ErrInvalidState error if
+// the process or request don't match the state.
+func (s State) Request(process int, request []int64) (Decision, error) {
+	if process < 0 || process >= len(s.Processes) {
+		return Decision{}, fmt.Errorf("%w: no process %d", ErrInvalidState, process)
+	}
+	if len(request) != len(s.Resources) {
+		return Decision{}, fmt.Errorf("%w: request for %d resources, want %d", ErrInvalidState, len(request), len(s.Resources))
+	}
+	for j, n := range request {
+		if n < 0 {
+			return Decision{}, fmt.Errorf("%w: request for %d of %s", ErrInvalidState, n, s.Resources[j])
+		}
+	}
+	if need := s.Need()[process]; !fits(request, need) {
+		return Decision{}, fmt.Errorf("%w: %s requests %v but needs at most %v", ErrExceedsClaim, s.Processes[process], request, need)
+	}
+	if !fits(request, s.Available) {
+		return Decision{Outcome: Wait, State: s}, nil
+	}
+
+	next := s.clone()
+	for j, n := range request {
+		next.Available[j] -= n
+		next.Allocation[process][j] += n
+	}
+	safety := next.Safety()
+	if !safety.Safe {
+		return Decision{Outcome: Unsafe, State: s, Safety: safety}, nil
+	}
+
+	return Decision{Outcome: Granted, State: next, Safety: safety}, nil
+}
+
+// Check returns an ErrInvalidState error unless the state has a name per process and resource, its vectors and
+// matrices have a row per process and a column per resource, nothing is negative, and no process holds more than
+// its maximum.
+func (s State) Check() error {
+	if len(s.Resources) == 0 {
+		return fmt.Errorf("%w: no resources", ErrInvalidState)
+	}
+	if len(s.Available) != len(s.Resources) {
+		return fmt.Errorf("%w: %d available, want one per resource", ErrInvalidState, len(s.Available))
+	}
+	if len(s.Max) != len(s.Processes) || len(s.Allocation) != len(s.Processes) {
+		return fmt.Errorf("%w: %d maximum and %d allocation rows, want one per process", ErrInvalidState, len(s.Max), len(s.Allocation))
+	}
+	for j, n := range s.Available {
+		if n < 0 {
+			return fmt.Errorf("%w: %d of %s available", ErrInvalidState, n, s.Resources[j])
+		}
+	}
+	for i, p := range s.Processes {
+		if len(s.Max[i]) != len(s.Resources) || len(s.Allocation[i]) != len(s.Resources) {
+			return fmt.Errorf("%w: %s: want one maximum and allocation per resource", ErrInvalidState, p)
+		}
+		for j, r := range s.Resources {
+			switch {
+			case s.Allocation[i][j] < 0 || s.Max[i][j] < 0:
+				return fmt.Errorf("%w: %s: negative %s", ErrInvalidState, p, r)
+			case s.Allocation[i][j] > s.Max[i][j]:
+				return fmt.Errorf("%w: %s holds %d of %s, more than its maximum of %d", ErrInvalidState, p, s.Allocation[i][j], r, s.Max[i][j])
+			}
+		}
+	}
+
+	return nil
+}
+
+// Process returns the index of the named process, or -1 if there is none.
+func (s State) Process(name string) int {
+	for i, p := range s.Processes {
+		if p == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// clone returns a copy of the state that shares none of its vectors.
+func (s State) clone() State {
+	c := s
+	c.Available = append([]int64(nil), s.Available...)
+	c.Allocation = make([][]int64, len(s.Allocation))
+	for i := range s.Allocation {
+		c.Allocation[i] = append([]int64(nil), s.Allocation[i]...)
+	}
+
+	return c
+}
+
+// fits reports whether every element of a is at most the same element of b.
+func fits(a, b []int64) bool {
+	for j := range a {
+		if a[j] > b[j] {
+			return false
+		}
+	}
+
+	return true
+}
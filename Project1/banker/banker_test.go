@@ -0,0 +1,177 @@
+package banker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// textbook is the textbook's five processes and three resources, in a safe state.
+func textbook() State {
+	return State{
+		Processes: []string{"P0", "P1", "P2", "P3", "P4"},
+		Resources: []string{"A", "B", "C"},
+		Available: []int64{3, 3, 2},
+		Max:       [][]int64{{7, 5, 3}, {3, 2, 2}, {9, 0, 2}, {2, 2, 2}, {4, 3, 3}},
+		Allocation: [][]int64{
+			{0, 1, 0}, {2, 0, 0}, {3, 0, 2}, {2, 1, 1}, {0, 0, 2},
+		},
+	}
+}
+
+func TestState_Need(t *testing.T) {
+	t.Parallel()
+	want := [][]int64{{7, 4, 3}, {1, 2, 2}, {6, 0, 0}, {0, 1, 1}, {4, 3, 1}}
+	if got := textbook().Need(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Need() = %v, want %v", got, want)
+	}
+}
+
+func TestState_Safety(t *testing.T) {
+	t.Parallel()
+	unsafe := textbook()
+	unsafe.Available = []int64{0, 1, 1}
+	tests := []struct {
+		name         string
+		state        State
+		wantSafe     bool
+		wantSequence []int
+		wantWork     [][]int64
+	}{
+		{
+			name:         "safe",
+			state:        textbook(),
+			wantSafe:     true,
+			wantSequence: []int{1, 3, 0, 2, 4},
+			wantWork:     [][]int64{{3, 3, 2}, {5, 3, 2}, {7, 4, 3}, {7, 5, 3}, {10, 5, 5}, {10, 5, 7}},
+		},
+		{
+			name:         "unsafe",
+			state:        unsafe,
+			wantSequence: []int{3, 1},
+			wantWork:     [][]int64{{0, 1, 1}, {2, 2, 2}, {4, 2, 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.state.Safety()
+			if got.Safe != tt.wantSafe || !reflect.DeepEqual(got.Sequence, tt.wantSequence) || !reflect.DeepEqual(got.Work, tt.wantWork) {
+				t.Errorf("Safety() = %+v, want safe %v, sequence %v and work %v", got, tt.wantSafe, tt.wantSequence, tt.wantWork)
+			}
+		})
+	}
+}
+
+func TestState_Request(t *testing.T) {
+	t.Parallel()
+	// The textbook's requests, after granting P1's.
+	granted, err := textbook().Request(1, []int64{1, 0, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		state         State
+		process       int
+		request       []int64
+		wantOutcome   string
+		wantAvailable []int64
+		wantErr       error
+	}{
+		{
+			name:          "granted",
+			state:         textbook(),
+			process:       1,
+			request:       []int64{1, 0, 2},
+			wantOutcome:   Granted,
+			wantAvailable: []int64{2, 3, 0},
+		},
+		{
+			name:          "not enough available",
+			state:         granted.State,
+			process:       4,
+			request:       []int64{3, 3, 0},
+			wantOutcome:   Wait,
+			wantAvailable: []int64{2, 3, 0},
+		},
+		{
+			name:          "unsafe",
+			state:         granted.State,
+			process:       0,
+			request:       []int64{0, 2, 0},
+			wantOutcome:   Unsafe,
+			wantAvailable: []int64{2, 3, 0},
+		},
+		{
+			name:    "exceeds the claim",
+			state:   textbook(),
+			process: 3,
+			request: []int64{1, 0, 0},
+			wantErr: ErrExceedsClaim,
+		},
+		{
+			name:    "no such process",
+			state:   textbook(),
+			process: 5,
+			request: []int64{0, 0, 0},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "wrong number of resources",
+			state:   textbook(),
+			process: 0,
+			request: []int64{0, 0},
+			wantErr: ErrInvalidState,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.state.Request(tt.process, tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Request() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Outcome != tt.wantOutcome || !reflect.DeepEqual(got.State.Available, tt.wantAvailable) {
+				t.Errorf("Request() = %s leaving %v available, want %s leaving %v", got.Outcome, got.State.Available, tt.wantOutcome, tt.wantAvailable)
+			}
+		})
+	}
+	// Granting doesn't change the state requested from.
+	if s := textbook(); !reflect.DeepEqual(s.Available, []int64{3, 3, 2}) || s.Allocation[1][0] != 2 {
+		t.Errorf("Request() changed the state it was called on")
+	}
+}
+
+func TestState_Check(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		change  func(s *State)
+		wantErr error
+	}{
+		{name: "success", change: func(s *State) {}},
+		{name: "no resources", change: func(s *State) { s.Resources = nil }, wantErr: ErrInvalidState},
+		{name: "short available", change: func(s *State) { s.Available = s.Available[:2] }, wantErr: ErrInvalidState},
+		{name: "missing row", change: func(s *State) { s.Max = s.Max[:4] }, wantErr: ErrInvalidState},
+		{name: "short row", change: func(s *State) { s.Allocation[2] = []int64{3, 0} }, wantErr: ErrInvalidState},
+		{name: "negative", change: func(s *State) { s.Available[0] = -1 }, wantErr: ErrInvalidState},
+		{name: "holds more than its maximum", change: func(s *State) { s.Allocation[3][1] = 3 }, wantErr: ErrInvalidState},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := textbook()
+			tt.change(&s)
+			if err := s.Check(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/banker"
+)
+
+func Test_loadBankerState(t *testing.T) {
+	t.Parallel()
+	want := banker.State{
+		Processes:  []string{"P0", "P1"},
+		Resources:  []string{"A", "B"},
+		Available:  []int64{1, 2},
+		Max:        [][]int64{{3, 3}, {2, 1}},
+		Allocation: [][]int64{{1, 0}, {0, 1}},
+	}
+	tests := []struct {
+		name    string
+		input   string
+		want    banker.State
+		wantErr error
+	}{
+		{
+			name:  "without a header",
+			input: "P0,1,0,3,3\nP1,0,1,2,1\navailable,1,2\n",
+			want:  want,
+		},
+		{
+			name:  "available first",
+			input: "Available, 1, 2\nP0, 1, 0, 3, 3\nP1, 0, 1, 2, 1\n",
+			want:  want,
+		},
+		{
+			name:  "header with the columns in another order",
+			input: "\uFEFFprocess,max_A,alloc_A,Max_B,allocation_B\nP0,3,1,3,0\nP1,2,0,1,1\navailable,1,2\n",
+			want:  want,
+		},
+		{
+			name:  "header naming the resources",
+			input: "process,alloc_cpu,max_cpu\nP0,1,2\navailable,1\n",
+			want: banker.State{
+				Processes:  []string{"P0"},
+				Resources:  []string{"cpu"},
+				Available:  []int64{1},
+				Max:        [][]int64{{2}},
+				Allocation: [][]int64{{1}},
+			},
+		},
+		{name: "no available row", input: "P0,1,0,3,3\n", wantErr: banker.ErrInvalidState},
+		{name: "two available rows", input: "P0,1,3\navailable,1\navailable,2\n", wantErr: banker.ErrInvalidState},
+		{name: "missing a maximum", input: "P0,1,0,3\navailable,1,2\n", wantErr: banker.ErrInvalidState},
+		{name: "short row", input: "P0,1,0,3,3\nP1,0,1\navailable,1,2\n", wantErr: banker.ErrInvalidState},
+		{name: "not a number", input: "P0,1,x\navailable,1\n", wantErr: banker.ErrInvalidState},
+		{name: "process named twice", input: "P0,1,3\nP0,0,1\navailable,1\n", wantErr: banker.ErrInvalidState},
+		{name: "header missing a maximum", input: "process,alloc_A,max_B\nP0,1,3\navailable,1\n", wantErr: banker.ErrInvalidState},
+		{name: "unknown header column", input: "process,alloc_A,need_A\nP0,1,3\navailable,1\n", wantErr: banker.ErrInvalidState},
+		{name: "holds more than its maximum", input: "P0,4,3\navailable,1\n", wantErr: banker.ErrInvalidState},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadBankerState(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadBankerState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadBankerState() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runBanker(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	if err := runBanker([]string{"-request", "P1:1,0,2; P4:3,3,0; P0:0,2,0; 3:1,0,0", "example_banker.csv"}, &b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"| P4        | 0 0 2              | 4 3 3       | 4 3 1        |",
+		"| 5 | P4      | 10 5 5 | 4 3 1 | 10 5 7     |",
+		"Safe sequence: P1, P3, P0, P2, P4\n",
+		"| 1 | P1      | 1 0 2   | granted             | 2 3 0           | Safe sequence: P1, P3, P0, P2, P4       |",
+		"| 2 | P4      | 3 3 0   | wait: not available | 2 3 0           |",
+		"| 3 | P0      | 0 2 0   | wait: unsafe        | 2 3 0           | Unsafe: P0, P1, P2, P3, P4 can't finish |",
+		"| 4 | P3      | 1 0 0   | exceeds claim       | 2 3 0           |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runBanker() output is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func Test_parseBankerRequests(t *testing.T) {
+	t.Parallel()
+	state := banker.State{Processes: []string{"P0", "P1"}, Resources: []string{"A", "B"}}
+	tests := []struct {
+		name    string
+		list    string
+		want    []bankerRequest
+		wantErr error
+	}{
+		{name: "none", list: ""},
+		{
+			name: "by name and index",
+			list: "P1:1,0; 0: 0, 2;",
+			want: []bankerRequest{{process: 1, request: []int64{1, 0}}, {process: 0, request: []int64{0, 2}}},
+		},
+		{name: "no colon", list: "P1 1,0", wantErr: ErrInvalidArgs},
+		{name: "unknown process", list: "P2:1,0", wantErr: ErrInvalidArgs},
+		{name: "index out of range", list: "2:1,0", wantErr: ErrInvalidArgs},
+		{name: "wrong number of resources", list: "P0:1", wantErr: ErrInvalidArgs},
+		{name: "negative amount", list: "P0:1,-1", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseBankerRequests(tt.list, state)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseBankerRequests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBankerRequests() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
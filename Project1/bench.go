@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// benchMetrics are the statistics compared by the bench subcommand, in table order.
+var benchMetrics = []string{"wait", "turnaround", "throughput"}
+
+// benchHigherIsBetter marks the benchMetrics where a higher value is better.
+var benchHigherIsBetter = []bool{false, false, true}
+
+// benchSummary is the mean, standard deviation and 95% confidence interval of each metric of an algorithm over a
+// benchmark's trials, and how it compares with the best algorithm by that metric.
+type benchSummary struct {
+	Algorithm string
+	Title     string
+	Mean      []float64
+	StdDev    []float64
+	// CI is the half-width of the 95% confidence interval for each metric's mean.
+	CI []float64
+	// Best marks the metrics the algorithm had the best mean of.
+	Best []bool
+	// Diff is how much worse each metric's mean was than the best algorithm's, over the same workloads, and DiffCI
+	// the half-width of the difference's 95% confidence interval. The algorithm is significantly worse when the
+	// interval excludes zero, i.e. when Diff is larger than DiffCI.
+	Diff   []float64
+	DiffCI []float64
+}
+
+// significantlyWorse reports whether the algorithm's mean of metric m was significantly worse than the best.
+func (s benchSummary) significantlyWorse(m int) bool {
+	return s.Diff[m] > s.DiffCI[m]
+}
+
+//region Benchmarking
+
+// runBench runs the bench subcommand: it generates -trials random workloads, schedules each under every
+// algorithm and writes the mean and 95% confidence interval of the average wait, turnaround and throughput of
+// each algorithm to w, and a paired comparison of each algorithm with the best one by each metric.
+func runBench(args []string, w io.Writer) error {
+	var (
+		fs       = flag.NewFlagSet("bench", flag.ContinueOnError)
+		cfg      generateConfig
+		s        = settings{Aging: 5}
+		priority string
+		trials   int
+		format   string
+	)
+	cfg.flags(fs, &priority)
+	fs.IntVar(&trials, "trials", 20, "number of random workloads to run")
+	fs.Int64Var(&s.Seed, "seed", 1, "random seed, to reproduce a benchmark")
+	fs.Int64Var(&s.Quantum, "quantum", 1, "Round-Robin time quantum, also the time between Lottery draws and Stride dispatches")
+	fs.IntVar(&s.Cores, "cores", 1, "number of CPUs")
+	fs.Int64Var(&s.SwitchCost, "switch-cost", 0, "context switch overhead")
+	fs.StringVar(&s.TieBreak, "tie-break", scheduler.TieFIFOName, "tie-break policy, fifo or arrival")
+	fs.StringVar(&format, "output", outputText, "report format, text or csv")
+	fs.Func("algo", "comma separated algorithms to compare, built-in or custom; the default set if empty", func(list string) error {
+		s.Algorithms = parseAlgorithms(list)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if err := cfg.parsePriority(priority); err != nil {
+		return err
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+	if format != outputText && format != outputCSV {
+		return fmt.Errorf("%w: unknown output format %q", ErrInvalidArgs, format)
+	}
+	summaries, err := bench(cfg, s, trials)
+	if err != nil {
+		return err
+	}
+
+	if format == outputCSV {
+		return outputBenchCSV(w, summaries)
+	}
+	outputBench(w, summaries, trials)
+
+	return nil
+}
+
+// bench schedules trials workloads, drawn from cfg with the seed in s, under each of the algorithms in s, or the
+// default ones, in parallel, and summarizes each algorithm's results. Each trial gets its own seed for the
+// randomized schedulers, drawn from the same seed, so their luck is sampled along with the workloads.
+func bench(cfg generateConfig, s settings, trials int) ([]benchSummary, error) {
+	if trials < 2 {
+		return nil, fmt.Errorf("%w: trials must be at least 2, for confidence intervals", ErrInvalidArgs)
+	}
+	algorithms := s.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms(nil, s)
+	}
+	var (
+		rng = rand.New(rand.NewSource(s.Seed))
+		// samples holds each algorithm's samples of each metric.
+		samples   = make([][][]float64, len(algorithms))
+		summaries = make([]benchSummary, len(algorithms))
+	)
+	for i, name := range algorithms {
+		samples[i] = make([][]float64, len(benchMetrics))
+		// Titled with the benchmark's seed, which reproduces every trial's.
+		title, _, err := algorithm(name, s)
+		if err != nil {
+			return nil, err
+		}
+		summaries[i].Algorithm, summaries[i].Title = name, title
+	}
+	trial := s
+	for n := 0; n < trials; n++ {
+		processes, err := generateWorkload(cfg, rng)
+		if err != nil {
+			return nil, err
+		}
+		trial.Seed = rng.Int63()
+		schedules, err := scheduleAll(processes, algorithms, trial)
+		if err != nil {
+			return nil, err
+		}
+		for i, sc := range schedules {
+			stats := sc.result.Stats
+			for m, v := range []float64{stats.AveWait, stats.AveTurnaround, stats.AveThroughput} {
+				samples[i][m] = append(samples[i][m], v)
+			}
+		}
+	}
+	for i := range summaries {
+		for _, values := range samples[i] {
+			mean, sd := meanStdDev(values)
+			summaries[i].Mean = append(summaries[i].Mean, mean)
+			summaries[i].StdDev = append(summaries[i].StdDev, sd)
+			summaries[i].CI = append(summaries[i].CI, confidence95(sd, len(values)))
+		}
+	}
+	for m := range benchMetrics {
+		best := 0
+		for i := range summaries {
+			if better(summaries[i].Mean[m], summaries[best].Mean[m], benchHigherIsBetter[m]) {
+				best = i
+			}
+		}
+		// Every algorithm ran the same workloads, so each is compared with the best a workload at a time.
+		for i := range summaries {
+			diffs := make([]float64, trials)
+			for n := range diffs {
+				diffs[n] = samples[i][m][n] - samples[best][m][n]
+				if benchHigherIsBetter[m] {
+					diffs[n] = -diffs[n]
+				}
+			}
+			mean, sd := meanStdDev(diffs)
+			summaries[i].Best = append(summaries[i].Best, i == best)
+			summaries[i].Diff = append(summaries[i].Diff, mean)
+			summaries[i].DiffCI = append(summaries[i].DiffCI, confidence95(sd, trials))
+		}
+	}
+
+	return summaries, nil
+}
+
+// better reports whether a is a better value of a metric than b.
+func better(a, b float64, higherIsBetter bool) bool {
+	if higherIsBetter {
+		return a > b
+	}
+	return a < b
+}
+
+// meanStdDev returns the mean and sample standard deviation of values, the deviation being 0 for fewer than two.
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+// tTable holds the two-sided 95% critical values of Student's t distribution for 1 to 30 degrees of freedom.
+var tTable = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical95 returns the two-sided 95% critical value of Student's t distribution with df degrees of freedom,
+// from the table up to 30 and by the Cornish-Fisher expansion around the normal distribution's 1.96 beyond.
+func tCritical95(df int) float64 {
+	if df <= len(tTable) {
+		return tTable[df-1]
+	}
+	z, n := 1.959964, float64(df)
+
+	return z + (z*z*z+z)/(4*n) + (5*math.Pow(z, 5)+16*z*z*z+3*z)/(96*n*n)
+}
+
+// confidence95 returns the half-width of the 95% confidence interval for the mean of n samples with standard
+// deviation sd.
+func confidence95(sd float64, n int) float64 {
+	return tCritical95(n-1) * sd / math.Sqrt(float64(n))
+}
+
+func outputBench(w io.Writer, summaries []benchSummary, trials int) {
+	_, _ = fmt.Fprintf(w, "Mean and 95%% confidence interval over %d random workloads\n", trials)
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Algorithm", "Wait", "Turnaround", "Throughput"})
+	for _, s := range summaries {
+		row := []string{s.Title}
+		for m := range benchMetrics {
+			row = append(row, fmt.Sprintf("%.2f ± %.2f", s.Mean[m], s.CI[m]))
+		}
+		table.Append(row)
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintln(w, "\nHow much worse than the best algorithm, workload by workload (* significant at 95%)")
+	table = tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Algorithm", "Wait", "Turnaround", "Throughput"})
+	for _, s := range summaries {
+		row := []string{s.Title}
+		for m := range benchMetrics {
+			switch {
+			case s.Best[m]:
+				row = append(row, "best")
+			case s.significantlyWorse(m):
+				row = append(row, fmt.Sprintf("%.2f ± %.2f *", s.Diff[m], s.DiffCI[m]))
+			default:
+				row = append(row, fmt.Sprintf("%.2f ± %.2f", s.Diff[m], s.DiffCI[m]))
+			}
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// outputBenchCSV writes a row per algorithm with the mean, standard deviation and confidence interval
+// half-width of each metric, and how much worse it was than the best algorithm, with that difference's
+// confidence interval half-width.
+func outputBenchCSV(w io.Writer, summaries []benchSummary) error {
+	cw := csv.NewWriter(w)
+	header := []string{"algorithm"}
+	for _, m := range benchMetrics {
+		header = append(header, m+"_mean", m+"_stddev", m+"_ci95", m+"_vs_best", m+"_vs_best_ci95")
+	}
+	_ = cw.Write(header)
+	for _, s := range summaries {
+		row := []string{s.Algorithm}
+		for m := range benchMetrics {
+			for _, v := range []float64{s.Mean[m], s.StdDev[m], s.CI[m], s.Diff[m], s.DiffCI[m]} {
+				row = append(row, strconv.FormatFloat(v, 'f', 4, 64))
+			}
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+//endregion
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_meanStdDev(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		values     []float64
+		wantMean   float64
+		wantStdDev float64
+	}{
+		{name: "one value", values: []float64{3}, wantMean: 3},
+		{name: "sample deviation", values: []float64{2, 4, 4, 4, 5, 5, 7, 9}, wantMean: 5, wantStdDev: math.Sqrt(32.0 / 7)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mean, sd := meanStdDev(tt.values)
+			if mean != tt.wantMean || math.Abs(sd-tt.wantStdDev) > 1e-9 {
+				t.Errorf("meanStdDev() = %v, %v, want %v, %v", mean, sd, tt.wantMean, tt.wantStdDev)
+			}
+		})
+	}
+}
+
+func Test_tCritical95(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		df   int
+		want float64
+	}{
+		{df: 1, want: 12.706},
+		{df: 10, want: 2.228},
+		{df: 30, want: 2.042},
+		{df: 60, want: 2.000},
+		{df: 120, want: 1.980},
+	} {
+		if got := tCritical95(tt.df); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("tCritical95(%d) = %v, want %v", tt.df, got, tt.want)
+		}
+	}
+}
+
+func Test_bench(t *testing.T) {
+	t.Parallel()
+	cfg := generateConfig{Count: 20, Arrivals: distPoisson, MeanArrival: 3, Bursts: distExponential, MeanBurst: 5, MinPriority: 1, MaxPriority: 10}
+	s := settings{Quantum: 2, Cores: 1, Aging: 5, Seed: 7}
+
+	got, err := bench(cfg, s, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(defaultAlgorithms(nil, s)) {
+		t.Fatalf("got %d summaries, want one per default algorithm", len(got))
+	}
+	for _, summary := range got {
+		for _, values := range [][]float64{summary.Mean, summary.StdDev, summary.CI, summary.Diff, summary.DiffCI} {
+			if len(values) != len(benchMetrics) {
+				t.Fatalf("%s summary = %+v, want every statistic of every metric", summary.Algorithm, summary)
+			}
+		}
+	}
+	// SRTF minimizes the average wait of every workload, so it does on average, and FCFS waits significantly
+	// longer.
+	fcfs, srtf := got[0], got[2]
+	if fcfs.Algorithm != algoFCFS || srtf.Algorithm != algoSRTF || !srtf.Best[0] || srtf.Diff[0] != 0 {
+		t.Errorf("SRTF = %+v, want the best wait", srtf)
+	}
+	if !fcfs.significantlyWorse(0) || fcfs.Diff[0] != fcfs.Mean[0]-srtf.Mean[0] {
+		t.Errorf("FCFS = %+v, want a significantly worse wait than SRTF's %v", fcfs, srtf.Mean[0])
+	}
+	if again, _ := bench(cfg, s, 5); !reflect.DeepEqual(again, got) {
+		t.Errorf("bench() with the same seed = %+v, want %+v", again, got)
+	}
+	if _, err := bench(cfg, s, 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("bench() of no trials error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_runBench(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := runBench([]string{"-trials", "3", "-n", "5", "-seed", "1", "-output", "csv"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rows[0][:6], []string{"algorithm", "wait_mean", "wait_stddev", "wait_ci95", "wait_vs_best", "wait_vs_best_ci95"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("header = %v, want %v...", rows[0], want)
+	}
+	if len(rows[0]) != 1+5*len(benchMetrics) {
+		t.Errorf("header = %v, want 5 columns per metric", rows[0])
+	}
+	out.Reset()
+	if err := runBench([]string{"-trials", "2", "-n", "5", "-seed", "1", "-output", "csv", "-algo", "fcfs,rr"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if rows, _ := csv.NewReader(&out).ReadAll(); len(rows) != 3 || rows[1][0] != algoFCFS || rows[2][0] != algoRoundRobin {
+		t.Errorf("rows = %v, want FCFS and Round-Robin only", rows)
+	}
+	if err := runBench([]string{"-output", "xml"}, &out); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+var ErrInvalidCgroup = errors.New("invalid cgroup")
+
+//region Loading cgroups.
+
+// loadCgroups reads cgroup definitions from CSV records of the form <Path>,<Quota>,<Period>.
+func loadCgroups(r io.Reader) ([]scheduler.Cgroup, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	groups := make([]scheduler.Cgroup, len(rows))
+	for i := range rows {
+		if len(rows[i]) != 3 {
+			return nil, fmt.Errorf("%w: line %d: expected path,quota,period", ErrInvalidCgroup, i+1)
+		}
+		groups[i].Path = scheduler.CleanCgroupPath(rows[i][0])
+		if groups[i].Quota, err = strconv.ParseInt(rows[i][1], 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCgroup, i+1, err)
+		}
+		if groups[i].Period, err = strconv.ParseInt(rows[i][2], 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCgroup, i+1, err)
+		}
+		if groups[i].Quota > 0 && groups[i].Period <= 0 {
+			return nil, fmt.Errorf("%w: line %d: a quota requires a positive period", ErrInvalidCgroup, i+1)
+		}
+	}
+
+	return groups, nil
+}
+
+//endregion
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadCgroups(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []scheduler.Cgroup
+		wantErr error
+	}{
+		{
+			name: "bad CSV",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "quota without period",
+			args: args{
+				r: strings.NewReader("/a,5,0"),
+			},
+			wantErr: ErrInvalidCgroup,
+		},
+		{
+			name: "bad quota",
+			args: args{
+				r: strings.NewReader("/a,x,10"),
+			},
+			wantErr: ErrInvalidCgroup,
+		},
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader("a/b/,5,10\n/c,0,0"),
+			},
+			want: []scheduler.Cgroup{
+				{Path: "/a/b", Quota: 5, Period: 10},
+				{Path: "/c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadCgroups(tt.args.r)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadCgroups() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// chromeEvent is an event of Chrome's trace event format, as read by chrome://tracing and Perfetto.
+type chromeEvent struct {
+	Name string `json:"name"`
+	Cat  string `json:"cat,omitempty"`
+	// Ph is the event's phase: X for a complete event (a slice), i for an instant, M for metadata.
+	Ph   string         `json:"ph"`
+	TS   int64          `json:"ts"`
+	Dur  int64          `json:"dur,omitempty"`
+	PID  int            `json:"pid"`
+	TID  int64          `json:"tid"`
+	S    string         `json:"s,omitempty"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+//region Chrome traces
+
+// chromeRenderer returns a renderer writing schedules as Chrome trace events, in the JSON array format. Each
+// algorithm's schedule gets two trace processes: one with a track per CPU, showing what ran on it, and one with a
+// track per process, showing where it ran, between markers for its arrival and completion. Time units show as
+// microseconds.
+//
+// The array is opened once per writer and left unterminated, which the format allows, so the schedules of
+// several algorithms written to the same output make one trace.
+func chromeRenderer() renderer {
+	algorithms := make(map[io.Writer]int)
+	return func(w io.Writer, name, title string, result scheduler.ScheduleResult) error {
+		n, ok := algorithms[w]
+		algorithms[w] = n + 1
+		if !ok {
+			_, _ = fmt.Fprint(w, "[\n")
+		}
+		enc := json.NewEncoder(w)
+		for i, e := range chromeEvents(name, title, n, result) {
+			if ok || i > 0 {
+				_, _ = fmt.Fprint(w, ",")
+			}
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// chromeEvents returns the trace events of the n-th algorithm's schedule written to a trace.
+func chromeEvents(name, title string, n int, result scheduler.ScheduleResult) []chromeEvent {
+	var (
+		cpuPID  = 2*n + 1
+		procPID = 2*n + 2
+		names   = processNames(result.Processes)
+		events  = []chromeEvent{
+			{Name: "process_name", Ph: "M", PID: cpuPID, Args: map[string]any{"name": title + ": CPUs"}},
+			{Name: "process_sort_index", Ph: "M", PID: cpuPID, Args: map[string]any{"sort_index": cpuPID}},
+			{Name: "process_name", Ph: "M", PID: procPID, Args: map[string]any{"name": title + ": processes"}},
+			{Name: "process_sort_index", Ph: "M", PID: procPID, Args: map[string]any{"sort_index": procPID}},
+		}
+		cpus  = make(map[int]bool)
+		procs = make(map[int64]bool)
+	)
+	for _, s := range result.Gantt {
+		if !cpus[s.CPU] {
+			cpus[s.CPU] = true
+			events = append(events, chromeEvent{
+				Name: "thread_name", Ph: "M", PID: cpuPID, TID: int64(s.CPU),
+				Args: map[string]any{"name": fmt.Sprintf("CPU %d", s.CPU)},
+			})
+		}
+	}
+	for _, p := range result.Processes {
+		if !procs[p.ProcessID] {
+			procs[p.ProcessID] = true
+			events = append(events, chromeEvent{
+				Name: "thread_name", Ph: "M", PID: procPID, TID: p.ProcessID,
+				Args: map[string]any{"name": "process " + label(p.ProcessID, names)},
+			})
+		}
+		events = append(events,
+			chromeEvent{Name: "arrival", Cat: name, Ph: "i", TS: p.ArrivalTime, PID: procPID, TID: p.ProcessID, S: "t"},
+			chromeEvent{
+				Name: "completion", Cat: name, Ph: "i", TS: p.Completion, PID: procPID, TID: p.ProcessID, S: "t",
+				Args: map[string]any{"wait": p.Wait, "response": p.Response, "turnaround": p.Turnaround},
+			},
+		)
+	}
+	for _, s := range result.Gantt {
+		text := label(s.PID, names)
+		if s.Switch {
+			events = append(events, chromeEvent{
+				Name: "context switch to " + text, Cat: name, Ph: "X", TS: s.Start, Dur: s.Stop - s.Start,
+				PID: cpuPID, TID: int64(s.CPU),
+			})
+			continue
+		}
+		events = append(events,
+			chromeEvent{
+				Name: text, Cat: name, Ph: "X", TS: s.Start, Dur: s.Stop - s.Start, PID: cpuPID, TID: int64(s.CPU),
+				Args: map[string]any{"pid": s.PID},
+			},
+			chromeEvent{
+				Name: fmt.Sprintf("CPU %d", s.CPU), Cat: name, Ph: "X", TS: s.Start, Dur: s.Stop - s.Start,
+				PID: procPID, TID: s.PID,
+			},
+		)
+	}
+
+	return events
+}
+
+//endregion
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_chromeRenderer(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 5},
+		{ProcessID: 2, BurstDuration: 3},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 1},
+	}
+	var (
+		fcfs   = scheduler.FCFS{}.Schedule(processes)
+		rr     = scheduler.RoundRobin{Quantum: 2, Cores: 2, SwitchCost: 1}.Schedule(processes)
+		render = chromeRenderer()
+		out    bytes.Buffer
+	)
+	if err := render(&out, algoFCFS, "First-come, first-serve", fcfs); err != nil {
+		t.Fatal(err)
+	}
+	if err := render(&out, algoRoundRobin, "Round-Robin", rr); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both schedules make one trace, once the array is closed.
+	var events []chromeEvent
+	if err := json.Unmarshal(append(out.Bytes(), ']'), &events); err != nil {
+		t.Fatalf("%v:\n%s", err, out.String())
+	}
+	type track struct {
+		pid int
+		tid int64
+	}
+	var (
+		tracks = make(map[track]string)
+		slices = make(map[int]int)
+	)
+	for _, e := range events {
+		switch e.Ph {
+		case "M":
+			if e.Name == "thread_name" {
+				tracks[track{e.PID, e.TID}] = e.Args["name"].(string)
+			}
+		case "X":
+			slices[e.PID]++
+			if _, ok := tracks[track{e.PID, e.TID}]; !ok {
+				t.Errorf("slice %q is on unnamed track %d/%d", e.Name, e.PID, e.TID)
+			}
+		}
+	}
+	for _, tt := range []struct {
+		track track
+		want  string
+	}{
+		{track: track{1, 0}, want: "CPU 0"},
+		{track: track{2, 1}, want: "process editor"},
+		{track: track{2, 3}, want: "process 3"},
+		{track: track{3, 1}, want: "CPU 1"},
+		{track: track{4, 2}, want: "process 2"},
+	} {
+		if got := tracks[tt.track]; got != tt.want {
+			t.Errorf("track %v = %q, want %q", tt.track, got, tt.want)
+		}
+	}
+	var switches int
+	for _, s := range rr.Gantt {
+		if s.Switch {
+			switches++
+		}
+	}
+	// Every slice is on its CPU's track, and all but context switches are on their process's track too.
+	for pid, want := range map[int]int{
+		1: len(fcfs.Gantt), 2: len(fcfs.Gantt),
+		3: len(rr.Gantt), 4: len(rr.Gantt) - switches,
+	} {
+		if slices[pid] != want {
+			t.Errorf("trace process %d has %d slices, want %d", pid, slices[pid], want)
+		}
+	}
+}
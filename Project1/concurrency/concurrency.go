@@ -0,0 +1,595 @@
+// Package concurrency simulates classic synchronization problems: threads that take turns doing work of their own
+// and entering a critical section guarded by semaphores or locks, in simulated time, counting how often each
+// completes an operation, how long it waits for the locks, and detecting deadlock, when every thread is waiting.
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// Scenarios, the synchronization problems that can be simulated.
+const (
+	// BoundedBuffer has producers put items in a buffer of limited capacity and consumers take them out.
+	BoundedBuffer = "bounded-buffer"
+	// ReadersWriters has readers that can share a database with each other, and writers that need it to
+	// themselves.
+	ReadersWriters = "readers-writers"
+	// DiningPhilosophers has philosophers around a table, each needing the forks either side of them to eat.
+	DiningPhilosophers = "philosophers"
+)
+
+// Scenarios lists the scenarios.
+var Scenarios = []string{BoundedBuffer, ReadersWriters, DiningPhilosophers}
+
+// Strategies, the ways each scenario's threads synchronize.
+const (
+	// Semaphores is the textbook bounded buffer: wait for a slot, empty or full, before locking the buffer.
+	Semaphores = "semaphores"
+	// MutexFirst locks the buffer before waiting for a slot, which deadlocks once a thread waits for a slot with
+	// the buffer locked.
+	MutexFirst = "mutex-first"
+	// ReadersFirst lets readers in whenever no writer is writing, which can starve writers.
+	ReadersFirst = "readers"
+	// WritersFirst keeps new readers out while a writer waits, which can starve readers.
+	WritersFirst = "writers"
+	// Fair lets readers and writers in in the order they arrive, readers together when they're next in line.
+	Fair = "fair"
+	// Naive philosophers pick up their left fork then their right, which deadlocks if they all pick up their
+	// left fork at once.
+	Naive = "naive"
+	// Ordered philosophers pick up the lower numbered of their forks first, which breaks the cycle of waiting.
+	Ordered = "ordered"
+	// Waiter philosophers ask a waiter for a seat first, who seats all but one of them at a time.
+	Waiter = "waiter"
+)
+
+// Strategies lists the strategies of each scenario, the first being the default.
+var Strategies = map[string][]string{
+	BoundedBuffer:      {Semaphores, MutexFirst},
+	ReadersWriters:     {ReadersFirst, WritersFirst, Fair},
+	DiningPhilosophers: {Ordered, Naive, Waiter},
+}
+
+// Roles of threads.
+const (
+	Producer    = "producer"
+	Consumer    = "consumer"
+	Reader      = "reader"
+	Writer      = "writer"
+	Philosopher = "philosopher"
+)
+
+var (
+	ErrUnknownScenario = errors.New("unknown synchronization scenario")
+	ErrUnknownStrategy = errors.New("unknown synchronization strategy")
+	ErrInvalidConfig   = errors.New("invalid synchronization simulation")
+)
+
+type (
+	// Timing is a range of durations, from Min to Max inclusive, each activity taking one drawn at random.
+	Timing struct {
+		Min int64
+		Max int64
+	}
+	// Config describes a simulation: a scenario, its strategy, the threads of each role, and how long they
+	// spend working on their own and inside their critical sections.
+	Config struct {
+		Scenario string
+		// Strategy is how the threads synchronize, one of the scenario's Strategies; empty means the default.
+		Strategy string
+		// Producers, Consumers and Capacity, of the buffer, are for the bounded buffer.
+		Producers int
+		Consumers int
+		Capacity  int64
+		// Readers and Writers are for readers-writers.
+		Readers int
+		Writers int
+		// Philosophers is for the dining philosophers, who share as many forks.
+		Philosophers int
+		// Work is the time a thread spends between its critical sections: producing or consuming an item,
+		// reading or writing what it got, or thinking.
+		Work Timing
+		// Hold is the time a thread spends in its critical section: putting an item in the buffer or taking
+		// one out, reading or writing the database, or eating.
+		Hold Timing
+		// Duration is the time to simulate.
+		Duration int64
+		// Seed seeds the random durations, so the same config simulates the same run.
+		Seed int64
+	}
+	// Result is the outcome of a simulation.
+	Result struct {
+		// Threads holds the statistics of each thread, in the order they were created, by role.
+		Threads []ThreadStats
+		// Roles holds the statistics of each role.
+		Roles []RoleStats
+		// End is the time the simulation ended: its duration, or when it deadlocked.
+		End int64
+		// Deadlock is the deadlock the simulation ended in, or nil if it didn't.
+		Deadlock *Deadlock
+	}
+	// ThreadStats are the statistics of one thread.
+	ThreadStats struct {
+		Name string
+		Role string
+		// Operations is the number of times it completed its critical section: items produced or consumed,
+		// reads or writes, or meals.
+		Operations int
+		// TotalWait is the time it spent waiting for locks during the operations it completed.
+		TotalWait int64
+		// MaxWait is the longest it waited for the locks of one operation, including one it was still waiting
+		// for at the end, so that a starving thread shows.
+		MaxWait int64
+	}
+	// RoleStats are the statistics of the threads of one role.
+	RoleStats struct {
+		Role       string
+		Threads    int
+		Operations int
+		// Throughput is the operations completed per unit of time.
+		Throughput float64
+		// AveWait is the average wait of an operation.
+		AveWait float64
+		MaxWait int64
+	}
+	// Deadlock is a state in which every thread waits for a lock another holds, so none can ever go on.
+	Deadlock struct {
+		Time    int64
+		Threads []Blocked
+	}
+	// Blocked describes a thread that's waiting.
+	Blocked struct {
+		Thread string
+		// Holds names the locks the thread holds, e.g. fork1 or mutex.
+		Holds []string
+		// WaitsFor names the lock or semaphore the thread waits for.
+		WaitsFor string
+	}
+)
+
+// Check returns an error if c can't be simulated.
+func (c Config) Check() error {
+	strategies, ok := Strategies[c.Scenario]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownScenario, c.Scenario)
+	}
+	if c.Strategy != "" && !contains(strategies, c.Strategy) {
+		return fmt.Errorf("%w: %q for %s", ErrUnknownStrategy, c.Strategy, c.Scenario)
+	}
+	switch {
+	case c.Scenario == BoundedBuffer && (c.Producers < 1 || c.Consumers < 1 || c.Capacity < 1):
+		return fmt.Errorf("%w: want at least one producer, consumer and buffer slot", ErrInvalidConfig)
+	case c.Scenario == ReadersWriters && (c.Readers < 0 || c.Writers < 0 || c.Readers+c.Writers < 1):
+		return fmt.Errorf("%w: want at least one reader or writer", ErrInvalidConfig)
+	case c.Scenario == DiningPhilosophers && c.Philosophers < 2:
+		return fmt.Errorf("%w: want at least two philosophers", ErrInvalidConfig)
+	case c.Work.Min < 0 || c.Work.Max < c.Work.Min:
+		return fmt.Errorf("%w: work time %d to %d", ErrInvalidConfig, c.Work.Min, c.Work.Max)
+	case c.Hold.Min < 1 || c.Hold.Max < c.Hold.Min:
+		// Every operation takes time, so that time passes.
+		return fmt.Errorf("%w: hold time %d to %d, want at least 1", ErrInvalidConfig, c.Hold.Min, c.Hold.Max)
+	case c.Duration < 1:
+		return fmt.Errorf("%w: duration %d, want at least 1", ErrInvalidConfig, c.Duration)
+	}
+
+	return nil
+}
+
+// Simulate runs the simulation, until its duration or a deadlock.
+func Simulate(c Config) (Result, error) {
+	if err := c.Check(); err != nil {
+		return Result{}, err
+	}
+	if c.Strategy == "" {
+		c.Strategy = Strategies[c.Scenario][0]
+	}
+	s := newSimulation(c)
+	s.run()
+
+	return s.result(), nil
+}
+
+//region Threads and locks
+
+// stepKind is what a step of a thread does.
+type stepKind int
+
+const (
+	// work takes a Work time, outside the critical section.
+	work stepKind = iota
+	// hold takes a Hold time, inside the critical section.
+	hold
+	// acquire waits for a lock, or a semaphore to be above zero.
+	acquire
+	// release releases a lock, or signals a semaphore.
+	release
+	// complete counts an operation.
+	complete
+)
+
+// step is a step of the cycle a thread repeats.
+type step struct {
+	kind     stepKind
+	resource *resource
+	// write is whether a readers-writers lock is acquired or released for writing, rather than reading.
+	write bool
+}
+
+// thread states.
+const (
+	ready = iota
+	busy
+	blocked
+)
+
+type thread struct {
+	name  string
+	role  string
+	steps []step
+	pc    int
+	state int
+	// until is when a busy thread finishes its step.
+	until int64
+	// since is when a blocked thread started waiting.
+	since int64
+	// wait is the time the thread has waited during its current operation.
+	wait  int64
+	holds []*resource
+	stats ThreadStats
+}
+
+// resource is a semaphore, with count its value, or a readers-writers lock, with its readers and writer. Waiting
+// threads queue in the order they arrive.
+type resource struct {
+	name string
+	// lock is whether a thread that acquires the resource holds it until it releases it, as with a mutex or fork,
+	// rather than another thread signaling it, as with the bounded buffer's empty and full slots.
+	lock  bool
+	count int64
+	// policy is a readers-writers lock's strategy, or empty for a semaphore.
+	policy  string
+	readers int
+	writer  bool
+	queue   []waiter
+}
+
+// waiter is a thread waiting for a resource.
+type waiter struct {
+	thread *thread
+	write  bool
+}
+
+// grant lets in the waiting threads that can go on, removing them from the queue, and returns them.
+func (r *resource) grant() []*thread {
+	var granted []*thread
+	if r.policy == "" {
+		// A semaphore lets the threads in in order, while it's above zero.
+		for len(r.queue) > 0 && r.count > 0 {
+			r.count--
+			granted, r.queue = append(granted, r.queue[0].thread), r.queue[1:]
+		}
+		return granted
+	}
+
+	remaining := r.queue[:0]
+	writerWaiting := false
+	for i, w := range r.queue {
+		var ok bool
+		switch {
+		case r.policy == Fair && len(remaining) > 0:
+			// Nobody overtakes a thread that has to wait.
+		case w.write:
+			ok = !r.writer && r.readers == 0 && !(r.policy == WritersFirst && writerWaiting)
+		default:
+			ok = !r.writer && !(r.policy == WritersFirst && (writerWaiting || hasWriter(r.queue[i+1:])))
+		}
+		if !ok {
+			remaining = append(remaining, w)
+			writerWaiting = writerWaiting || w.write
+			continue
+		}
+		if w.write {
+			r.writer = true
+		} else {
+			r.readers++
+		}
+		granted = append(granted, w.thread)
+	}
+	r.queue = remaining
+
+	return granted
+}
+
+// release releases the resource, by a writer if write is set.
+func (r *resource) release(write bool) {
+	switch {
+	case r.policy == "":
+		r.count++
+	case write:
+		r.writer = false
+	default:
+		r.readers--
+	}
+}
+
+// hasWriter reports whether a writer is among the waiters.
+func hasWriter(waiters []waiter) bool {
+	for _, w := range waiters {
+		if w.write {
+			return true
+		}
+	}
+
+	return false
+}
+
+//endregion
+
+//region Simulating
+
+type simulation struct {
+	config    Config
+	rng       *rand.Rand
+	now       int64
+	threads   []*thread
+	resources []*resource
+	deadlock  *Deadlock
+}
+
+// newSimulation sets up the threads and resources of the config's scenario and strategy.
+func newSimulation(c Config) *simulation {
+	s := &simulation{config: c, rng: rand.New(rand.NewSource(c.Seed))}
+	add := func(role, prefix string, n int, steps func(i int) []step) {
+		for i := 1; i <= n; i++ {
+			s.threads = append(s.threads, &thread{
+				name:  fmt.Sprintf("%s%d", prefix, i),
+				role:  role,
+				steps: steps(i),
+				stats: ThreadStats{Name: fmt.Sprintf("%s%d", prefix, i), Role: role},
+			})
+		}
+	}
+	switch c.Scenario {
+	case BoundedBuffer:
+		var (
+			mutex = s.resource(&resource{name: "mutex", lock: true, count: 1})
+			empty = s.resource(&resource{name: "empty", count: c.Capacity})
+			full  = s.resource(&resource{name: "full"})
+		)
+		// Each thread waits for the semaphore of its slot and the mutex, in the strategy's order.
+		first, second := func(slot *resource) *resource { return slot }, func(*resource) *resource { return mutex }
+		if c.Strategy == MutexFirst {
+			first, second = second, first
+		}
+		add(Producer, "P", c.Producers, func(int) []step {
+			return []step{
+				{kind: work},
+				{kind: acquire, resource: first(empty)}, {kind: acquire, resource: second(empty)},
+				{kind: hold},
+				{kind: release, resource: mutex}, {kind: release, resource: full},
+				{kind: complete},
+			}
+		})
+		add(Consumer, "C", c.Consumers, func(int) []step {
+			return []step{
+				{kind: acquire, resource: first(full)}, {kind: acquire, resource: second(full)},
+				{kind: hold},
+				{kind: release, resource: mutex}, {kind: release, resource: empty},
+				{kind: complete},
+				{kind: work},
+			}
+		})
+	case ReadersWriters:
+		db := s.resource(&resource{name: "db", lock: true, policy: c.Strategy})
+		role := func(write bool) func(int) []step {
+			return func(int) []step {
+				return []step{
+					{kind: work},
+					{kind: acquire, resource: db, write: write},
+					{kind: hold},
+					{kind: release, resource: db, write: write},
+					{kind: complete},
+				}
+			}
+		}
+		add(Reader, "R", c.Readers, role(false))
+		add(Writer, "W", c.Writers, role(true))
+	case DiningPhilosophers:
+		n := c.Philosophers
+		forks := make([]*resource, n)
+		for i := range forks {
+			forks[i] = s.resource(&resource{name: fmt.Sprintf("fork%d", i+1), lock: true, count: 1})
+		}
+		var seat *resource
+		if c.Strategy == Waiter {
+			seat = s.resource(&resource{name: "waiter", count: int64(n - 1)})
+		}
+		add(Philosopher, "Ph", n, func(i int) []step {
+			// Philosopher i sits between fork i on their left and the next on their right.
+			left, right := forks[i-1], forks[i%n]
+			if c.Strategy == Ordered && i == n {
+				left, right = right, left
+			}
+			steps := []step{
+				{kind: work},
+				{kind: acquire, resource: left}, {kind: acquire, resource: right},
+				{kind: hold},
+				{kind: release, resource: right}, {kind: release, resource: left},
+				{kind: complete},
+			}
+			if seat != nil {
+				steps = append([]step{steps[0], {kind: acquire, resource: seat}}, steps[1:]...)
+				steps = append(steps[:len(steps)-1], step{kind: release, resource: seat}, step{kind: complete})
+			}
+			return steps
+		})
+	}
+
+	return s
+}
+
+// resource adds a resource to the simulation.
+func (s *simulation) resource(r *resource) *resource {
+	s.resources = append(s.resources, r)
+	return r
+}
+
+// run runs the threads from one time a step finishes to the next, until the duration or a deadlock.
+func (s *simulation) run() {
+	for {
+		for _, t := range s.threads {
+			if t.state == busy && t.until == s.now {
+				t.state = ready
+				t.pc = (t.pc + 1) % len(t.steps)
+			}
+		}
+		s.settle()
+
+		next := int64(-1)
+		for _, t := range s.threads {
+			if t.state == busy && (next < 0 || t.until < next) {
+				next = t.until
+			}
+		}
+		if next < 0 {
+			// Every thread is waiting, and only a thread that isn't could wake one.
+			s.deadlock = s.blocked()
+			return
+		}
+		if next > s.config.Duration {
+			s.now = s.config.Duration
+			return
+		}
+		s.now = next
+	}
+}
+
+// settle runs the ready threads, and lets in the waiting threads that can go on, until every thread is busy or
+// waiting.
+func (s *simulation) settle() {
+	for progress := true; progress; {
+		progress = false
+		for _, t := range s.threads {
+			if t.state == ready {
+				s.step(t)
+			}
+		}
+		for _, r := range s.resources {
+			for _, t := range r.grant() {
+				t.wait += s.now - t.since
+				t.state = ready
+				if r.lock {
+					t.holds = append(t.holds, r)
+				}
+				t.pc = (t.pc + 1) % len(t.steps)
+				progress = true
+			}
+		}
+	}
+}
+
+// step runs a ready thread's steps until it's busy or waiting.
+func (s *simulation) step(t *thread) {
+	for t.state == ready {
+		st := t.steps[t.pc]
+		switch st.kind {
+		case work, hold:
+			timing := s.config.Work
+			if st.kind == hold {
+				timing = s.config.Hold
+			}
+			if d := timing.Min + s.rng.Int63n(timing.Max-timing.Min+1); d > 0 {
+				t.state, t.until = busy, s.now+d
+				return
+			}
+		case acquire:
+			st.resource.queue = append(st.resource.queue, waiter{thread: t, write: st.write})
+			t.state, t.since = blocked, s.now
+			return
+		case release:
+			st.resource.release(st.write)
+			for i, r := range t.holds {
+				if r == st.resource {
+					t.holds = append(t.holds[:i], t.holds[i+1:]...)
+					break
+				}
+			}
+		case complete:
+			t.stats.Operations++
+			t.stats.TotalWait += t.wait
+			t.stats.MaxWait = max(t.stats.MaxWait, t.wait)
+			t.wait = 0
+		}
+		t.pc = (t.pc + 1) % len(t.steps)
+	}
+}
+
+// blocked describes the threads, which are all waiting.
+func (s *simulation) blocked() *Deadlock {
+	d := &Deadlock{Time: s.now}
+	for _, t := range s.threads {
+		b := Blocked{Thread: t.name, WaitsFor: t.steps[t.pc].resource.name}
+		for _, r := range t.holds {
+			b.Holds = append(b.Holds, r.name)
+		}
+		d.Threads = append(d.Threads, b)
+	}
+
+	return d
+}
+
+// result returns the statistics of the simulation.
+func (s *simulation) result() Result {
+	result := Result{End: s.now, Deadlock: s.deadlock}
+	for _, t := range s.threads {
+		stats := t.stats
+		if t.state == blocked {
+			stats.MaxWait = max(stats.MaxWait, t.wait+s.now-t.since)
+		}
+		result.Threads = append(result.Threads, stats)
+
+		if n := len(result.Roles); n == 0 || result.Roles[n-1].Role != t.role {
+			result.Roles = append(result.Roles, RoleStats{Role: t.role})
+		}
+		role := &result.Roles[len(result.Roles)-1]
+		role.Threads++
+		role.Operations += stats.Operations
+		role.AveWait += float64(stats.TotalWait)
+		role.MaxWait = max(role.MaxWait, stats.MaxWait)
+	}
+	for i := range result.Roles {
+		role := &result.Roles[i]
+		if role.Operations > 0 {
+			role.AveWait /= float64(role.Operations)
+		}
+		if result.End > 0 {
+			role.Throughput = float64(role.Operations) / float64(result.End)
+		}
+	}
+
+	return result
+}
+
+//endregion
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// max returns the larger of a and b.
+func max(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
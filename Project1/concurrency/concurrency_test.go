@@ -0,0 +1,173 @@
+package concurrency
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate_boundedBuffer(t *testing.T) {
+	t.Parallel()
+	c := Config{
+		Scenario:  BoundedBuffer,
+		Producers: 1,
+		Consumers: 1,
+		Capacity:  1,
+		Work:      Timing{Min: 1, Max: 1},
+		Hold:      Timing{Min: 1, Max: 1},
+		Duration:  10,
+	}
+	got, err := Simulate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The producer puts an item in every two time units from time 2, and the consumer, which waits for the first
+	// from time 0, takes each out the time unit after.
+	want := []ThreadStats{
+		{Name: "P1", Role: Producer, Operations: 5},
+		{Name: "C1", Role: Consumer, Operations: 4, TotalWait: 2, MaxWait: 2},
+	}
+	if !reflect.DeepEqual(got.Threads, want) || got.End != 10 || got.Deadlock != nil {
+		t.Errorf("Simulate() = %+v ending at %d with deadlock %v, want %+v", got.Threads, got.End, got.Deadlock, want)
+	}
+	if got.Roles[0].Throughput != 0.5 || got.Roles[1].AveWait != 0.5 {
+		t.Errorf("Simulate() roles = %+v", got.Roles)
+	}
+
+	// Locking the buffer before waiting for a slot deadlocks once the consumer waits for an item holding the lock.
+	c.Strategy = MutexFirst
+	got, err = Simulate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDeadlock := &Deadlock{Time: 1, Threads: []Blocked{
+		{Thread: "P1", WaitsFor: "mutex"},
+		{Thread: "C1", Holds: []string{"mutex"}, WaitsFor: "full"},
+	}}
+	if !reflect.DeepEqual(got.Deadlock, wantDeadlock) || got.End != 1 {
+		t.Errorf("Simulate(mutex-first) deadlock = %+v at %d, want %+v", got.Deadlock, got.End, wantDeadlock)
+	}
+	if got.Threads[1].MaxWait != 1 {
+		t.Errorf("Simulate(mutex-first) consumer's max wait = %d, want the wait still going", got.Threads[1].MaxWait)
+	}
+}
+
+func TestSimulate_philosophers(t *testing.T) {
+	t.Parallel()
+	c := Config{
+		Scenario:     DiningPhilosophers,
+		Philosophers: 3,
+		Work:         Timing{Min: 3, Max: 3},
+		Hold:         Timing{Min: 2, Max: 2},
+		Duration:     60,
+	}
+	for _, strategy := range []string{Ordered, Waiter} {
+		c.Strategy = strategy
+		got, err := Simulate(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Deadlock != nil || got.End != 60 || got.Roles[0].Operations == 0 {
+			t.Errorf("Simulate(%s) = %+v, want meals and no deadlock", strategy, got)
+		}
+	}
+
+	// Every philosopher picks up their left fork at once, after thinking for as long as the others.
+	c.Strategy = Naive
+	got, err := Simulate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Deadlock{Time: 3, Threads: []Blocked{
+		{Thread: "Ph1", Holds: []string{"fork1"}, WaitsFor: "fork2"},
+		{Thread: "Ph2", Holds: []string{"fork2"}, WaitsFor: "fork3"},
+		{Thread: "Ph3", Holds: []string{"fork3"}, WaitsFor: "fork1"},
+	}}
+	if !reflect.DeepEqual(got.Deadlock, want) {
+		t.Errorf("Simulate(naive) deadlock = %+v, want %+v", got.Deadlock, want)
+	}
+}
+
+func TestSimulate_readersWriters(t *testing.T) {
+	t.Parallel()
+	c := Config{
+		Scenario: ReadersWriters,
+		Readers:  4,
+		Writers:  2,
+		Work:     Timing{Min: 0, Max: 2},
+		Hold:     Timing{Min: 2, Max: 4},
+		Duration: 100,
+		Seed:     3,
+	}
+	results := make(map[string]Result)
+	for _, strategy := range Strategies[ReadersWriters] {
+		c.Strategy = strategy
+		r, err := Simulate(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Deadlock != nil || len(r.Roles) != 2 || r.Roles[0].Role != Reader || r.Roles[1].Role != Writer {
+			t.Fatalf("Simulate(%s) = %+v", strategy, r)
+		}
+		results[strategy] = r
+	}
+	readerWait := func(strategy string) int64 { return results[strategy].Roles[0].MaxWait }
+	writerWait := func(strategy string) int64 { return results[strategy].Roles[1].MaxWait }
+	// Each preference starves the other role, compared to letting them in in order.
+	if writerWait(ReadersFirst) <= writerWait(Fair) {
+		t.Errorf("writers waited up to %d preferring readers, want more than %d", writerWait(ReadersFirst), writerWait(Fair))
+	}
+	if readerWait(WritersFirst) <= readerWait(Fair) {
+		t.Errorf("readers waited up to %d preferring writers, want more than %d", readerWait(WritersFirst), readerWait(Fair))
+	}
+
+	again, _ := Simulate(c)
+	if !reflect.DeepEqual(again, results[c.Strategy]) {
+		t.Error("Simulate() with the same seed differs")
+	}
+}
+
+func TestConfig_Check(t *testing.T) {
+	t.Parallel()
+	valid := Config{
+		Scenario:  BoundedBuffer,
+		Producers: 1,
+		Consumers: 1,
+		Capacity:  1,
+		Work:      Timing{Min: 0, Max: 1},
+		Hold:      Timing{Min: 1, Max: 1},
+		Duration:  1,
+	}
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		wantErr error
+	}{
+		{name: "valid", change: func(*Config) {}},
+		{name: "unknown scenario", change: func(c *Config) { c.Scenario = "sleeping-barber" }, wantErr: ErrUnknownScenario},
+		{name: "strategy of another scenario", change: func(c *Config) { c.Strategy = Naive }, wantErr: ErrUnknownStrategy},
+		{name: "no producers", change: func(c *Config) { c.Producers = 0 }, wantErr: ErrInvalidConfig},
+		{name: "no capacity", change: func(c *Config) { c.Capacity = 0 }, wantErr: ErrInvalidConfig},
+		{name: "no readers or writers", change: func(c *Config) { c.Scenario = ReadersWriters }, wantErr: ErrInvalidConfig},
+		{name: "only writers", change: func(c *Config) { c.Scenario, c.Writers = ReadersWriters, 1 }},
+		{name: "one philosopher", change: func(c *Config) { c.Scenario, c.Philosophers = DiningPhilosophers, 1 }, wantErr: ErrInvalidConfig},
+		{name: "negative work", change: func(c *Config) { c.Work.Min = -1 }, wantErr: ErrInvalidConfig},
+		{name: "work backwards", change: func(c *Config) { c.Work = Timing{Min: 2, Max: 1} }, wantErr: ErrInvalidConfig},
+		{name: "no hold", change: func(c *Config) { c.Hold = Timing{} }, wantErr: ErrInvalidConfig},
+		{name: "no duration", change: func(c *Config) { c.Duration = 0 }, wantErr: ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := valid
+			tt.change(&c)
+			if err := c.Check(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := Simulate(c); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Simulate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package disk simulates disk scheduling: a queue of requests for cylinders is served by one head, in the order
+// chosen by the scheduling algorithm, and the cost of an order is the distance the head travels.
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scheduling algorithms, which pick the order requests are served in.
+const (
+	// FCFS serves requests in the order they're queued.
+	FCFS = "fcfs"
+	// SSTF serves the request nearest the head next, which can starve requests far from it.
+	SSTF = "sstf"
+	// SCAN sweeps the head to one end of the disk serving requests on the way, then back towards the other.
+	SCAN = "scan"
+	// CSCAN sweeps in one direction only: at the end of the disk the head returns to the other end without
+	// serving anything, and sweeps again.
+	CSCAN = "c-scan"
+	// LOOK sweeps like SCAN, but reverses at the last request in the direction of travel instead of the end.
+	LOOK = "look"
+)
+
+// Algorithms lists the scheduling algorithms.
+var Algorithms = []string{FCFS, SSTF, SCAN, CSCAN, LOOK}
+
+var (
+	ErrInvalidRequest   = errors.New("invalid disk request")
+	ErrUnknownAlgorithm = errors.New("unknown disk scheduling algorithm")
+)
+
+type (
+	// Move is a movement of the head from one cylinder to another.
+	Move struct {
+		From int64
+		To   int64
+		// Request is whether the head serves a request at To, rather than sweeping to the end of the disk.
+		Request bool
+		// Return is whether this is C-SCAN's return to the other end of the disk.
+		Return bool
+	}
+	// Result is the outcome of a request queue.
+	Result struct {
+		Moves []Move
+		// Order holds the requests in the order they were served.
+		Order []int64
+		Stats Stats
+	}
+	// Stats are the aggregate statistics of a request queue.
+	Stats struct {
+		Requests int
+		// Movement is the number of cylinders the head travelled, C-SCAN's returns included.
+		Movement int64
+		// AveSeek is the average movement per request.
+		AveSeek float64
+	}
+)
+
+// Drive serves requests for cylinders zero up to Cylinders with a head that starts at Head, moving towards
+// higher cylinders unless Down is set, in the order chosen by Algorithm; empty means FCFS.
+type Drive struct {
+	Cylinders int64
+	Head      int64
+	Down      bool
+	Algorithm string
+}
+
+// Simulate serves the requests and returns the head's movements. The sweeping algorithms only travel past the
+// last request in their direction, to the end of the disk, when there are requests left behind the head.
+func (d Drive) Simulate(requests []int64) Result {
+	var (
+		moves = make([]Move, 0, len(requests))
+		order = make([]int64, 0, len(requests))
+		stats = Stats{Requests: len(requests)}
+		head  = d.Head
+	)
+	for _, m := range d.schedule(requests) {
+		m.From = head
+		moves = append(moves, m)
+		if m.Request {
+			order = append(order, m.To)
+		}
+		stats.Movement += abs(m.To - m.From)
+		head = m.To
+	}
+	if stats.Requests > 0 {
+		stats.AveSeek = float64(stats.Movement) / float64(stats.Requests)
+	}
+
+	return Result{Moves: moves, Order: order, Stats: stats}
+}
+
+// schedule returns the moves that serve the requests under the drive's algorithm, without their From.
+func (d Drive) schedule(requests []int64) []Move {
+	serve := func(cylinders []int64) []Move {
+		moves := make([]Move, len(cylinders))
+		for i, c := range cylinders {
+			moves[i] = Move{To: c, Request: true}
+		}
+		return moves
+	}
+	switch d.Algorithm {
+	case SSTF:
+		return serve(d.nearestFirst(requests))
+	case SCAN, CSCAN, LOOK:
+		// ahead holds the requests in the direction of travel, nearest first, and behind the rest.
+		var ahead, behind []int64
+		for _, c := range requests {
+			if c == d.Head || (c > d.Head) != d.Down {
+				ahead = append(ahead, c)
+			} else {
+				behind = append(behind, c)
+			}
+		}
+		sortCylinders(ahead, d.Down)
+		moves := serve(ahead)
+		if len(behind) == 0 {
+			return moves
+		}
+		first, last := d.Cylinders-1, int64(0)
+		if d.Down {
+			first, last = last, first
+		}
+		switch d.Algorithm {
+		case SCAN:
+			if len(ahead) == 0 || ahead[len(ahead)-1] != first {
+				moves = append(moves, Move{To: first})
+			}
+			sortCylinders(behind, !d.Down)
+		case CSCAN:
+			if len(ahead) == 0 || ahead[len(ahead)-1] != first {
+				moves = append(moves, Move{To: first})
+			}
+			moves = append(moves, Move{To: last, Return: true})
+			sortCylinders(behind, d.Down)
+		default:
+			sortCylinders(behind, !d.Down)
+		}
+		return append(moves, serve(behind)...)
+	default:
+		return serve(requests)
+	}
+}
+
+// nearestFirst orders the requests by SSTF, each the nearest to the one before; ties go to the lower cylinder.
+func (d Drive) nearestFirst(requests []int64) []int64 {
+	var (
+		left  = append([]int64(nil), requests...)
+		order = make([]int64, 0, len(requests))
+		head  = d.Head
+	)
+	for len(left) > 0 {
+		next := 0
+		for i, c := range left {
+			if dist, best := abs(c-head), abs(left[next]-head); dist < best || dist == best && c < left[next] {
+				next = i
+			}
+		}
+		head = left[next]
+		order = append(order, head)
+		left = append(left[:next], left[next+1:]...)
+	}
+
+	return order
+}
+
+// sortCylinders sorts cylinders ascending, or descending when down is set.
+func sortCylinders(cylinders []int64, down bool) {
+	sort.Slice(cylinders, func(i, j int) bool {
+		if down {
+			return cylinders[i] > cylinders[j]
+		}
+		return cylinders[i] < cylinders[j]
+	})
+}
+
+// CheckAlgorithm returns an ErrUnknownAlgorithm error unless algorithm is a disk scheduling algorithm.
+func CheckAlgorithm(algorithm string) error {
+	for _, a := range Algorithms {
+		if algorithm == a {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q, want one of %s", ErrUnknownAlgorithm, algorithm, strings.Join(Algorithms, ", "))
+}
+
+// CheckRequests returns an ErrInvalidRequest error unless the drive has cylinders, its head starts on one and
+// every request is for one.
+func (d Drive) CheckRequests(requests []int64) error {
+	switch {
+	case d.Cylinders <= 0:
+		return fmt.Errorf("%w: the disk has no cylinders", ErrInvalidRequest)
+	case d.Head < 0 || d.Head >= d.Cylinders:
+		return fmt.Errorf("%w: the head starts at %d, outside cylinders 0 to %d", ErrInvalidRequest, d.Head, d.Cylinders-1)
+	}
+	for i, c := range requests {
+		if c < 0 || c >= d.Cylinders {
+			return fmt.Errorf("%w: request %d: cylinder %d is outside 0 to %d", ErrInvalidRequest, i+1, c, d.Cylinders-1)
+		}
+	}
+
+	return nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
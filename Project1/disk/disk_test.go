@@ -0,0 +1,163 @@
+package disk
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// queue is the textbook request queue, with the head at cylinder 53 of 200.
+var queue = []int64{98, 183, 37, 122, 14, 124, 65, 67}
+
+func TestDrive_Simulate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		drive        Drive
+		wantOrder    []int64
+		wantMovement int64
+	}{
+		{
+			name:         "FCFS",
+			drive:        Drive{Cylinders: 200, Head: 53, Algorithm: FCFS},
+			wantOrder:    queue,
+			wantMovement: 640,
+		},
+		{
+			name:         "SSTF",
+			drive:        Drive{Cylinders: 200, Head: 53, Algorithm: SSTF},
+			wantOrder:    []int64{65, 67, 37, 14, 98, 122, 124, 183},
+			wantMovement: 236,
+		},
+		{
+			name:         "SCAN down sweeps to cylinder 0 before reversing",
+			drive:        Drive{Cylinders: 200, Head: 53, Down: true, Algorithm: SCAN},
+			wantOrder:    []int64{37, 14, 65, 67, 98, 122, 124, 183},
+			wantMovement: 53 + 183,
+		},
+		{
+			name:         "SCAN up sweeps to the last cylinder before reversing",
+			drive:        Drive{Cylinders: 200, Head: 53, Algorithm: SCAN},
+			wantOrder:    []int64{65, 67, 98, 122, 124, 183, 37, 14},
+			wantMovement: 146 + 185,
+		},
+		{
+			name:         "C-SCAN returns to cylinder 0 and sweeps up again",
+			drive:        Drive{Cylinders: 200, Head: 53, Algorithm: CSCAN},
+			wantOrder:    []int64{65, 67, 98, 122, 124, 183, 14, 37},
+			wantMovement: 146 + 199 + 37,
+		},
+		{
+			name:         "LOOK reverses at the last request",
+			drive:        Drive{Cylinders: 200, Head: 53, Algorithm: LOOK},
+			wantOrder:    []int64{65, 67, 98, 122, 124, 183, 37, 14},
+			wantMovement: 130 + 169,
+		},
+		{
+			name:         "LOOK down",
+			drive:        Drive{Cylinders: 200, Head: 53, Down: true, Algorithm: LOOK},
+			wantOrder:    []int64{37, 14, 65, 67, 98, 122, 124, 183},
+			wantMovement: 39 + 169,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.drive.Simulate(queue)
+			if !reflect.DeepEqual(got.Order, tt.wantOrder) {
+				t.Errorf("Simulate() order = %v, want %v", got.Order, tt.wantOrder)
+			}
+			if got.Stats.Movement != tt.wantMovement {
+				t.Errorf("Simulate() movement = %d, want %d", got.Stats.Movement, tt.wantMovement)
+			}
+			if want := float64(tt.wantMovement) / float64(len(queue)); got.Stats.AveSeek != want {
+				t.Errorf("Simulate() average seek = %v, want %v", got.Stats.AveSeek, want)
+			}
+		})
+	}
+}
+
+func TestDrive_Simulate_moves(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		drive    Drive
+		requests []int64
+		want     []Move
+	}{
+		{
+			name:     "C-SCAN down returns to the last cylinder",
+			drive:    Drive{Cylinders: 10, Head: 5, Down: true, Algorithm: CSCAN},
+			requests: []int64{7, 2},
+			want: []Move{
+				{From: 5, To: 2, Request: true},
+				{From: 2, To: 0},
+				{From: 0, To: 9, Return: true},
+				{From: 9, To: 7, Request: true},
+			},
+		},
+		{
+			name:     "SCAN doesn't sweep to the end with nothing behind the head",
+			drive:    Drive{Cylinders: 10, Head: 5, Algorithm: SCAN},
+			requests: []int64{5, 8},
+			want:     []Move{{From: 5, To: 5, Request: true}, {From: 5, To: 8, Request: true}},
+		},
+		{
+			name:     "SCAN doesn't sweep past a request at the end",
+			drive:    Drive{Cylinders: 10, Head: 5, Algorithm: SCAN},
+			requests: []int64{9, 1},
+			want:     []Move{{From: 5, To: 9, Request: true}, {From: 9, To: 1, Request: true}},
+		},
+		{
+			name:     "SSTF ties go to the lower cylinder",
+			drive:    Drive{Cylinders: 10, Head: 5, Algorithm: SSTF},
+			requests: []int64{7, 3},
+			want:     []Move{{From: 5, To: 3, Request: true}, {From: 3, To: 7, Request: true}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.drive.Simulate(tt.requests); !reflect.DeepEqual(got.Moves, tt.want) {
+				t.Errorf("Simulate() moves = %+v, want %+v", got.Moves, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrive_CheckRequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		drive   Drive
+		wantErr error
+	}{
+		{name: "success", drive: Drive{Cylinders: 200, Head: 53}},
+		{name: "no cylinders", drive: Drive{}, wantErr: ErrInvalidRequest},
+		{name: "head off the disk", drive: Drive{Cylinders: 200, Head: 200}, wantErr: ErrInvalidRequest},
+		{name: "request off the disk", drive: Drive{Cylinders: 150, Head: 53}, wantErr: ErrInvalidRequest},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.drive.CheckRequests(queue); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckRequests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckAlgorithm(t *testing.T) {
+	t.Parallel()
+	for _, algorithm := range Algorithms {
+		if err := CheckAlgorithm(algorithm); err != nil {
+			t.Errorf("CheckAlgorithm(%q) error = %v", algorithm, err)
+		}
+	}
+	if err := CheckAlgorithm("c-look"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("CheckAlgorithm(\"c-look\") error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
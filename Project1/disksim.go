@@ -0,0 +1,190 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/disk"
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// diskTitles holds the report title of each disk scheduling algorithm.
+var diskTitles = map[string]string{
+	disk.FCFS:  "FCFS",
+	disk.SSTF:  "SSTF",
+	disk.SCAN:  "SCAN",
+	disk.CSCAN: "C-SCAN",
+	disk.LOOK:  "LOOK",
+}
+
+// Head directions.
+const (
+	directionUp   = "up"
+	directionDown = "down"
+)
+
+//region Simulating disk scheduling
+
+// runDisksim runs the disksim subcommand: it serves a queue of cylinder requests, given with -requests or in a
+// file, under each disk scheduling algorithm, and writes a timeline of the head's movements, a table of them and
+// the total head movement of each to w, followed by a comparison of the algorithms when there are several.
+func runDisksim(args []string, w io.Writer) error {
+	var (
+		fs         = flag.NewFlagSet("disksim", flag.ContinueOnError)
+		cylinders  int64
+		head       int64
+		direction  string
+		algorithms string
+		queue      string
+		format     string
+		color      string
+	)
+	fs.Int64Var(&cylinders, "cylinders", 200, "number of cylinders, numbered from 0")
+	fs.Int64Var(&head, "head", 0, "cylinder the head starts at")
+	fs.StringVar(&direction, "direction", directionUp, "direction the head starts moving in, up or down, for the sweeping algorithms")
+	fs.StringVar(&algorithms, "algo", strings.Join(disk.Algorithms, ","), "comma separated algorithms to compare, fcfs, sstf, scan, c-scan or look")
+	fs.StringVar(&queue, "requests", "", "request queue of cylinders separated by commas or spaces, instead of a file")
+	fs.StringVar(&format, "format", "", "request file format, csv or json; detected from the file's extension if empty")
+	fs.StringVar(&color, "color", colorAuto, "color the timeline, auto (when writing to a terminal), always or never")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if direction != directionUp && direction != directionDown {
+		return fmt.Errorf("%w: direction %q, want up or down", ErrInvalidArgs, direction)
+	}
+	names := parseAlgorithms(algorithms)
+	for _, algorithm := range names {
+		if err := disk.CheckAlgorithm(algorithm); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+	}
+	requests, err := diskRequests(queue, format, fs.Args())
+	if err != nil {
+		return err
+	}
+	drive := disk.Drive{Cylinders: cylinders, Head: head, Down: direction == directionDown}
+	if err := drive.CheckRequests(requests); err != nil {
+		return err
+	}
+
+	results := make([]disk.Result, len(names))
+	for i, algorithm := range names {
+		drive.Algorithm = algorithm
+		results[i] = drive.Simulate(requests)
+		title := fmt.Sprintf("%s (head at %d of %d cylinders)", diskTitles[algorithm], head, cylinders)
+		outputDisk(w, title, results[i], useColor(color, w))
+	}
+	if len(names) > 1 {
+		outputDiskComparison(w, names, results)
+	}
+
+	return nil
+}
+
+// diskRequests returns the request queue given with -requests, or else read from the file named in args.
+func diskRequests(queue, format string, args []string) ([]int64, error) {
+	if queue != "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%w: give a request queue with -requests or a file, not both", ErrInvalidArgs)
+		}
+		return loadNumbers(strings.NewReader(queue), formatCSV, "cylinder", disk.ErrInvalidRequest)
+	}
+	f, closeFile, err := openProcessingFile(append([]string{"disksim"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+	if format, err = workloadFormat(format, f.Name()); err != nil {
+		return nil, err
+	}
+
+	return loadNumbers(f, format, "cylinder", disk.ErrInvalidRequest)
+}
+
+//endregion
+
+//region Disk output
+
+// outputDisk outputs the outcome of a disk scheduling algorithm as a timeline of the head's movements, each bar
+// as wide as the cylinders it crosses and labeled with the cylinder it reaches, a table of the movements, and
+// the order the requests were served in and total head movement.
+func outputDisk(w io.Writer, title string, result disk.Result, colored bool) {
+	var (
+		slices = make([]scheduler.TimeSlice, 0, len(result.Moves))
+		names  = make(map[int64]string, len(result.Moves))
+		total  int64
+	)
+	for i, m := range result.Moves {
+		seek := m.To - m.From
+		if seek < 0 {
+			seek = -seek
+		}
+		id := int64(i + 1)
+		names[id] = strconv.FormatInt(m.To, 10)
+		if m.Return {
+			names[id] = "return"
+		}
+		slices = append(slices, scheduler.TimeSlice{PID: id, Start: total, Stop: total + seek})
+		total += seek
+	}
+	outputTitle(w, title)
+	_, _ = fmt.Fprintln(w, "Head movement")
+	outputGanttRow(w, slices, names, ganttColumns(total), ganttGap{}, colored)
+
+	_, _ = fmt.Fprintln(w, "Seek table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"#", "From", "To", "Seek", "Total", "Note"})
+	for i, m := range result.Moves {
+		var note string
+		switch {
+		case m.Return:
+			note = "return"
+		case !m.Request:
+			note = "end of disk"
+		}
+		table.Append([]string{
+			strconv.Itoa(i + 1),
+			strconv.FormatInt(m.From, 10),
+			strconv.FormatInt(m.To, 10),
+			strconv.FormatInt(slices[i].Stop-slices[i].Start, 10),
+			strconv.FormatInt(slices[i].Stop, 10),
+			note,
+		})
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintf(w, "Service order: %s\n", joinInts(result.Order))
+	_, _ = fmt.Fprintf(w, "Total head movement: %d  Average seek: %.2f\n\n", result.Stats.Movement, result.Stats.AveSeek)
+}
+
+// outputDiskComparison outputs a table comparing the head movement of the disk scheduling algorithms.
+func outputDiskComparison(w io.Writer, algorithms []string, results []disk.Result) {
+	outputTitle(w, "Disk scheduling algorithms")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Head Movement", "Average Seek"})
+	for i, r := range results {
+		table.Append([]string{
+			algorithms[i],
+			strconv.FormatInt(r.Stats.Movement, 10),
+			fmt.Sprintf("%.2f", r.Stats.AveSeek),
+		})
+	}
+	table.Render()
+}
+
+// joinInts joins numbers with commas.
+func joinInts(numbers []int64) string {
+	text := make([]string, len(numbers))
+	for i, n := range numbers {
+		text[i] = strconv.FormatInt(n, 10)
+	}
+
+	return strings.Join(text, ", ")
+}
+
+//endregion
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/disk"
+)
+
+func Test_runDisksim(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	args := []string{"-head", "53", "-requests", "98 183 37 122 14 124 65 67", "-algo", "fcfs,c-scan", "-color", colorNever}
+	if err := runDisksim(args, &b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"FCFS (head at 53 of 200 cylinders)",
+		"| 98  |   183   |        37        |   122   |     14      |    124     |  65   |",
+		"Service order: 98, 183, 37, 122, 14, 124, 65, 67",
+		"Total head movement: 640  Average seek: 80.00",
+		"|  7 |  183 | 199 |   16 |   146 | end of disk |",
+		"|  8 |  199 |   0 |  199 |   345 | return      |",
+		"| c-scan    |           382 |        47.75 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runDisksim() output is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func Test_runDisksim_invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "unknown algorithm", args: []string{"-algo", "c-look", "-requests", "1"}, wantErr: ErrInvalidArgs},
+		{name: "unknown direction", args: []string{"-direction", "left", "-requests", "1"}, wantErr: ErrInvalidArgs},
+		{name: "requests and a file", args: []string{"-requests", "1", "queue.csv"}, wantErr: ErrInvalidArgs},
+		{name: "cylinder off the disk", args: []string{"-cylinders", "100", "-requests", "1,100"}, wantErr: disk.ErrInvalidRequest},
+		{name: "head off the disk", args: []string{"-head", "-1", "-requests", "1"}, wantErr: disk.ErrInvalidRequest},
+		{name: "not a cylinder", args: []string{"-requests", "1,two"}, wantErr: disk.ErrInvalidRequest},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := runDisksim(tt.args, &bytes.Buffer{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("runDisksim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// Distributions for generated workloads.
+const (
+	distPoisson     = "poisson"
+	distUniform     = "uniform"
+	distExponential = "exponential"
+	distNormal      = "normal"
+)
+
+// generateConfig describes a random workload.
+type generateConfig struct {
+	Count int
+	// Arrivals is the distribution of arrivals: poisson (exponential gaps) or uniform gaps, with a mean gap of
+	// MeanArrival.
+	Arrivals    string
+	MeanArrival float64
+	// Bursts is the distribution of burst durations, exponential or normal, with a mean of MeanBurst and, for
+	// normal, a standard deviation of StdDevBurst. Bursts are at least 1.
+	Bursts      string
+	MeanBurst   float64
+	StdDevBurst float64
+	// MinPriority and MaxPriority bound the uniformly drawn priorities.
+	MinPriority int64
+	MaxPriority int64
+}
+
+//region Generating workloads.
+
+// runGenerate runs the generate subcommand, writing a random workload to w or the -o file.
+func runGenerate(args []string, w io.Writer) error {
+	var (
+		fs       = flag.NewFlagSet("generate", flag.ContinueOnError)
+		cfg      generateConfig
+		priority string
+		format   string
+		out      string
+		seed     int64
+	)
+	cfg.flags(fs, &priority)
+	fs.StringVar(&format, "format", formatCSV, "output format, csv or json")
+	fs.StringVar(&out, "o", "", "file to write the workload to instead of stdout")
+	fs.Int64Var(&seed, "seed", time.Now().UnixNano(), "random seed, to reproduce a workload")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if err := cfg.parsePriority(priority); err != nil {
+		return err
+	}
+	processes, err := generateWorkload(cfg, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("%v: error creating workload file", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return writeWorkload(w, processes, format)
+}
+
+// flags defines the flags of a random workload on fs, with the priority range left in priority for
+// parsePriority.
+func (cfg *generateConfig) flags(fs *flag.FlagSet, priority *string) {
+	fs.IntVar(&cfg.Count, "n", 10, "number of processes")
+	fs.StringVar(&cfg.Arrivals, "arrivals", distPoisson, "arrival distribution, poisson or uniform")
+	fs.Float64Var(&cfg.MeanArrival, "mean-arrival", 3, "mean time between arrivals")
+	fs.StringVar(&cfg.Bursts, "bursts", distExponential, "burst distribution, exponential or normal")
+	fs.Float64Var(&cfg.MeanBurst, "mean-burst", 5, "mean burst duration")
+	fs.Float64Var(&cfg.StdDevBurst, "stddev-burst", 2, "standard deviation of normal burst durations")
+	fs.StringVar(priority, "priority", "1-10", "priority range as min-max")
+}
+
+// parsePriority sets the priority range from its min-max flag.
+func (cfg *generateConfig) parsePriority(priority string) error {
+	var err error
+	cfg.MinPriority, cfg.MaxPriority, err = parseRange(priority)
+
+	return err
+}
+
+// generateWorkload draws cfg.Count processes, numbered from 1 in order of arrival.
+func generateWorkload(cfg generateConfig, rng *rand.Rand) ([]scheduler.Process, error) {
+	switch {
+	case cfg.Count < 1:
+		return nil, fmt.Errorf("%w: the process count must be at least 1", ErrInvalidArgs)
+	case cfg.MeanArrival < 0 || cfg.MeanBurst < 1 || cfg.StdDevBurst < 0:
+		return nil, fmt.Errorf("%w: means must be at least 0 (arrivals) or 1 (bursts), and deviations at least 0", ErrInvalidArgs)
+	case cfg.MinPriority > cfg.MaxPriority:
+		return nil, fmt.Errorf("%w: the priority range is empty", ErrInvalidArgs)
+	}
+	var gap, burst func() float64
+	switch cfg.Arrivals {
+	case distPoisson:
+		gap = func() float64 { return rng.ExpFloat64() * cfg.MeanArrival }
+	case distUniform:
+		gap = func() float64 { return rng.Float64() * 2 * cfg.MeanArrival }
+	default:
+		return nil, fmt.Errorf("%w: unknown arrival distribution %q", ErrInvalidArgs, cfg.Arrivals)
+	}
+	switch cfg.Bursts {
+	case distExponential:
+		burst = func() float64 { return rng.ExpFloat64() * cfg.MeanBurst }
+	case distNormal:
+		burst = func() float64 { return rng.NormFloat64()*cfg.StdDevBurst + cfg.MeanBurst }
+	default:
+		return nil, fmt.Errorf("%w: unknown burst distribution %q", ErrInvalidArgs, cfg.Bursts)
+	}
+
+	var (
+		processes = make([]scheduler.Process, cfg.Count)
+		arrival   float64
+	)
+	for i := range processes {
+		if i > 0 {
+			arrival += gap()
+		}
+		processes[i] = scheduler.Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   int64(math.Round(arrival)),
+			BurstDuration: int64(math.Max(1, math.Round(burst()))),
+			Priority:      cfg.MinPriority + rng.Int63n(cfg.MaxPriority-cfg.MinPriority+1),
+		}
+	}
+
+	return processes, nil
+}
+
+// writeWorkload writes processes as a CSV or JSON process file.
+func writeWorkload(w io.Writer, processes []scheduler.Process, format string) error {
+	switch format {
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		for _, p := range processes {
+			_ = cw.Write([]string{
+				strconv.FormatInt(p.ProcessID, 10),
+				strconv.FormatInt(p.BurstDuration, 10),
+				strconv.FormatInt(p.ArrivalTime, 10),
+				strconv.FormatInt(p.Priority, 10),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		workload := make([]workloadProcess, len(processes))
+		for i, p := range processes {
+			workload[i] = workloadProcess{PID: p.ProcessID, Burst: p.BurstDuration, Arrival: p.ArrivalTime, Priority: p.Priority}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(workload)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// parseRange parses a range of the form min-max.
+func parseRange(s string) (int64, int64, error) {
+	lo, hi, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: range %q must be min-max", ErrInvalidArgs, s)
+	}
+	min, err := strconv.ParseInt(lo, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: range %q: %v", ErrInvalidArgs, s, err)
+	}
+	max, err := strconv.ParseInt(hi, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: range %q: %v", ErrInvalidArgs, s, err)
+	}
+
+	return min, max, nil
+}
+
+//endregion
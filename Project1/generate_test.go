@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_generateWorkload(t *testing.T) {
+	t.Parallel()
+	valid := generateConfig{
+		Count:       50,
+		Arrivals:    distPoisson,
+		MeanArrival: 3,
+		Bursts:      distNormal,
+		MeanBurst:   5,
+		StdDevBurst: 4,
+		MinPriority: 2,
+		MaxPriority: 4,
+	}
+	tests := []struct {
+		name    string
+		cfg     func(cfg generateConfig) generateConfig
+		wantErr error
+	}{
+		{
+			name: "poisson arrivals, normal bursts",
+			cfg:  func(cfg generateConfig) generateConfig { return cfg },
+		},
+		{
+			name: "uniform arrivals, exponential bursts",
+			cfg: func(cfg generateConfig) generateConfig {
+				cfg.Arrivals, cfg.Bursts = distUniform, distExponential
+				return cfg
+			},
+		},
+		{
+			name: "unknown distribution",
+			cfg: func(cfg generateConfig) generateConfig {
+				cfg.Bursts = "gamma"
+				return cfg
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "empty priority range",
+			cfg: func(cfg generateConfig) generateConfig {
+				cfg.MinPriority = 5
+				return cfg
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := tt.cfg(valid)
+			got, err := generateWorkload(cfg, rand.New(rand.NewSource(1)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != cfg.Count {
+				t.Fatalf("generated %d processes, want %d", len(got), cfg.Count)
+			}
+			for i, p := range got {
+				switch {
+				case p.ProcessID != int64(i+1):
+					t.Errorf("process %d has PID %d", i, p.ProcessID)
+				case i > 0 && p.ArrivalTime < got[i-1].ArrivalTime:
+					t.Errorf("process %d arrives at %d, before the previous process", p.ProcessID, p.ArrivalTime)
+				case p.BurstDuration < 1:
+					t.Errorf("process %d has burst %d", p.ProcessID, p.BurstDuration)
+				case p.Priority < cfg.MinPriority || p.Priority > cfg.MaxPriority:
+					t.Errorf("process %d has priority %d", p.ProcessID, p.Priority)
+				}
+			}
+
+			// The same seed generates the same workload.
+			again, _ := generateWorkload(cfg, rand.New(rand.NewSource(1)))
+			if !reflect.DeepEqual(got, again) {
+				t.Errorf("generateWorkload() is not reproducible: %v, then %v", got, again)
+			}
+		})
+	}
+}
+
+func Test_writeWorkload(t *testing.T) {
+	t.Parallel()
+	processes, err := generateWorkload(generateConfig{
+		Count:       5,
+		Arrivals:    distPoisson,
+		MeanArrival: 2,
+		Bursts:      distExponential,
+		MeanBurst:   4,
+		MinPriority: 1,
+		MaxPriority: 3,
+	}, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{formatCSV, formatJSON} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			if err := writeWorkload(&b, processes, format); err != nil {
+				t.Fatal(err)
+			}
+			got, err := loadWorkload(&b, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, processes) {
+				t.Errorf("loaded %v, want %v", got, processes)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+//region Interactive mode
+
+// runInteractive steps through a schedule a tick at a time, redrawing the state of the CPUs, the ready queue
+// and the GANTT chart so far to w after each line read from in: an empty line steps one tick, a number steps
+// that many ticks, and q (or the end of in) stops.
+func runInteractive(in io.Reader, w io.Writer, title string, result scheduler.ScheduleResult, colored bool) {
+	var (
+		keys      = bufio.NewScanner(in)
+		names     = processNames(result.Processes)
+		timelines = make([][]interval, len(result.Processes))
+	)
+	for i, row := range result.Processes {
+		timelines[i] = processTimeline(row, result.Gantt)
+	}
+
+	for now := int64(0); ; {
+		_, _ = fmt.Fprint(w, clearScreen)
+		outputTitle(w, title)
+		outputTick(w, now, result, timelines, names, colored)
+		if now >= result.Stats.Makespan {
+			_, _ = fmt.Fprintln(w, "Done.")
+			return
+		}
+		_, _ = fmt.Fprint(w, "[Enter] next tick, [n Enter] n ticks, [q Enter] quit: ")
+		if !keys.Scan() {
+			return
+		}
+		switch key := strings.TrimSpace(keys.Text()); key {
+		case "":
+			now++
+		case "q":
+			return
+		default:
+			if n, err := strconv.ParseInt(key, 10, 64); err == nil && n > 0 {
+				now = min(now+n, result.Stats.Makespan)
+			}
+		}
+	}
+}
+
+// outputTick outputs the state of a schedule at time now: what each CPU is running, the ready queue in order of
+// how long each process has waited, the processes blocked on I/O, and the GANTT chart up to now.
+func outputTick(w io.Writer, now int64, result scheduler.ScheduleResult, timelines [][]interval,
+	names map[int64]string, colored bool,
+) {
+	_, _ = fmt.Fprintf(w, "Time %d of %d\n\n", now, result.Stats.Makespan)
+
+	var (
+		running = make(map[int]string)
+		cpus    = 1
+		gantt   = make([]scheduler.TimeSlice, 0)
+	)
+	for _, s := range result.Gantt {
+		if s.CPU >= cpus {
+			cpus = s.CPU + 1
+		}
+		if s.Start <= now && now < s.Stop {
+			running[s.CPU] = label(s.PID, names)
+			if s.Switch {
+				running[s.CPU] = "switching to " + running[s.CPU]
+			}
+		}
+		if s.Start < now {
+			s.Stop = min(s.Stop, now)
+			gantt = append(gantt, s)
+		}
+	}
+	for cpu := 0; cpu < cpus; cpu++ {
+		p, ok := running[cpu]
+		if !ok {
+			p = "idle"
+		}
+		_, _ = fmt.Fprintf(w, "CPU %d: %s\n", cpu, p)
+	}
+
+	type waiting struct {
+		label string
+		since int64
+	}
+	var ready, blocked []waiting
+	done := 0
+	for i, row := range result.Processes {
+		if row.Completion <= now {
+			done++
+			continue
+		}
+		for _, iv := range timelines[i] {
+			if iv.Start <= now && now < iv.Stop {
+				switch iv.State {
+				case stateWait:
+					ready = append(ready, waiting{label(row.ProcessID, names), iv.Start})
+				case stateIO:
+					blocked = append(blocked, waiting{label(row.ProcessID, names), iv.Start})
+				}
+			}
+		}
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].since < ready[j].since
+	})
+	for _, q := range []struct {
+		name  string
+		procs []waiting
+	}{{"Ready", ready}, {"Blocked on I/O", blocked}} {
+		labels := make([]string, len(q.procs))
+		for i, p := range q.procs {
+			labels[i] = fmt.Sprintf("%s (since %d)", p.label, p.since)
+		}
+		if len(labels) == 0 {
+			labels = append(labels, "-")
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s\n", q.name, strings.Join(labels, ", "))
+	}
+	_, _ = fmt.Fprintf(w, "Completed: %d of %d\n\n", done, len(result.Processes))
+
+	outputGantt(w, gantt, names, colored)
+}
+
+//endregion
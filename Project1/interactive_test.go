@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_runInteractive(t *testing.T) {
+	t.Parallel()
+	result := scheduler.FCFS{}.Schedule([]scheduler.Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 2},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 1},
+	})
+	tests := []struct {
+		name string
+		keys string
+		// want are the frames drawn after the first, which all start the same.
+		want []string
+		done bool
+	}{
+		{
+			name: "one tick at a time",
+			keys: "\n\nq\n",
+			want: []string{
+				"Time 1 of 5\n\nCPU 0: editor\nReady: 2 (since 1), 3 (since 1)\nBlocked on I/O: -\nCompleted: 0 of 3\n\n",
+				"Time 2 of 5\n\nCPU 0: 2\nReady: 3 (since 1)\nBlocked on I/O: -\nCompleted: 1 of 3\n\n",
+			},
+		},
+		{
+			name: "several ticks to the end",
+			keys: "9\n",
+			want: []string{
+				"Time 5 of 5\n\nCPU 0: idle\nReady: -\nBlocked on I/O: -\nCompleted: 3 of 3\n\n",
+			},
+			done: true,
+		},
+		{
+			name: "stops at the end of input",
+			keys: "x\n",
+			want: []string{"Time 0 of 5\n\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			runInteractive(strings.NewReader(tt.keys), &out, "FCFS", result, false)
+			frames := strings.Split(out.String(), clearScreen)[2:]
+			if len(frames) != len(tt.want) {
+				t.Fatalf("got %d frames after the first, want %d:\n%s", len(frames), len(tt.want), out.String())
+			}
+			for i, frame := range frames {
+				if !strings.Contains(frame, tt.want[i]) {
+					t.Errorf("frame %d =\n%s\nwant it to contain\n%s", i+1, frame, tt.want[i])
+				}
+			}
+			if done := strings.HasSuffix(out.String(), "Done.\n"); done != tt.done {
+				t.Errorf("ended with Done. = %v, want %v", done, tt.done)
+			}
+		})
+	}
+}
@@ -3,433 +3,960 @@ package main
 import (
 	"encoding/csv"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/olekukonko/tablewriter"
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+var (
+	inputFormat       = flag.String("format", "", "process file format, csv, json, yaml or sched (a perf or ftrace sched trace); detected from the file extension if empty")
+	cgroupsFile       = flag.String("cgroups", "", "CSV file of cgroups (path,quota,period) to enforce CPU limits with")
+	traceFile         = flag.String("trace", "", "`perf sched script` or ftrace output to chart instead of a process file")
+	traceCPU          = flag.Int64("trace-cpu", 0, "CPU to chart from the -trace file")
+	agingEvery        = flag.Int64("aging", 5, "time a process waits before its priority improves by one under priority aging")
+	mlqQueues         = flag.String("mlq", "", "multi-level queues, highest first, as name:min-max:discipline[:quantum],... e.g. fg:1-25:rr:2,bg:26-50:fcfs")
+	mlqSlices         = flag.String("mlq-slices", "", "time slice per -mlq queue, e.g. 8,2; strict priority between queues if empty")
+	predictAlpha      = flag.Float64("predict-alpha", 0, "adds SJF with each burst predicted by exponential averaging with this weight of the last burst, from 0 to 1, e.g. 0.5")
+	predictInitial    = flag.Float64("predict-initial", 10, "-predict-alpha's prediction of each process's first burst")
+	mlfqLevels        = flag.String("mlfq", "", "multi-level feedback queue levels, highest first, as discipline[:quantum[:allotment]],... e.g. rr:8,rr:16,fcfs")
+	mlfqBoost         = flag.Int64("mlfq-boost", 0, "time between moving every -mlfq process back to the top level; never if 0")
+	energyLevels      = flag.String("energy", "", "frequency levels to account for each schedule's energy with, as frequency:power[:idle power],... e.g. 1:10:2,0.5:3:1")
+	frequency         = flag.Float64("frequency", 0, "frequency to run every -energy CPU at, rounded up to a level; 0 races to idle at the fastest level")
+	sleepPower        = flag.Float64("sleep-power", 0, "power an idle CPU draws asleep when racing to idle under -energy")
+	seed              = flag.Int64("seed", 1, "seed for randomized schedulers, to reproduce a run")
+	cores             = flag.Int("cores", 1, "number of CPUs for the FCFS, SJF, SRTF, SJF Priority and Round-Robin schedulers")
+	switchCost        = flag.Int64("switch-cost", 0, "context switch overhead for the FCFS, SJF, SRTF, SJF Priority and Round-Robin schedulers")
+	quantum           = flag.Int64("quantum", 1, "Round-Robin time quantum")
+	tieBreak          = flag.String("tie-break", scheduler.TieFIFOName, "how to order processes that arrive together or rank equally, fifo (as listed) or arrival (by arrival time, then PID)")
+	outDir            = flag.String("out", "", "directory to write each algorithm's report to, as <algorithm>.txt, instead of stdout")
+	color             = flag.String("color", colorAuto, "color the text report, auto (when writing to a terminal), always or never")
+	interactive       = flag.Bool("interactive", false, "step through each schedule a tick at a time, reading keys from stdin")
+	replaySpeed       = flag.Float64("replay", 0, "replay each schedule's events in real time at `SPEED` time units a second")
+	quantumSweepRange = flag.String("quantum-sweep", "", "run Round-Robin with each quantum in `MIN:MAX` and compare them instead of the algorithms")
+	stream            = flag.Bool("stream", false, "schedule a CSV process file FCFS while reading it, writing a CSV report, for files too large for memory")
+	algo              = flag.String("algo", "", "comma separated algorithms to run, in order, built-in or custom, e.g. fcfs,rr; the default set if empty")
+	check             = flag.Bool("check", false, "verify every schedule's invariants, failing on the first one violated")
+	verbose           = flag.Bool("verbose", false, "follow each text report with every process's timeline and slowdown")
+	worst             = flag.Int("worst", 0, "follow each text report with the `K` processes with the worst slowdown and when they waited")
+	output            = flag.String("output", outputText, "report format, text, json, csv, markdown, svg or html GANTT charts, or a chrome trace")
+	configFile        = flag.String("config", "", "YAML file of default settings, as in a scenario; "+defaultConfigFile+" if it exists and this is empty")
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "generate":
+			if err := runGenerate(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "bench":
+			if err := runBench(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "verify":
+			if err := runVerify(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "serve":
+			if err := runServe(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "memsim":
+			if err := runMemsim(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "pagesim":
+			if err := runPagesim(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "disksim":
+			if err := runDisksim(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "banker":
+			if err := runBanker(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "syncsim":
+			if err := runSyncsim(os.Args[2:], os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	flag.Parse()
+	if *traceFile != "" {
+		// Chart a real kernel schedule
+		trace, err := loadTraceFile(*traceFile, *traceCPU)
+		if err != nil {
+			log.Fatal(err)
+		}
+		title := fmt.Sprintf("Linux sched trace (CPU %d, µs)", *traceCPU)
+		outputResult(os.Stdout, title, traceResult(trace), useColor(*color, os.Stdout))
+		return
+	}
+	f, closeFile, err := openProcessingFile(append([]string{os.Args[0]}, flag.Args()...)...)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer closeFile()
 
-	// Load and parse processes
-	processes, err := loadProcesses(f)
+	// Load and parse processes, and the settings of a scenario
+	format, err := workloadFormat(*inputFormat, f.Name())
 	if err != nil {
 		log.Fatal(err)
 	}
+	var (
+		processes []scheduler.Process
+		s         = flagSettings()
+	)
+	// A config file gives the defaults, which flags given on the command line override.
+	if err := loadConfigFile(*configFile, &s); err != nil {
+		log.Fatal(err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		s.override(f.Name)
+	})
+	if *stream {
+		if format != formatCSV {
+			log.Fatal(fmt.Errorf("%w: only CSV process files can be streamed", ErrInvalidArgs))
+		}
+		if err := s.validate(); err != nil {
+			log.Fatal(err)
+		}
+		if err := streamFCFS(os.Stdout, f, s); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if format == formatYAML {
+		if processes, err = loadScenario(f, &s); err != nil {
+			log.Fatal(err)
+		}
+		// Flags given on the command line override the scenario.
+		flag.Visit(func(f *flag.Flag) {
+			s.override(f.Name)
+		})
+	} else if processes, err = loadWorkload(f, format); err != nil {
+		log.Fatal(err)
+	}
+	if len(processes) == 0 {
+		log.Fatal(fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs))
+	}
+	if err := s.validate(); err != nil {
+		log.Fatal(err)
+	}
+	if s.Interactive && f == stdin {
+		log.Fatal(fmt.Errorf("%w: -interactive reads keys from stdin, so processes must come from a file", ErrInvalidArgs))
+	}
+	if s.QuantumSweep != "" {
+		if err := runSweep(os.Stdout, processes, s); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-
-	// Shortest Job First (preemptive) scheduling
-	SJFSchedule(os.Stdout, "Shortest Job First (preemptive)", processes)
-
-	// Shortest Job First Priority (preemptive) scheduling
-	SJFPrioritySchedule(os.Stdout, "Shortest Job First Priority (preemptive)", processes)
-
-	// Round-Robin (non-preemptive) scheduling
-	RRSchedule(os.Stdout, "Round-Robin (non-preemptive)", processes)
+	if err := runSchedules(os.Stdout, processes, s); err != nil {
+		log.Fatal(err)
+	}
 }
 
-func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+// runSchedules outputs the schedule of processes under each of the algorithms in s, or the default set, to w or
+// to a file per algorithm in s.Out.
+func runSchedules(w io.Writer, processes []scheduler.Process, s settings) error {
+	algorithms := s.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms(processes, s)
 	}
-	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
+	render, ext, err := reportRenderer(s)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+		return err
 	}
-	closeFn := func() {
-		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
+	if s.Out != "" {
+		if err := os.MkdirAll(s.Out, 0o755); err != nil {
+			return fmt.Errorf("%v: error creating output directory", err)
+		}
+	}
+	schedules, err := scheduleAll(processes, algorithms, s)
+	if err != nil {
+		return err
+	}
+	for _, sc := range schedules {
+		name, title, result := sc.name, sc.title, sc.result
+		if s.Replay > 0 {
+			replay(w, title, result, s.Replay, time.Sleep)
+			continue
+		}
+		if s.Interactive {
+			runInteractive(stdin, w, title, result, useColor(s.Color, w))
+			continue
+		}
+		if s.Out == "" {
+			if err := render(w, name, title, result); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeReport(filepath.Join(s.Out, name+"."+ext), render, name, title, result); err != nil {
+			return err
 		}
 	}
 
-	return f, closeFn, nil
+	return nil
 }
 
-type (
-	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
+// scheduled is the schedule of a workload under the named algorithm.
+type scheduled struct {
+	name, title string
+	result      scheduler.ScheduleResult
+}
+
+// scheduleAll schedules processes under each of the named algorithms, configured by s, in parallel, each on its
+// own copy of processes. The schedules are returned in the order of the algorithms, and, when s.Check is set,
+// an error for the first one that violates an invariant or leaves out a process.
+func scheduleAll(processes []scheduler.Process, algorithms []string, s settings) ([]scheduled, error) {
+	var (
+		schedules  = make([]scheduled, len(algorithms))
+		schedulers = make([]scheduler.Scheduler, len(algorithms))
+		wg         sync.WaitGroup
+	)
+	for _, p := range processes {
+		for _, cpu := range p.Affinity {
+			if cpu >= s.Cores {
+				return nil, fmt.Errorf("%w: process %d: affinity CPU %d is not one of the %d CPUs", ErrInvalidProcess, p.ProcessID, cpu, s.Cores)
+			}
+		}
 	}
-	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
+	for _, name := range algorithms {
+		switch name {
+		case algoGang:
+			if err := checkGangs(processes, s.Cores); err != nil {
+				return nil, err
+			}
+		case algoMLQ, algoMLFQ, algoCgroup:
+			if err := checkSingleCPU(processes, name); err != nil {
+				return nil, err
+			}
+		case algoRateMonotonic:
+			if err := scheduler.CheckPeriodic(processes); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+			}
+		}
+	}
+	var model *scheduler.EnergyModel
+	if s.Energy != "" {
+		levels, err := scheduler.ParseFrequencyLevels(s.Energy)
+		if err != nil {
+			return nil, err
+		}
+		model = &scheduler.EnergyModel{Levels: levels, SleepPower: s.SleepPower}
+	}
+	for i, name := range algorithms {
+		title, sched, err := algorithm(name, s)
+		if err != nil {
+			return nil, err
+		}
+		if model != nil {
+			title, sched = energyTitle(title, s.Frequency), scheduler.DVFS{Scheduler: sched, Model: *model, Frequency: s.Frequency}
+		}
+		schedules[i], schedulers[i] = scheduled{name: name, title: title}, sched
+	}
+	for i := range schedules {
+		wg.Add(1)
+		go func(i int, processes []scheduler.Process) {
+			defer wg.Done()
+			schedules[i].result = schedulers[i].Schedule(processes)
+		}(i, append([]scheduler.Process(nil), processes...))
+	}
+	wg.Wait()
+	if s.Check {
+		for _, sc := range schedules {
+			if err := sc.result.Check(); err != nil {
+				return nil, fmt.Errorf("%s: %w", sc.name, err)
+			}
+			if err := checkCompleted(processes, sc.result); err != nil {
+				return nil, fmt.Errorf("%s: %w", sc.name, err)
+			}
+		}
 	}
-)
 
-//region Schedulers
+	return schedules, nil
+}
 
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
+// checkCompleted checks that result has a row for every process, one for each that isn't a periodic task, which
+// has one per job, and that none completes before it could have run its burst.
+func checkCompleted(processes []scheduler.Process, result scheduler.ScheduleResult) error {
+	rows := make(map[int64]int, len(processes))
+	for _, r := range result.Processes {
+		rows[r.ProcessID]++
+		if r.Completion < r.ArrivalTime+r.BurstDuration {
+			return fmt.Errorf("%w: process %d completes at %d, before its burst of %d from %d could", scheduler.ErrInvariant,
+				r.ProcessID, r.Completion, r.BurstDuration, r.ArrivalTime)
+		}
+	}
 	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
+		p := &processes[i]
+		switch n := rows[p.ProcessID]; {
+		case n == 0:
+			return fmt.Errorf("%w: process %d is never scheduled", scheduler.ErrInvariant, p.ProcessID)
+		case n > 1 && p.Period == 0:
+			return fmt.Errorf("%w: process %d is scheduled %d times", scheduler.ErrInvariant, p.ProcessID, n)
 		}
-		totalWait += float64(waitingTime)
+	}
+	if len(rows) != len(processes) {
+		return fmt.Errorf("%w: %d processes are scheduled, but %d were given", scheduler.ErrInvariant, len(rows), len(processes))
+	}
 
-		start := waitingTime + processes[i].ArrivalTime
+	return nil
+}
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+// energyTitle returns the title of a schedule run at frequency under an energy model.
+func energyTitle(title string, frequency float64) string {
+	if frequency == 0 {
+		return title + ", racing to idle"
+	}
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
+	return fmt.Sprintf("%s, at frequency %g", title, frequency)
+}
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
+// writeReport writes the report of the named algorithm's schedule to file.
+func writeReport(file string, render renderer, name, title string, result scheduler.ScheduleResult) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("%v: error creating report file", err)
+	}
+	if err := render(f, name, title, result); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%v: error writing report file", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%v: error closing report file", err)
+	}
 
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+	return nil
+}
+
+// defaultAlgorithms lists the algorithms to run when none are chosen: every general purpose algorithm, plus
+// the deadline and periodic ones when the workload uses them, the ones that have been configured, and the custom
+// ones.
+func defaultAlgorithms(processes []scheduler.Process, s settings) []string {
+	algorithms := []string{
+		algoFCFS, algoSJF, algoSRTF, algoSJFPriority, algoAging, algoHRRN, algoRoundRobin, algoLottery, algoStride,
+	}
+	if hasDeadlines(processes) {
+		algorithms = append(algorithms, algoEDF)
+	}
+	if hasPeriodicTasks(processes) {
+		algorithms = append(algorithms, algoRateMonotonic)
+	}
+	if s.PredictAlpha > 0 {
+		algorithms = append(algorithms, algoPredictiveSJF)
+	}
+	if s.MLQ != "" {
+		algorithms = append(algorithms, algoMLQ)
 	}
+	if s.MLFQ != "" {
+		algorithms = append(algorithms, algoMLFQ)
+	}
+	if s.Cgroups != "" {
+		algorithms = append(algorithms, algoCgroup)
+	}
+	if hasGangs(processes) {
+		algorithms = append(algorithms, algoGang)
+	}
+	algorithms = append(algorithms, s.registry().Names()...)
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return algorithms
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// algorithm returns the report title and scheduler of the named algorithm, configured by s.
+func algorithm(name string, s settings) (string, scheduler.Scheduler, error) {
+	tb := s.tieBreak()
+	switch name {
+	case algoFCFS:
+		return "First-come, first-serve", scheduler.FCFS{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoSJF:
+		return "Shortest Job First (non-preemptive)", scheduler.SJF{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoSRTF:
+		return "Shortest Remaining Time First (preemptive)", scheduler.SRTF{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoSJFPriority:
+		return "Shortest Job First Priority (preemptive)",
+			scheduler.SJFPriority{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoPredictiveSJF:
+		alpha := s.PredictAlpha
+		if alpha == 0 {
+			alpha = defaultPredictAlpha
+		}
+		return fmt.Sprintf("Shortest Job First with predicted bursts (alpha %g, initial %g)", alpha, s.PredictInitial),
+			scheduler.PredictiveSJF{
+				Alpha:      alpha,
+				Initial:    s.PredictInitial,
+				Cores:      s.Cores,
+				SwitchCost: s.SwitchCost,
+				TieBreak:   tb,
+			}, nil
+	case algoAging:
+		return fmt.Sprintf("Priority with aging every %d (preemptive)", s.Aging), scheduler.Aging{Interval: s.Aging, Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoHRRN:
+		return "Highest Response Ratio Next (non-preemptive)", scheduler.HRRN{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoRoundRobin:
+		return fmt.Sprintf("Round-Robin (quantum %d)", s.Quantum),
+			scheduler.RoundRobin{Quantum: s.Quantum, Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoLottery:
+		return fmt.Sprintf("Lottery (preemptive, quantum %d, seed %d)", s.Quantum, s.Seed),
+			scheduler.Lottery{Quantum: s.Quantum, Seed: s.Seed, Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoStride:
+		return fmt.Sprintf("Stride (preemptive, quantum %d)", s.Quantum),
+			scheduler.Stride{Quantum: s.Quantum, Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoEDF:
+		return "Earliest Deadline First (preemptive)", scheduler.EDF{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoRateMonotonic:
+		return "Rate-monotonic (preemptive)", scheduler.RateMonotonic{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: tb}, nil
+	case algoMLQ:
+		mlq, err := parseMLQ(s.MLQ, s.MLQSlices)
+		if err != nil {
+			return "", nil, err
+		}
+		mlq.TieBreak = tb
+		arbitration := "strict"
+		if mlq.TimeSliced {
+			arbitration = "time-sliced"
+		}
+		return singleCPU(fmt.Sprintf("Multi-level queue (%s)", arbitration), s), mlq, nil
+	case algoMLFQ:
+		spec := s.MLFQ
+		if spec == "" {
+			spec = defaultMLFQ
+		}
+		levels, err := scheduler.ParseLevels(spec)
+		if err != nil {
+			return "", nil, err
+		}
+		title := fmt.Sprintf("Multi-level feedback queue (%s)", spec)
+		if s.MLFQBoost > 0 {
+			title = fmt.Sprintf("Multi-level feedback queue (%s, boost every %d)", spec, s.MLFQBoost)
+		}
+		return singleCPU(title, s), scheduler.MLFQ{Levels: levels, Boost: s.MLFQBoost, TieBreak: tb}, nil
+	case algoCgroup:
+		groups, err := loadCgroupsFile(s.Cgroups)
+		if err != nil {
+			return "", nil, err
+		}
+		return singleCPU("Round-Robin with cgroup CPU limits", s), scheduler.CgroupRoundRobin{Groups: groups, TieBreak: tb}, nil
+	case algoGang:
+		return "Gang scheduling (first-come, first-serve)", scheduler.Gang{Cores: s.Cores, TieBreak: tb}, nil
+	default:
+		factory, ok := s.registry().Lookup(name)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, name)
+		}
+		opts := scheduler.Options{Quantum: s.Quantum, Cores: s.Cores, SwitchCost: s.SwitchCost, Seed: s.Seed, TieBreak: tb}
+		return fmt.Sprintf("%s (custom)", name), factory(opts), nil
+	}
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		remainingBurst   = make(map[int64]int64)
-	)
-	copyProcesses := make([]Process, len(processes))
-	copy(copyProcesses, processes)
+// singleCPU flags the title of a schedule whose scheduler only runs on one CPU, without context switch overhead,
+// when s asks for more CPUs or a switch cost, so it isn't mistaken for a like-for-like comparison.
+func singleCPU(title string, s settings) string {
+	if s.Cores > 1 || s.SwitchCost > 0 {
+		return title + " [1 CPU, no switch cost]"
+	}
+
+	return title
+}
 
-	for i := range copyProcesses {
-		remainingBurst[copyProcesses[i].ProcessID] = copyProcesses[i].BurstDuration
+func hasDeadlines(processes []scheduler.Process) bool {
+	for i := range processes {
+		if processes[i].Deadline > 0 {
+			return true
+		}
 	}
 
-	for len(copyProcesses) > 0 {
-		shortestJobIndex := 0
-		for i := range copyProcesses {
-			if copyProcesses[i].ArrivalTime <= serviceTime {
-				if remainingBurst[copyProcesses[i].ProcessID] < remainingBurst[copyProcesses[shortestJobIndex].ProcessID] {
-					shortestJobIndex = i
-				}
-			}
+	return false
+}
+
+func hasPeriodicTasks(processes []scheduler.Process) bool {
+	for i := range processes {
+		if processes[i].Period > 0 {
+			return true
 		}
+	}
+
+	return false
+}
 
-		shortestJob := copyProcesses[shortestJobIndex]
-		delete(remainingBurst, shortestJob.ProcessID)
-		if shortestJob.ArrivalTime > serviceTime {
-			waitingTime = shortestJob.ArrivalTime - serviceTime
+// hasGangs reports whether any process is in a gang.
+func hasGangs(processes []scheduler.Process) bool {
+	for i := range processes {
+		if processes[i].Gang != 0 {
+			return true
 		}
-		totalWait += float64(waitingTime)
+	}
+
+	return false
+}
 
-		start := serviceTime + waitingTime
-		turnaround := waitingTime + shortestJob.BurstDuration
-		totalTurnaround += float64(turnaround)
+// checkGangs checks that every gang can run, given cores CPUs.
+func checkGangs(processes []scheduler.Process, cores int) error {
+	if err := (scheduler.Gang{Cores: cores}).Check(processes); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProcess, err)
+	}
 
-		completion := serviceTime + waitingTime + shortestJob.BurstDuration
-		lastCompletion = float64(completion)
+	return nil
+}
 
-		schedule[len(processes)-len(copyProcesses)] = []string{
-			fmt.Sprint(shortestJob.ProcessID),
-			fmt.Sprint(shortestJob.Priority),
-			fmt.Sprint(shortestJob.BurstDuration),
-			fmt.Sprint(shortestJob.ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+// checkSingleCPU checks that every process can run on CPU 0, the only CPU the named algorithm runs on.
+func checkSingleCPU(processes []scheduler.Process, name string) error {
+	for i := range processes {
+		if !processes[i].RunsOn(0) {
+			return fmt.Errorf("%w: process %d: affinity %v excludes CPU 0, the only CPU the %s schedule runs on",
+				ErrInvalidProcess, processes[i].ProcessID, processes[i].Affinity, name)
 		}
+	}
 
-		serviceTime += turnaround
+	return nil
+}
 
-		gantt = append(gantt, TimeSlice{
-			PID:   shortestJob.ProcessID,
-			Start: start,
-			Stop:  start + turnaround,
-		})
+// defaultPredictAlpha is the weight of the last burst in SJF's predictions when it's picked without
+// -predict-alpha: the textbook's, averaging the last burst and the previous prediction.
+const defaultPredictAlpha = 0.5
 
-		copyProcesses = append(copyProcesses[:shortestJobIndex], copyProcesses[shortestJobIndex+1:]...)
-	}
+// defaultMLFQ are the levels of the MLFQ schedule when it's picked without -mlfq: the textbook example of two
+// Round-Robin levels with quanta of 8 and 16 above an FCFS one.
+const defaultMLFQ = "rr:8,rr:16,fcfs"
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+func parseMLQ(queues, slices string) (scheduler.MLQ, error) {
+	qs, err := scheduler.ParseQueues(queues)
+	if err != nil {
+		return scheduler.MLQ{}, err
+	}
+	if slices == "" {
+		return scheduler.MLQ{Queues: qs}, nil
+	}
+	if err := scheduler.ParseSlices(qs, slices); err != nil {
+		return scheduler.MLQ{}, err
+	}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return scheduler.MLQ{Queues: qs, TimeSliced: true}, nil
 }
 
-// SJFPrioritySchedule implements Shortest Job First (SJF) Priority preemptive scheduling algorithm
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		remainingBurst   = make(map[int64]int64)
-	)
-	copyProcesses := make([]Process, len(processes))
-	copy(copyProcesses, processes)
+// stdin is where processes are read from when the scheduling file is "-" or is left out in a pipeline.
+var stdin = os.Stdin
 
-	for i := range copyProcesses {
-		remainingBurst[copyProcesses[i].ProcessID] = copyProcesses[i].BurstDuration
+func openProcessingFile(args ...string) (*os.File, func(), error) {
+	if len(args) == 1 && !isTerminal(stdin) {
+		args = append(args, "-")
 	}
-
-	for len(copyProcesses) > 0 {
-		highestPriorityIndex := 0
-		for i := range copyProcesses {
-			if copyProcesses[i].ArrivalTime <= serviceTime {
-				if copyProcesses[i].Priority < copyProcesses[highestPriorityIndex].Priority {
-					highestPriorityIndex = i
-				}
-			}
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	}
+	if args[1] == "-" {
+		return stdin, func() {}, nil
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing scheduling file", err)
 		}
+	}
 
-		highestPriorityJob := copyProcesses[highestPriorityIndex]
-		delete(remainingBurst, highestPriorityJob.ProcessID)
-		if highestPriorityJob.ArrivalTime > serviceTime {
-			waitingTime = highestPriorityJob.ArrivalTime - serviceTime
-		}
-		totalWait += float64(waitingTime)
+	return f, closeFn, nil
+}
 
-		start := serviceTime + waitingTime
-		turnaround := waitingTime + highestPriorityJob.BurstDuration
-		totalTurnaround += float64(turnaround)
+// Color modes.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
 
-		completion := serviceTime + waitingTime + highestPriorityJob.BurstDuration
-		lastCompletion = float64(completion)
+// useColor reports whether to color output to w in the given color mode. Auto colors output to a terminal,
+// unless the NO_COLOR environment variable is set.
+func useColor(mode string, w io.Writer) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorAuto, "":
+		f, ok := w.(*os.File)
+		return ok && isTerminal(f) && os.Getenv("NO_COLOR") == ""
+	default:
+		return false
+	}
+}
 
-		schedule[len(processes)-len(copyProcesses)] = []string{
-			fmt.Sprint(highestPriorityJob.ProcessID),
-			fmt.Sprint(highestPriorityJob.Priority),
-			fmt.Sprint(highestPriorityJob.BurstDuration),
-			fmt.Sprint(highestPriorityJob.ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
+// isTerminal reports whether f is a terminal rather than a pipe or a redirected file.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
 
-		serviceTime += turnaround
+func loadCgroupsFile(name string) ([]scheduler.Cgroup, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening cgroups file", err)
+	}
+	defer f.Close()
 
-		gantt = append(gantt, TimeSlice{
-			PID:   highestPriorityJob.ProcessID,
-			Start: start,
-			Stop:  start + turnaround,
-		})
+	return loadCgroups(f)
+}
 
-		copyProcesses = append(copyProcesses[:highestPriorityIndex], copyProcesses[highestPriorityIndex+1:]...)
+// loadConfigFile reads the named config file into s, or defaultConfigFile if there is one and name is empty.
+func loadConfigFile(name string, s *settings) error {
+	explicit := name != ""
+	if !explicit {
+		name = defaultConfigFile
 	}
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%v: error opening config file", err)
+	}
+	defer f.Close()
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return loadConfig(f, s)
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+func loadTraceFile(name string, cpu int64) (*schedTrace, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening trace file", err)
+	}
+	defer f.Close()
+
+	return importSchedTrace(f, cpu)
 }
 
-// RRSchedule implements Round-Robin preemptive scheduling algorithm
-func RRSchedule(w io.Writer, title string, processes []Process) {
+//region Loading processes.
+
+var (
+	ErrInvalidArgs    = errors.New("invalid args")
+	ErrInvalidProcess = errors.New("invalid process")
+)
+
+// Process file columns, in the order they are read from a file without a header row.
+const (
+	colPID       = "pid"
+	colBurst     = "burst"
+	colArrival   = "arrival"
+	colPriority  = "priority"
+	colGroup     = "group"
+	colDeadline  = "deadline"
+	colPeriod    = "period"
+	colBursts    = "bursts"
+	colDependsOn = "depends_on"
+	colName      = "name"
+	colAffinity  = "affinity"
+	colGang      = "gang"
+)
+
+var (
+	csvColumns = []string{
+		colPID, colBurst, colArrival, colPriority, colGroup, colDeadline, colPeriod, colBursts, colDependsOn, colName,
+		colAffinity, colGang,
+	}
+	// csvAliases maps other common header names to their column.
+	csvAliases = map[string]string{
+		"id":             colPID,
+		"process":        colPID,
+		"process_id":     colPID,
+		"process_name":   colName,
+		"label":          colName,
+		"burst_duration": colBurst,
+		"burst_time":     colBurst,
+		"duration":       colBurst,
+		"arrival_time":   colArrival,
+		"dependencies":   colDependsOn,
+		"depends":        colDependsOn,
+		"cpus":           colAffinity,
+		"cpu_affinity":   colAffinity,
+		"gang_id":        colGang,
+		"thread_group":   colGang,
+	}
+)
+
+// loadProcesses reads processes from CSV. The columns are in csvColumns order, unless the first row is a header
+// naming them, in which case they can come in any order and unknown columns are ignored.
+func loadProcesses(r io.Reader) ([]scheduler.Process, error) {
 	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		remainingBurst   = make(map[int64]int64)
+		pr        = newProcessReader(r)
+		processes = make([]scheduler.Process, 0)
+		lines     = make(map[int64]int) // the line of each PID
 	)
-	copyProcesses := make([]Process, len(processes))
-	copy(copyProcesses, processes)
-
-	for i := range copyProcesses {
-		remainingBurst[copyProcesses[i].ProcessID] = copyProcesses[i].BurstDuration
-	}
-
-	for len(copyProcesses) > 0 {
-		for i := range copyProcesses {
-			if copyProcesses[i].ArrivalTime <= serviceTime {
-				burstTime := min(remainingBurst[copyProcesses[i].ProcessID], 1) 
-				remainingBurst[copyProcesses[i].ProcessID] -= burstTime
-
-				if remainingBurst[copyProcesses[i].ProcessID] == 0 {
-					waitingTime = serviceTime + 1 - copyProcesses[i].ArrivalTime - burstTime
-				} else {
-					waitingTime = serviceTime - copyProcesses[i].ArrivalTime
-				}
-				totalWait += float64(waitingTime)
-
-				start := serviceTime + waitingTime
-				turnaround := waitingTime + burstTime
-				totalTurnaround += float64(turnaround)
-
-				completion := serviceTime + 1
-				lastCompletion = float64(completion)
-
-				schedule[len(processes)-len(copyProcesses)] = []string{
-					fmt.Sprint(copyProcesses[i].ProcessID),
-					fmt.Sprint(copyProcesses[i].Priority),
-					fmt.Sprint(copyProcesses[i].BurstDuration),
-					fmt.Sprint(copyProcesses[i].ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-
-				serviceTime = completion
-
-				gantt = append(gantt, TimeSlice{
-					PID:   copyProcesses[i].ProcessID,
-					Start: start,
-					Stop:  start + turnaround,
-				})
-
-				if remainingBurst[copyProcesses[i].ProcessID] == 0 {
-					copyProcesses = append(copyProcesses[:i], copyProcesses[i+1:]...)
-					break
-				}
-			}
+	for {
+		p, err := pr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
 		}
+		if line, ok := lines[p.ProcessID]; ok {
+			return nil, fmt.Errorf("%w: line %d: PID %d is already used on line %d", ErrInvalidProcess, pr.line, p.ProcessID, line)
+		}
+		lines[p.ProcessID] = pr.line
+		processes = append(processes, p)
+	}
+	if err := scheduler.CheckDependencies(processes); err != nil {
+		return nil, err
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return processes, nil
+}
+
+// processReader reads the processes of a CSV process file a row at a time, so a file needn't fit in memory.
+type processReader struct {
+	csv     *csv.Reader
+	columns map[string]int
+	// line is the line number of the last row read.
+	line int
+}
+
+func newProcessReader(r io.Reader) *processReader {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return &processReader{csv: reader}
 }
 
-func min(a, b int64) int64 {
-	if a < b {
-		return a
+// Read returns the next process, or io.EOF after the last one. A header row is read along with the first
+// process.
+func (pr *processReader) Read() (scheduler.Process, error) {
+	row, err := pr.csv.Read()
+	if errors.Is(err, io.EOF) {
+		return scheduler.Process{}, err
 	}
-	return b
+	if err != nil {
+		return scheduler.Process{}, fmt.Errorf("%w: reading CSV", err)
+	}
+	pr.line, _ = pr.csv.FieldPos(0)
+
+	if pr.columns == nil {
+		// Spreadsheets may start the file with a byte order mark.
+		row[0] = strings.TrimPrefix(row[0], "\uFEFF")
+		pr.columns = make(map[string]int, len(csvColumns))
+		for i, c := range csvColumns {
+			pr.columns[c] = i
+		}
+		if isHeader(row) {
+			if pr.columns, err = headerColumns(row); err != nil {
+				return scheduler.Process{}, err
+			}
+			return pr.Read()
+		}
+	}
+
+	return pr.process(row)
 }
 
-//region Output helpers
+// process parses a row of the file.
+func (pr *processReader) process(row []string) (scheduler.Process, error) {
+	var (
+		p     scheduler.Process
+		err   error
+		field = func(name string) (string, bool) {
+			c, ok := pr.columns[name]
+			if !ok || c >= len(row) {
+				return "", false
+			}
+			return row[c], true
+		}
+		// number parses the named column into n, leaving n alone when the column is empty and optional.
+		number = func(name string, n *int64, required bool) error {
+			v, _ := field(name)
+			if v == "" && !required {
+				return nil
+			}
+			if *n, err = strconv.ParseInt(strings.TrimSpace(v), 10, 64); err != nil {
+				return fmt.Errorf("%w: line %d: %s %q is not a whole number", ErrInvalidProcess, pr.line, name, v)
+			}
+			return nil
+		}
+	)
+	for _, n := range []struct {
+		name     string
+		n        *int64
+		required bool
+	}{
+		{colPID, &p.ProcessID, true},
+		{colBurst, &p.BurstDuration, true},
+		{colArrival, &p.ArrivalTime, false},
+		{colPriority, &p.Priority, false},
+		{colDeadline, &p.Deadline, false},
+		{colPeriod, &p.Period, false},
+		{colGang, &p.Gang, false},
+	} {
+		if err := number(n.name, n.n, n.required); err != nil {
+			return p, err
+		}
+	}
+	if v, ok := field(colGroup); ok {
+		p.Group = scheduler.CleanCgroupPath(v)
+	}
+	if v, ok := field(colBursts); ok && v != "" {
+		if p.Bursts, err = parseBursts(v, p.BurstDuration); err != nil {
+			return p, fmt.Errorf("%w: line %d: %v", ErrInvalidProcess, pr.line, err)
+		}
+	}
+	if v, ok := field(colName); ok {
+		p.Name = strings.TrimSpace(v)
+	}
+	if v, ok := field(colDependsOn); ok {
+		for _, f := range strings.Fields(v) {
+			pid, err := strconv.ParseInt(f, 10, 64)
+			if err != nil {
+				return p, fmt.Errorf("%w: line %d: dependency %q is not a PID", ErrInvalidProcess, pr.line, f)
+			}
+			p.DependsOn = append(p.DependsOn, pid)
+		}
+	}
+	if v, ok := field(colAffinity); ok {
+		for _, f := range strings.Fields(v) {
+			cpu, err := strconv.Atoi(f)
+			if err != nil {
+				return p, fmt.Errorf("%w: line %d: affinity %q is not a CPU", ErrInvalidProcess, pr.line, f)
+			}
+			p.Affinity = append(p.Affinity, cpu)
+		}
+	}
+	if err := checkProcess(p); err != nil {
+		return p, fmt.Errorf("%w: line %d: %v", ErrInvalidProcess, pr.line, err)
+	}
 
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	return p, nil
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+// checkProcess checks that a process's PID and times aren't negative and that its burst is positive.
+func checkProcess(p scheduler.Process) error {
+	switch {
+	case p.ProcessID < 0:
+		return fmt.Errorf("process %d: PID must not be negative", p.ProcessID)
+	case p.BurstDuration <= 0:
+		return fmt.Errorf("process %d: burst must be positive", p.ProcessID)
+	case p.ArrivalTime < 0:
+		return fmt.Errorf("process %d: arrival must not be negative", p.ProcessID)
+	case p.Deadline < 0 || p.Period < 0:
+		return fmt.Errorf("process %d: deadline and period must not be negative", p.ProcessID)
 	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+	for _, cpu := range p.Affinity {
+		if cpu < 0 {
+			return fmt.Errorf("process %d: affinity CPUs must not be negative", p.ProcessID)
 		}
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
+
+	return nil
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
-	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
-	table.Render()
+// isHeader reports whether a CSV row is a header, going by its first field not being a PID.
+func isHeader(row []string) bool {
+	_, err := strconv.ParseInt(row[0], 10, 64)
+	return err != nil
 }
 
-//endregion
+// headerColumns maps the columns named by a process file's header row to their index.
+func headerColumns(row []string) (map[string]int, error) {
+	return csvHeaderColumns(row, csvAliases, []string{colPID, colBurst}, ErrInvalidProcess)
+}
 
-//region Loading processes.
+// csvHeaderColumns maps the columns named by a header row to their index, returning an errInvalid error when the
+// header names a column twice or lacks a required one. Names are case-insensitive, spaces and dashes are read as
+// underscores, and aliases maps other names to the column they stand for.
+func csvHeaderColumns(row []string, aliases map[string]string, required []string, errInvalid error) (map[string]int, error) {
+	columns := make(map[string]int, len(row))
+	for i, name := range row {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if c, ok := aliases[name]; ok {
+			name = c
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: header names %q twice", errInvalid, row[i])
+		}
+		columns[name] = i
+	}
+	for _, c := range required {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("%w: header has no %s column", errInvalid, c)
+		}
+	}
 
-var ErrInvalidArgs = errors.New("invalid args")
+	return columns, nil
+}
 
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
+// parseBursts parses space separated CPU and I/O bursts, e.g. "3 4 2" for 3 on the CPU, 4 of I/O and 2 more on
+// the CPU. The CPU bursts must add up to the process's burst.
+func parseBursts(s string, burst int64) ([]int64, error) {
+	fields := strings.Fields(s)
+	bursts := make([]int64, len(fields))
+	for i := range fields {
+		var err error
+		if bursts[i], err = strconv.ParseInt(fields[i], 10, 64); err != nil {
+			return nil, err
+		}
 	}
+	if err := checkBursts(bursts, burst); err != nil {
+		return nil, err
+	}
+
+	return bursts, nil
+}
 
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
+// checkBursts checks that bursts alternate positive CPU bursts with I/O, starting and ending on the CPU, and that
+// the CPU bursts add up to burst.
+func checkBursts(bursts []int64, burst int64) error {
+	if len(bursts)%2 == 0 {
+		return fmt.Errorf("bursts %v must start and end with a CPU burst", bursts)
+	}
+	if cpu := cpuTime(bursts); cpu != burst {
+		return fmt.Errorf("CPU bursts add up to %d, not the burst of %d", cpu, burst)
+	}
+	for i, b := range bursts {
+		switch {
+		case i%2 == 1 && b < 0:
+			return fmt.Errorf("I/O burst %d must not be negative", b)
+		case i%2 == 0 && b <= 0:
+			return fmt.Errorf("CPU burst %d must be positive", b)
 		}
 	}
 
-	return processes, nil
+	return nil
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// cpuTime returns the total of the CPU bursts in bursts.
+func cpuTime(bursts []int64) int64 {
+	var total int64
+	for i := 0; i < len(bursts); i += 2 {
+		total += bursts[i]
 	}
 
-	return i
+	return total
 }
 
 //endregion
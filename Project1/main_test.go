@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
 	"errors"
 	"io"
 	"os"
@@ -10,12 +11,14 @@ import (
 	"strings"
 	"testing"
 	"testing/iotest"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
 )
 
 func TestFCFSSchedule(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		processes []Process
+		processes []scheduler.Process
 		title     string
 	}
 	tests := []struct {
@@ -26,7 +29,7 @@ func TestFCFSSchedule(t *testing.T) {
 		{
 			name: "default",
 			args: args{
-				processes: []Process{
+				processes: []scheduler.Process{
 					{
 						ProcessID:     1,
 						ArrivalTime:   0,
@@ -56,7 +59,7 @@ func TestFCFSSchedule(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			var w bytes.Buffer
-			FCFSSchedule(&w, tt.args.title, tt.args.processes)
+			outputResult(&w, tt.args.title, scheduler.FCFS{}.Schedule(tt.args.processes), false)
 			if got := w.String(); got != tt.wantOut {
 				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
 			}
@@ -72,8 +75,10 @@ func Test_loadProcesses(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    []Process
+		want    []scheduler.Process
 		wantErr error
+		// wantMsg is part of the error message, e.g. the offending line.
+		wantMsg string
 	}{
 		{
 			name: "bad CSV",
@@ -89,7 +94,7 @@ func Test_loadProcesses(t *testing.T) {
 2,9,3,1
 3,6,3,3`),
 			},
-			want: []Process{
+			want: []scheduler.Process{
 				{
 					ProcessID:     1,
 					ArrivalTime:   0,
@@ -110,6 +115,215 @@ func Test_loadProcesses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "optional group, deadline, and period columns",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,web,12,
+2,9,3,1,,0,
+3,6,3,3,/db/,,20`),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Group:         "/web",
+					Deadline:      12,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+					Group:         "/",
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+					Group:         "/db",
+					Period:        20,
+				},
+			},
+		},
+		{
+			name: "CPU and I/O bursts",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,3 4 2
+2,9,3,1,,,,`),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Group:         "/",
+					Bursts:        []int64{3, 4, 2},
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+					Group:         "/",
+				},
+			},
+		},
+		{
+			name: "CPU bursts do not add up to the burst",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,3 4 1`),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "dependencies",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,,2 3
+2,9,3,1,,,,,
+3,6,3,3,,,,,2`),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2, Group: "/", DependsOn: []int64{2, 3}},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1, Group: "/"},
+				{ProcessID: 3, ArrivalTime: 3, BurstDuration: 6, Priority: 3, Group: "/", DependsOn: []int64{2}},
+			},
+		},
+		{
+			name: "affinity",
+			args: args{
+				r: strings.NewReader("pid,burst,cpus\n1,5,0 2\n2,9,\n"),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5, Affinity: []int{0, 2}},
+				{ProcessID: 2, BurstDuration: 9},
+			},
+		},
+		{
+			name: "gangs",
+			args: args{
+				r: strings.NewReader("pid,burst,gang\n1,5,3\n2,9,3\n3,6,\n"),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5, Gang: 3},
+				{ProcessID: 2, BurstDuration: 9, Gang: 3},
+				{ProcessID: 3, BurstDuration: 6},
+			},
+		},
+		{
+			name: "negative affinity",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,,,,-1`),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "line 1: process 1: affinity CPUs must not be negative",
+		},
+		{
+			name: "dependency cycle",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,,2
+2,9,3,1,,,,,1`),
+			},
+			wantErr: scheduler.ErrDependencyCycle,
+		},
+		{
+			name: "header row in any order",
+			args: args{
+				r: strings.NewReader("\uFEFFArrival Time,PID,Burst,Notes,Deadline,Name\n0,1,5,x,12,editor\n3,2,9,y,,compiler\n"),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "editor", ArrivalTime: 0, BurstDuration: 5, Deadline: 12},
+				{ProcessID: 2, Name: "compiler", ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "header without a burst column",
+			args: args{
+				r: strings.NewReader("pid,arrival\n1,0\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "bursts end with I/O",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,5 4`),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "not a number",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,nine,3,1\n"),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: `line 2: burst "nine" is not a whole number`,
+		},
+		{
+			name: "missing PID",
+			args: args{
+				r: strings.NewReader("pid,burst\n,5\n"),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: `line 2: pid "" is not a whole number`,
+		},
+		{
+			name: "bad dependency",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,,,,,one`),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "line 1",
+		},
+		{
+			name: "zero burst",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,0,3,1\n"),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "line 2: process 2: burst must be positive",
+		},
+		{
+			name: "negative burst",
+			args: args{
+				r: strings.NewReader("1,-5,0,2\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "negative PID",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n-1,5,0,1\n"),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "line 2: process -1: PID must not be negative",
+		},
+		{
+			name: "negative arrival",
+			args: args{
+				r: strings.NewReader("1,5,-1,2\n"),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "arrival must not be negative",
+		},
+		{
+			name: "duplicate PID",
+			args: args{
+				r: strings.NewReader("pid,burst\n1,5\n2,3\n1,4\n"),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "line 4: PID 1 is already used on line 2",
+		},
+		{
+			name: "row with too few fields",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,9\n"),
+			},
+			wantErr: csv.ErrFieldCount,
+			wantMsg: "line 2",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -122,6 +336,9 @@ func Test_loadProcesses(t *testing.T) {
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("error = %v, want %v", err, tt.wantErr)
 			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.wantMsg)
+			}
 		})
 	}
 }
@@ -140,9 +357,22 @@ func Test_openProcessingFile1(t *testing.T) {
 	if tErr != nil {
 		t.Fatal(tErr)
 	}
+	pipe, tErr := os.CreateTemp(t.TempDir(), "")
+	if tErr != nil {
+		t.Fatal(tErr)
+	}
+	terminal, tErr := os.Open(os.DevNull)
+	if tErr != nil {
+		t.Fatal(tErr)
+	}
+	t.Cleanup(func() {
+		stdin = os.Stdin
+		_ = terminal.Close()
+	})
 
 	type args struct {
-		args []string
+		args  []string
+		stdin *os.File
 	}
 	tests := []struct {
 		name    string
@@ -160,10 +390,27 @@ func Test_openProcessingFile1(t *testing.T) {
 		{
 			name: "not enough args",
 			args: args{
-				args: []string{"binary_name"},
+				args:  []string{"binary_name"},
+				stdin: terminal,
 			},
 			wantErr: true,
 		},
+		{
+			name: "dash reads stdin",
+			args: args{
+				args:  []string{"binary_name", "-"},
+				stdin: pipe,
+			},
+			want: pipe,
+		},
+		{
+			name: "no file reads a piped stdin",
+			args: args{
+				args:  []string{"binary_name"},
+				stdin: pipe,
+			},
+			want: pipe,
+		},
 		{
 			name: "bad file",
 			args: args{
@@ -174,6 +421,7 @@ func Test_openProcessingFile1(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			stdin = tt.args.stdin
 			got, closeFn, err := openProcessingFile(tt.args.args...)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("openProcessingFile() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,248 @@
+// Package memory simulates contiguous memory allocation: blocks are carved out of one region of memory as they're
+// requested and returned to it when freed, leaving holes between the blocks still in use.
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Placement strategies, which pick the hole a block is allocated at the start of.
+const (
+	// FirstFit takes the first hole, by address, that the block fits in.
+	FirstFit = "first-fit"
+	// BestFit takes the smallest hole the block fits in, leaving the smallest leftover.
+	BestFit = "best-fit"
+	// WorstFit takes the largest hole, leaving the largest leftover.
+	WorstFit = "worst-fit"
+)
+
+// Fits lists the placement strategies.
+var Fits = []string{FirstFit, BestFit, WorstFit}
+
+var (
+	ErrInvalidRequest = errors.New("invalid memory request")
+	ErrUnknownFit     = errors.New("unknown placement strategy")
+)
+
+type (
+	// Request asks for a block of memory, or frees one.
+	Request struct {
+		// ID names the block: a request allocates it, and a later request with Free set frees it.
+		ID int64
+		// Name is an optional label for the block, e.g. "editor".
+		Name string
+		Free bool
+		// Size is the block's size; frees ignore it.
+		Size int64
+	}
+	// Block is an allocated block of memory, from Start up to Stop.
+	Block struct {
+		ID    int64
+		Start int64
+		Stop  int64
+	}
+	// Step is the outcome of a request and the state of memory after it.
+	Step struct {
+		Request
+		// Address is where the block was allocated, or freed from; -1 means the allocation failed, or the block
+		// freed was never allocated because its allocation failed.
+		Address int64
+		// Holes is the number of holes, Available their total size and Largest the largest.
+		Holes     int
+		Available int64
+		Largest   int64
+		// Fragmentation is the external fragmentation: the fraction of the free memory outside the largest hole,
+		// which can't be given to a block as large as all the free memory. It is zero when the free memory is in
+		// one hole, or there is none.
+		Fragmentation float64
+	}
+	// Result is the outcome of a sequence of requests.
+	Result struct {
+		Steps []Step
+		// Blocks is the memory map after the last request, in address order.
+		Blocks []Block
+		Stats  Stats
+	}
+	// Stats are the aggregate statistics of a sequence of requests.
+	Stats struct {
+		Allocations int
+		// Failures counts the allocations there was no hole large enough for, and FragmentationFailures the ones
+		// of those that there was enough free memory for in total.
+		Failures              int
+		FragmentationFailures int
+		Frees                 int
+		// Fragmentation is the external fragmentation after the last request, and AveFragmentation its average
+		// after each request.
+		Fragmentation    float64
+		AveFragmentation float64
+		// PeakUsed is the most memory allocated at once.
+		PeakUsed int64
+	}
+)
+
+// Failed reports whether the step's allocation failed.
+func (s Step) Failed() bool {
+	return !s.Free && s.Address < 0
+}
+
+// Allocator allocates blocks from Size units of memory, starting at address zero, placing each one by Fit; empty
+// means FirstFit.
+type Allocator struct {
+	Size int64
+	Fit  string
+}
+
+// Simulate runs requests in order and returns their outcome. Freed memory merges with the holes around it.
+func (a Allocator) Simulate(requests []Request) Result {
+	var (
+		blocks   = make([]Block, 0) // in address order
+		steps    = make([]Step, 0, len(requests))
+		stats    Stats
+		used     int64
+		fragment float64
+	)
+	for _, r := range requests {
+		step := Step{Request: r, Address: -1}
+		if r.Free {
+			for i, b := range blocks {
+				if b.ID == r.ID {
+					step.Address = b.Start
+					used -= b.Stop - b.Start
+					blocks = append(blocks[:i], blocks[i+1:]...)
+					stats.Frees++
+					break
+				}
+			}
+		} else {
+			stats.Allocations++
+			holes := a.holes(blocks)
+			if h, ok := a.place(holes, r.Size); ok {
+				step.Address = h.Start
+				blocks = append(blocks, Block{ID: r.ID, Start: h.Start, Stop: h.Start + r.Size})
+				sort.Slice(blocks, func(i, j int) bool {
+					return blocks[i].Start < blocks[j].Start
+				})
+				used += r.Size
+				stats.PeakUsed = max(stats.PeakUsed, used)
+			} else {
+				stats.Failures++
+				if a.Size-used >= r.Size {
+					stats.FragmentationFailures++
+				}
+			}
+		}
+		for _, h := range a.holes(blocks) {
+			step.Holes++
+			step.Available += h.Stop - h.Start
+			step.Largest = max(step.Largest, h.Stop-h.Start)
+		}
+		if step.Available > 0 {
+			step.Fragmentation = float64(step.Available-step.Largest) / float64(step.Available)
+		}
+		fragment += step.Fragmentation
+		steps = append(steps, step)
+	}
+	if len(steps) > 0 {
+		stats.Fragmentation = steps[len(steps)-1].Fragmentation
+		stats.AveFragmentation = fragment / float64(len(steps))
+	}
+
+	return Result{Steps: steps, Blocks: blocks, Stats: stats}
+}
+
+// holes returns the free memory between blocks, in address order, as blocks with no ID.
+func (a Allocator) holes(blocks []Block) []Block {
+	var (
+		holes []Block
+		at    int64
+	)
+	for _, b := range blocks {
+		if b.Start > at {
+			holes = append(holes, Block{Start: at, Stop: b.Start})
+		}
+		at = b.Stop
+	}
+	if a.Size > at {
+		holes = append(holes, Block{Start: at, Stop: a.Size})
+	}
+
+	return holes
+}
+
+// place returns the hole a block of size goes in under the allocator's strategy, and whether there is one large
+// enough. Ties go to the lowest address.
+func (a Allocator) place(holes []Block, size int64) (Block, bool) {
+	var (
+		best  Block
+		found bool
+	)
+	for _, h := range holes {
+		n := h.Stop - h.Start
+		if n < size {
+			continue
+		}
+		if !found {
+			best, found = h, true
+			if a.Fit == FirstFit {
+				break
+			}
+			continue
+		}
+		switch bestSize := best.Stop - best.Start; a.Fit {
+		case BestFit:
+			if n < bestSize {
+				best = h
+			}
+		case WorstFit:
+			if n > bestSize {
+				best = h
+			}
+		}
+	}
+
+	return best, found
+}
+
+// CheckFit returns an ErrUnknownFit error unless fit is a placement strategy.
+func CheckFit(fit string) error {
+	for _, f := range Fits {
+		if fit == f {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q, want one of %s", ErrUnknownFit, fit, strings.Join(Fits, ", "))
+}
+
+// CheckRequests checks that every allocation asks for a positive size for a block that isn't already allocated,
+// and that every block freed was allocated and hasn't been freed since, returning an ErrInvalidRequest error for
+// the first request that doesn't.
+func CheckRequests(requests []Request) error {
+	live := make(map[int64]bool)
+	for i, r := range requests {
+		switch {
+		case r.Free && !live[r.ID]:
+			return fmt.Errorf("%w: request %d: block %d is freed but isn't allocated", ErrInvalidRequest, i+1, r.ID)
+		case r.Free:
+			delete(live, r.ID)
+		case r.Size <= 0:
+			return fmt.Errorf("%w: request %d: block %d's size must be positive", ErrInvalidRequest, i+1, r.ID)
+		case live[r.ID]:
+			return fmt.Errorf("%w: request %d: block %d is already allocated", ErrInvalidRequest, i+1, r.ID)
+		default:
+			live[r.ID] = true
+		}
+	}
+
+	return nil
+}
+
+func max(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// fragmenting leaves holes of 10 at 0, 30 at 30 and 15 at 85 in 100 units of memory, then allocates 12 and 25.
+var fragmenting = []Request{
+	{ID: 1, Size: 10},
+	{ID: 2, Size: 20},
+	{ID: 3, Size: 30},
+	{ID: 4, Size: 10},
+	{ID: 5, Size: 15},
+	{ID: 1, Free: true},
+	{ID: 3, Free: true},
+	{ID: 6, Size: 12},
+	{ID: 7, Size: 25},
+}
+
+func TestAllocator_Simulate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name              string
+		fit               string
+		wantAddresses     []int64
+		wantBlocks        []Block
+		wantStats         Stats
+		wantFragmentation float64
+	}{
+		{
+			name:          "first fit takes the first hole that fits",
+			fit:           FirstFit,
+			wantAddresses: []int64{0, 10, 30, 60, 70, 0, 30, 30, -1},
+			wantBlocks:    []Block{{ID: 2, Start: 10, Stop: 30}, {ID: 6, Start: 30, Stop: 42}, {ID: 4, Start: 60, Stop: 70}, {ID: 5, Start: 70, Stop: 85}},
+			wantStats:     Stats{Allocations: 7, Failures: 1, FragmentationFailures: 1, Frees: 2, PeakUsed: 85},
+			// Holes of 10, 18 and 15.
+			wantFragmentation: 25.0 / 43,
+		},
+		{
+			name:          "best fit keeps the large hole for the large block",
+			fit:           BestFit,
+			wantAddresses: []int64{0, 10, 30, 60, 70, 0, 30, 85, 30},
+			wantBlocks: []Block{
+				{ID: 2, Start: 10, Stop: 30}, {ID: 7, Start: 30, Stop: 55}, {ID: 4, Start: 60, Stop: 70},
+				{ID: 5, Start: 70, Stop: 85}, {ID: 6, Start: 85, Stop: 97},
+			},
+			wantStats: Stats{Allocations: 7, Frees: 2, PeakUsed: 85},
+			// Holes of 10, 5 and 3.
+			wantFragmentation: 8.0 / 18,
+		},
+		{
+			name:              "worst fit takes the largest hole",
+			fit:               WorstFit,
+			wantAddresses:     []int64{0, 10, 30, 60, 70, 0, 30, 30, -1},
+			wantBlocks:        []Block{{ID: 2, Start: 10, Stop: 30}, {ID: 6, Start: 30, Stop: 42}, {ID: 4, Start: 60, Stop: 70}, {ID: 5, Start: 70, Stop: 85}},
+			wantStats:         Stats{Allocations: 7, Failures: 1, FragmentationFailures: 1, Frees: 2, PeakUsed: 85},
+			wantFragmentation: 25.0 / 43,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Allocator{Size: 100, Fit: tt.fit}.Simulate(fragmenting)
+			addresses := make([]int64, len(got.Steps))
+			for i, s := range got.Steps {
+				addresses[i] = s.Address
+			}
+			if !reflect.DeepEqual(addresses, tt.wantAddresses) {
+				t.Errorf("Simulate() addresses = %v, want %v", addresses, tt.wantAddresses)
+			}
+			if !reflect.DeepEqual(got.Blocks, tt.wantBlocks) {
+				t.Errorf("Simulate() blocks = %v, want %v", got.Blocks, tt.wantBlocks)
+			}
+			if math.Abs(got.Stats.Fragmentation-tt.wantFragmentation) > 1e-9 {
+				t.Errorf("Simulate() fragmentation = %v, want %v", got.Stats.Fragmentation, tt.wantFragmentation)
+			}
+			got.Stats.Fragmentation, got.Stats.AveFragmentation = 0, 0
+			if !reflect.DeepEqual(got.Stats, tt.wantStats) {
+				t.Errorf("Simulate() stats = %+v, want %+v", got.Stats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestAllocator_Simulate_free(t *testing.T) {
+	t.Parallel()
+	requests := []Request{
+		{ID: 1, Size: 10},
+		{ID: 2, Size: 20},
+		{ID: 3, Size: 80},
+		{ID: 1, Free: true},
+		{ID: 3, Free: true},
+		{ID: 2, Free: true},
+	}
+	got := Allocator{Size: 50}.Simulate(requests)
+	want := []Step{
+		{Request: requests[0], Address: 0, Holes: 1, Available: 40, Largest: 40},
+		{Request: requests[1], Address: 10, Holes: 1, Available: 20, Largest: 20},
+		{Request: requests[2], Address: -1, Holes: 1, Available: 20, Largest: 20},
+		{Request: requests[3], Address: 0, Holes: 2, Available: 30, Largest: 20, Fragmentation: 10.0 / 30},
+		// Block 3 was never allocated, so freeing it changes nothing.
+		{Request: requests[4], Address: -1, Holes: 2, Available: 30, Largest: 20, Fragmentation: 10.0 / 30},
+		// The freed block merges with the holes on either side.
+		{Request: requests[5], Address: 10, Holes: 1, Available: 50, Largest: 50},
+	}
+	if !reflect.DeepEqual(got.Steps, want) {
+		t.Errorf("Simulate() steps =\n%+v\nwant\n%+v", got.Steps, want)
+	}
+	if !got.Steps[2].Failed() || got.Steps[4].Failed() {
+		t.Errorf("Failed() is only true of the failed allocation")
+	}
+	if got.Stats.Frees != 2 || got.Stats.FragmentationFailures != 0 {
+		t.Errorf("Simulate() stats = %+v, want 2 frees and no fragmentation failures", got.Stats)
+	}
+}
+
+func TestCheckRequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		requests []Request
+		wantErr  error
+	}{
+		{name: "success", requests: fragmenting},
+		{name: "reallocating a freed block", requests: []Request{{ID: 1, Size: 1}, {ID: 1, Free: true}, {ID: 1, Size: 2}}},
+		{name: "zero size", requests: []Request{{ID: 1}}, wantErr: ErrInvalidRequest},
+		{name: "allocated twice", requests: []Request{{ID: 1, Size: 1}, {ID: 1, Size: 2}}, wantErr: ErrInvalidRequest},
+		{name: "never allocated", requests: []Request{{ID: 1, Free: true}}, wantErr: ErrInvalidRequest},
+		{name: "freed twice", requests: []Request{{ID: 1, Size: 1}, {ID: 1, Free: true}, {ID: 1, Free: true}}, wantErr: ErrInvalidRequest},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckRequests(tt.requests); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckRequests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckFit(t *testing.T) {
+	t.Parallel()
+	for _, fit := range Fits {
+		if err := CheckFit(fit); err != nil {
+			t.Errorf("CheckFit(%q) error = %v", fit, err)
+		}
+	}
+	if err := CheckFit("next-fit"); !errors.Is(err, ErrUnknownFit) {
+		t.Errorf("CheckFit(\"next-fit\") error = %v, want %v", err, ErrUnknownFit)
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/memory"
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// Memory request file columns, in the order they are read from a file without a header row.
+const (
+	memColID   = "id"
+	memColOp   = "op"
+	memColSize = "size"
+	memColName = "name"
+)
+
+// Memory request operations.
+const (
+	memOpAlloc = "alloc"
+	memOpFree  = "free"
+)
+
+var (
+	memColumns = []string{memColID, memColOp, memColSize, memColName}
+	// memAliases maps other common header names to their column.
+	memAliases = map[string]string{
+		"block":     memColID,
+		"block_id":  memColID,
+		"pid":       memColID,
+		"operation": memColOp,
+		"request":   memColOp,
+		"action":    memColOp,
+		"bytes":     memColSize,
+		"label":     memColName,
+	}
+	// memOps maps the accepted spellings of each operation to it.
+	memOps = map[string]string{
+		"alloc": memOpAlloc, "allocate": memOpAlloc, "malloc": memOpAlloc, "a": memOpAlloc,
+		"free": memOpFree, "release": memOpFree, "f": memOpFree,
+	}
+)
+
+// memRequest is a request in a JSON memory request file.
+type memRequest struct {
+	ID   int64  `json:"id"`
+	Op   string `json:"op"`
+	Size int64  `json:"size,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+//region Simulating memory allocation
+
+// runMemsim runs the memsim subcommand: it runs a file of memory requests through a contiguous allocator under
+// each placement strategy and writes the memory map, a table of the requests' outcomes and the fragmentation of
+// each to w, followed by a comparison of the strategies when there are several.
+func runMemsim(args []string, w io.Writer) error {
+	var (
+		fs     = flag.NewFlagSet("memsim", flag.ContinueOnError)
+		size   int64
+		fits   string
+		format string
+		color  string
+	)
+	fs.Int64Var(&size, "size", 1024, "size of memory, in the units of the requests")
+	fs.StringVar(&fits, "fit", strings.Join(memory.Fits, ","), "comma separated placement strategies to compare, first-fit, best-fit or worst-fit")
+	fs.StringVar(&format, "format", "", "request file format, csv or json; detected from the file's extension if empty")
+	fs.StringVar(&color, "color", colorAuto, "color the memory map, auto (when writing to a terminal), always or never")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if size <= 0 {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidArgs)
+	}
+	strategies := parseAlgorithms(fits)
+	for _, fit := range strategies {
+		if err := memory.CheckFit(fit); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+	}
+	f, closeFile, err := openProcessingFile(append([]string{"memsim"}, fs.Args()...)...)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+	if format, err = workloadFormat(format, f.Name()); err != nil {
+		return err
+	}
+	requests, err := loadMemRequests(f, format)
+	if err != nil {
+		return err
+	}
+
+	results := make([]memory.Result, len(strategies))
+	for i, fit := range strategies {
+		results[i] = memory.Allocator{Size: size, Fit: fit}.Simulate(requests)
+		outputMemory(w, memTitle(fit, size), size, results[i], useColor(color, w))
+	}
+	if len(strategies) > 1 {
+		outputMemoryComparison(w, strategies, size, results)
+	}
+
+	return nil
+}
+
+// memTitle returns the title of a placement strategy's report, e.g. "First fit (memory 1024)".
+func memTitle(fit string, size int64) string {
+	name := strings.ReplaceAll(fit, "-", " ")
+	return fmt.Sprintf("%s%s (memory %d)", strings.ToUpper(name[:1]), name[1:], size)
+}
+
+//endregion
+
+//region Loading memory requests
+
+// loadMemRequests reads memory requests in the given format, CSV or JSON, and checks them.
+func loadMemRequests(r io.Reader, format string) ([]memory.Request, error) {
+	var (
+		requests []memory.Request
+		err      error
+	)
+	switch format {
+	case formatCSV:
+		requests, err = loadMemRequestsCSV(r)
+	case formatJSON:
+		requests, err = loadMemRequestsJSON(r)
+	default:
+		return nil, fmt.Errorf("%w: %q: memory requests are read from CSV or JSON", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := memory.CheckRequests(requests); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// loadMemRequestsCSV reads memory requests from CSV, a row per request: the block's ID, the operation, alloc or
+// free, the size to allocate and an optional name, e.g. "1,alloc,100,editor" and "1,free". The columns are in
+// that order, unless the first row is a header naming them.
+func loadMemRequestsCSV(r io.Reader) ([]memory.Request, error) {
+	var (
+		reader   = csv.NewReader(r)
+		columns  map[string]int
+		requests = make([]memory.Request, 0)
+	)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if columns == nil {
+			row[0] = strings.TrimPrefix(row[0], "\uFEFF")
+			columns = make(map[string]int, len(memColumns))
+			for i, c := range memColumns {
+				columns[c] = i
+			}
+			if isHeader(row) {
+				if columns, err = csvHeaderColumns(row, memAliases, []string{memColID, memColOp}, memory.ErrInvalidRequest); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		field := func(name string) string {
+			if c, ok := columns[name]; ok && c < len(row) {
+				return strings.TrimSpace(row[c])
+			}
+			return ""
+		}
+		req := memRequest{Op: field(memColOp), Name: field(memColName)}
+		if req.ID, err = strconv.ParseInt(field(memColID), 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: line %d: id %q is not a whole number", memory.ErrInvalidRequest, line, field(memColID))
+		}
+		if v := field(memColSize); v != "" {
+			if req.Size, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: line %d: size %q is not a whole number", memory.ErrInvalidRequest, line, v)
+			}
+		}
+		request, err := req.request()
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", memory.ErrInvalidRequest, line, err)
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// loadMemRequestsJSON reads memory requests from a JSON array of objects, e.g.
+//
+//	[{"id": 1, "op": "alloc", "size": 100, "name": "editor"}, {"id": 1, "op": "free"}]
+func loadMemRequestsJSON(r io.Reader) ([]memory.Request, error) {
+	var raw []memRequest
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	}
+	requests := make([]memory.Request, len(raw))
+	for i, req := range raw {
+		var err error
+		if requests[i], err = req.request(); err != nil {
+			return nil, fmt.Errorf("%w: request %d: %v", memory.ErrInvalidRequest, i+1, err)
+		}
+	}
+
+	return requests, nil
+}
+
+// request converts a request read from a file.
+func (r memRequest) request() (memory.Request, error) {
+	op, ok := memOps[strings.ToLower(r.Op)]
+	if !ok {
+		return memory.Request{}, fmt.Errorf("unknown operation %q, want alloc or free", r.Op)
+	}
+
+	return memory.Request{ID: r.ID, Name: r.Name, Free: op == memOpFree, Size: r.Size}, nil
+}
+
+//endregion
+
+//region Memory output
+
+// outputMemory outputs the outcome of a placement strategy as a map of memory after the last request, a table of
+// each request's outcome and the state of memory after it, and the allocation failures and fragmentation.
+func outputMemory(w io.Writer, title string, size int64, result memory.Result, colored bool) {
+	names := make(map[int64]string)
+	for _, s := range result.Steps {
+		if s.Name != "" {
+			names[s.ID] = s.Name
+		}
+	}
+	blocks := make([]scheduler.TimeSlice, len(result.Blocks))
+	for i, b := range result.Blocks {
+		blocks[i] = scheduler.TimeSlice{PID: b.ID, Start: b.Start, Stop: b.Stop}
+	}
+	outputTitle(w, title)
+	_, _ = fmt.Fprintln(w, "Memory map")
+	outputGanttRow(w, blocks, names, ganttColumns(size), ganttGap{label: "free", end: size}, colored)
+
+	_, _ = fmt.Fprintln(w, "Allocation table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"#", "Request", "Size", "Address", "Holes", "Free", "Largest Hole", "Fragmentation"})
+	for i, s := range result.Steps {
+		op, address, size := memOpAlloc, strconv.FormatInt(s.Address, 10), strconv.FormatInt(s.Size, 10)
+		switch {
+		case s.Free:
+			op, size = memOpFree, ""
+			if s.Address < 0 {
+				address = "-"
+			}
+		case s.Failed():
+			address = "failed"
+		}
+		table.Append([]string{
+			strconv.Itoa(i + 1),
+			op + " " + label(s.ID, names),
+			size,
+			address,
+			strconv.Itoa(s.Holes),
+			strconv.FormatInt(s.Available, 10),
+			strconv.FormatInt(s.Largest, 10),
+			fmt.Sprintf("%.1f%%", 100*s.Fragmentation),
+		})
+	}
+	table.SetFooter([]string{"", "", "", "", "", "", "Average", fmt.Sprintf("%.1f%%", 100*result.Stats.AveFragmentation)})
+	table.Render()
+
+	stats := result.Stats
+	_, _ = fmt.Fprintf(w, "Allocations: %d  Failures: %d (%d with enough memory free)  Frees: %d  Peak use: %d of %d (%.1f%%)\n",
+		stats.Allocations, stats.Failures, stats.FragmentationFailures, stats.Frees,
+		stats.PeakUsed, size, 100*float64(stats.PeakUsed)/float64(size))
+	_, _ = fmt.Fprintf(w, "External fragmentation: %.1f%% (average %.1f%%)\n\n", 100*stats.Fragmentation, 100*stats.AveFragmentation)
+}
+
+// outputMemoryComparison outputs a table comparing the failures and fragmentation of the placement strategies.
+func outputMemoryComparison(w io.Writer, fits []string, size int64, results []memory.Result) {
+	outputTitle(w, fmt.Sprintf("Placement strategies (memory %d)", size))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Fit", "Failures", "Fragmentation Failures", "Fragmentation", "Ave Fragmentation", "Peak Use"})
+	for i, r := range results {
+		table.Append([]string{
+			fits[i],
+			strconv.Itoa(r.Stats.Failures),
+			strconv.Itoa(r.Stats.FragmentationFailures),
+			fmt.Sprintf("%.1f%%", 100*r.Stats.Fragmentation),
+			fmt.Sprintf("%.1f%%", 100*r.Stats.AveFragmentation),
+			strconv.FormatInt(r.Stats.PeakUsed, 10),
+		})
+	}
+	table.Render()
+}
+
+//endregion
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/memory"
+)
+
+func Test_loadMemRequests(t *testing.T) {
+	t.Parallel()
+	want := []memory.Request{
+		{ID: 1, Size: 10},
+		{ID: 2, Name: "editor", Size: 20},
+		{ID: 1, Free: true},
+	}
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []memory.Request
+		wantErr error
+	}{
+		{
+			name:   "CSV without a header",
+			format: formatCSV,
+			input:  "1,alloc,10\n2,malloc,20,editor\n1,free\n",
+			want:   want,
+		},
+		{
+			name:   "CSV with a reordered header and aliases",
+			format: formatCSV,
+			input:  "\uFEFFoperation,bytes,block,label\nalloc,10,1,\nALLOC,20,2,editor\nrelease,,1,\n",
+			want:   want,
+		},
+		{
+			name:   "JSON",
+			format: formatJSON,
+			input:  `[{"id": 1, "op": "alloc", "size": 10}, {"id": 2, "op": "alloc", "size": 20, "name": "editor"}, {"id": 1, "op": "free"}]`,
+			want:   want,
+		},
+		{
+			name:    "unknown operation",
+			format:  formatCSV,
+			input:   "1,alloc,10\n1,realloc,20\n",
+			wantErr: memory.ErrInvalidRequest,
+		},
+		{
+			name:    "size not a number",
+			format:  formatCSV,
+			input:   "1,alloc,ten\n",
+			wantErr: memory.ErrInvalidRequest,
+		},
+		{
+			name:    "header missing the operation",
+			format:  formatCSV,
+			input:   "id,size\n1,10\n",
+			wantErr: memory.ErrInvalidRequest,
+		},
+		{
+			name:    "freeing a block that isn't allocated",
+			format:  formatJSON,
+			input:   `[{"id": 1, "op": "free"}]`,
+			wantErr: memory.ErrInvalidRequest,
+		},
+		{
+			name:    "YAML",
+			format:  formatYAML,
+			input:   "- id: 1\n",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadMemRequests(strings.NewReader(tt.input), tt.format)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadMemRequests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadMemRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runMemsim(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	if err := runMemsim([]string{"-size", "100", "-color", colorNever, "example_memory_requests.csv"}, &b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"First fit (memory 100)",
+		"Best fit (memory 100)",
+		"Worst fit (memory 100)",
+		"Placement strategies (memory 100)",
+		"| 9 | alloc 7      |   25 | failed  |",
+		"Allocations: 7  Failures: 1 (1 with enough memory free)  Frees: 2  Peak use: 85 of 100 (85.0%)",
+		"External fragmentation: 58.1% (average 22.4%)",
+		"| best-fit  |        0 |                      0 | 44.4%         | 17.8%             |       85 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runMemsim() output is missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "Memory map") != 3 {
+		t.Errorf("runMemsim() output should have a memory map per fit:\n%s", got)
+	}
+}
+
+func Test_runMemsim_invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "unknown fit", args: []string{"-fit", "next-fit", "example_memory_requests.csv"}, wantErr: ErrInvalidArgs},
+		{name: "no memory", args: []string{"-size", "0", "example_memory_requests.csv"}, wantErr: ErrInvalidArgs},
+		{name: "unknown flag", args: []string{"-quantum", "2"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := runMemsim(tt.args, &bytes.Buffer{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("runMemsim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recentRuns is the number of an algorithm's most recent schedules its average wait and turnaround are taken over.
+const recentRuns = 100
+
+var (
+	// latencyBuckets are the upper bounds, in seconds, of the request latency histogram's buckets.
+	latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	// sizeBuckets are the upper bounds of the histogram of the number of processes each schedule completed.
+	sizeBuckets = []float64{1, 10, 100, 1000, 10000, 100000}
+)
+
+// metrics are the server's counters and histograms, exposed in the Prometheus text format. They're kept in memory
+// from when the server starts.
+type metrics struct {
+	mu sync.Mutex
+	// requests counts the requests by path and then status code, and latency their durations by path.
+	requests map[string]map[int]int64
+	latency  map[string]*histogram
+	// simulations counts the schedules run by algorithm, and size the processes each completed.
+	simulations map[string]int64
+	size        *histogram
+	// recent holds the average wait and turnaround of each algorithm's most recent schedules, oldest first.
+	recent map[string][]runStats
+}
+
+// runStats are the statistics of a schedule kept for the recent averages.
+type runStats struct {
+	wait, turnaround float64
+}
+
+// histogram counts observations into cumulative buckets.
+type histogram struct {
+	bounds []float64
+	counts []int64 // per bucket, not cumulative
+	count  int64
+	sum    float64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests:    make(map[string]map[int]int64),
+		latency:     make(map[string]*histogram),
+		simulations: make(map[string]int64),
+		size:        newHistogram(sizeBuckets),
+		recent:      make(map[string][]runStats),
+	}
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]int64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	h.count++
+	h.sum += v
+	for i, bound := range h.bounds {
+		if v <= bound {
+			h.counts[i]++
+			return
+		}
+	}
+}
+
+//region Recording metrics
+
+// instrument wraps handler to count the requests to path and time them.
+func (m *metrics) instrument(path string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handler(rec, r)
+		m.observeRequest(path, rec.status, time.Since(start))
+	}
+}
+
+// statusRecorder records the status code a handler responds with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (m *metrics) observeRequest(path string, status int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.requests[path] == nil {
+		m.requests[path] = make(map[int]int64)
+		m.latency[path] = newHistogram(latencyBuckets)
+	}
+	m.requests[path][status]++
+	m.latency[path].observe(d.Seconds())
+}
+
+// observeSchedules records the schedules of a request.
+func (m *metrics) observeSchedules(schedules []jsonReport) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, sc := range schedules {
+		m.simulations[sc.Algorithm]++
+		m.size.observe(float64(len(sc.Processes)))
+		recent := append(m.recent[sc.Algorithm], runStats{wait: sc.Stats.AveWait, turnaround: sc.Stats.AveTurnaround})
+		if len(recent) > recentRuns {
+			recent = recent[len(recent)-recentRuns:]
+		}
+		m.recent[sc.Algorithm] = recent
+	}
+}
+
+//endregion
+
+//region Exposing metrics
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (m *metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "use GET"})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.write(w)
+}
+
+// write writes the metrics in the Prometheus text format, with series in label order so the output is stable.
+func (m *metrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metricHeader(w, "scheduler_http_requests_total", "counter", "HTTP requests handled, by path and status code.")
+	for _, path := range sortedKeys(m.requests) {
+		codes := make([]int, 0, len(m.requests[path]))
+		for code := range m.requests[path] {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			_, _ = fmt.Fprintf(w, "scheduler_http_requests_total{%s,%s} %d\n",
+				promLabel("code", strconv.Itoa(code)), promLabel("path", path), m.requests[path][code])
+		}
+	}
+	metricHeader(w, "scheduler_http_request_duration_seconds", "histogram", "Time taken to handle HTTP requests, by path.")
+	for _, path := range sortedKeys(m.latency) {
+		writeHistogram(w, "scheduler_http_request_duration_seconds", promLabel("path", path), m.latency[path])
+	}
+
+	metricHeader(w, "scheduler_simulations_total", "counter", "Schedules run, by algorithm.")
+	for _, algorithm := range sortedKeys(m.simulations) {
+		_, _ = fmt.Fprintf(w, "scheduler_simulations_total{%s} %d\n", promLabel("algorithm", algorithm), m.simulations[algorithm])
+	}
+	metricHeader(w, "scheduler_simulation_processes", "histogram", "Processes completed by each schedule run.")
+	writeHistogram(w, "scheduler_simulation_processes", "", m.size)
+
+	metricHeader(w, "scheduler_recent_average_wait", "gauge",
+		fmt.Sprintf("Mean of the average wait of each algorithm's last %d schedules.", recentRuns))
+	for _, algorithm := range sortedKeys(m.recent) {
+		_, _ = fmt.Fprintf(w, "scheduler_recent_average_wait{%s} %s\n", promLabel("algorithm", algorithm),
+			formatFloat(meanOf(m.recent[algorithm], func(r runStats) float64 { return r.wait })))
+	}
+	metricHeader(w, "scheduler_recent_average_turnaround", "gauge",
+		fmt.Sprintf("Mean of the average turnaround of each algorithm's last %d schedules.", recentRuns))
+	for _, algorithm := range sortedKeys(m.recent) {
+		_, _ = fmt.Fprintf(w, "scheduler_recent_average_turnaround{%s} %s\n", promLabel("algorithm", algorithm),
+			formatFloat(meanOf(m.recent[algorithm], func(r runStats) float64 { return r.turnaround })))
+	}
+}
+
+func metricHeader(w io.Writer, name, kind, help string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeHistogram writes the cumulative buckets, sum and count of a histogram, with labels, as name="value" pairs
+// separated by commas, on every series.
+func writeHistogram(w io.Writer, name, labels string, h *histogram) {
+	prefix := labels
+	if prefix != "" {
+		prefix += ","
+	}
+	var cumulative int64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		_, _ = fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", name, prefix, formatFloat(bound), cumulative)
+	}
+	_, _ = fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, prefix, h.count)
+	series := ""
+	if labels != "" {
+		series = "{" + labels + "}"
+	}
+	_, _ = fmt.Fprintf(w, "%s_sum%s %s\n%s_count%s %d\n", name, series, formatFloat(h.sum), name, series, h.count)
+}
+
+// labelEscaper escapes label values as the Prometheus text format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promLabel formats a label as name="value".
+func promLabel(name, value string) string {
+	return name + `="` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func meanOf(runs []runStats, value func(runStats) float64) float64 {
+	var sum float64
+	for _, r := range runs {
+		sum += value(r)
+	}
+
+	return sum / float64(len(runs))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+//endregion
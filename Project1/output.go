@@ -0,0 +1,442 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+//region Output helpers
+
+// ganttColors are the ANSI background colors of processes' bars in colored GANTT charts, chosen by PID.
+var ganttColors = []string{"44", "43", "41", "46", "42", "45", "104", "103", "101", "106", "102", "105"}
+
+// pidColor chooses a process's color from colors by its PID, which may be negative.
+func pidColor(colors []string, pid int64) string {
+	n := int64(len(colors))
+	return colors[((pid%n)+n)%n]
+}
+
+// outputResult outputs a schedule as a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • the result of a scheduler
+// • whether to color the chart and the averages with ANSI escapes
+func outputResult(w io.Writer, title string, result scheduler.ScheduleResult, colored bool) {
+	outputTitle(w, title)
+	outputGantt(w, result.Gantt, processNames(result.Processes), colored)
+	outputSchedule(w, result.Processes, result.Stats, colored)
+	outputUtilization(w, result.Stats)
+	if result.Stats.Fragmentation > 0 {
+		outputFragmentation(w, result.Stats)
+	}
+	if result.Stats.Energy > 0 {
+		outputEnergy(w, result.Stats)
+	}
+	if result.Stats.Switches > 0 {
+		outputSwitches(w, result.Gantt, result.Stats)
+	}
+	if len(result.Cgroups) > 0 {
+		outputCgroups(w, result.Cgroups)
+	}
+	if len(result.Shares) > 0 {
+		outputShares(w, result.Shares)
+	}
+	if len(result.Dispatches) > 0 {
+		outputDispatches(w, result.Dispatches)
+	}
+	if len(result.Predictions) > 0 {
+		outputPredictions(w, result.Predictions, result.Stats)
+	}
+	if result.Schedulability != nil {
+		outputSchedulability(w, *result.Schedulability, result.Stats.DeadlineMisses)
+	}
+	if len(result.Cores) > 0 {
+		outputCores(w, result.Cores, result.Stats)
+	}
+}
+
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+// processNames maps the PIDs of named processes to their names.
+func processNames(rows []scheduler.ProcessResult) map[int64]string {
+	names := make(map[int64]string)
+	for i := range rows {
+		if rows[i].Name != "" {
+			names[rows[i].ProcessID] = rows[i].Name
+		}
+	}
+
+	return names
+}
+
+// label returns the name of a process, or its PID when it has none.
+func label(pid int64, names map[int64]string) string {
+	if name, ok := names[pid]; ok {
+		return name
+	}
+
+	return fmt.Sprint(pid)
+}
+
+func outputGantt(w io.Writer, gantt []scheduler.TimeSlice, names map[int64]string, colored bool) {
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	outputGanttRows(w, gantt, names, colored)
+}
+
+// ganttWidth is the widest a text GANTT chart gets, in characters, and ganttUnit the most characters a unit of
+// time gets.
+const (
+	ganttWidth = 80
+	ganttUnit  = 4
+)
+
+func outputGanttRows(w io.Writer, gantt []scheduler.TimeSlice, names map[int64]string, colored bool) {
+	// Every row shares the time scale, so multi-core rows line up.
+	var (
+		cpus   int
+		length int64
+	)
+	for i := range gantt {
+		if gantt[i].CPU >= cpus {
+			cpus = gantt[i].CPU + 1
+		}
+		if gantt[i].Stop > length {
+			length = gantt[i].Stop
+		}
+	}
+	column := ganttColumns(length)
+
+	if cpus <= 1 {
+		outputGanttRow(w, gantt, names, column, ganttGap{label: "idle"}, colored)
+		return
+	}
+	for cpu := 0; cpu < cpus; cpu++ {
+		row := make([]scheduler.TimeSlice, 0)
+		for i := range gantt {
+			if gantt[i].CPU == cpu {
+				row = append(row, gantt[i])
+			}
+		}
+		_, _ = fmt.Fprintf(w, "CPU %d\n", cpu)
+		outputGanttRow(w, row, names, column, ganttGap{label: "idle"}, colored)
+	}
+}
+
+// ganttColumns returns the column of a time in a GANTT chart of the given length.
+func ganttColumns(length int64) func(int64) int {
+	scale := float64(ganttWidth) / float64(length)
+	if scale >= 1 {
+		// Whole characters per unit keep equal durations equally wide.
+		scale = math.Min(ganttUnit, math.Floor(scale))
+	}
+
+	return func(t int64) int {
+		return int(math.Round(float64(t) * scale))
+	}
+}
+
+// ganttGap is how a GANTT chart row shows the gaps between its bars: with label, and up to end, when it's past
+// the last bar.
+type ganttGap struct {
+	label string
+	end   int64
+}
+
+// outputGanttRow outputs a row of a GANTT chart, with a bar per slice as wide as its duration, gap bars for the
+// gaps between slices, and a time axis with each bar's start under its left edge. column gives the column of a
+// time. Bars too narrow for their label get as much of it as fits. Colored bars get a background color per
+// process, with switches in reverse video and gaps dimmed.
+func outputGanttRow(w io.Writer, gantt []scheduler.TimeSlice, names map[int64]string, column func(int64) int, gap ganttGap, colored bool) {
+	type bar struct {
+		label       string
+		start, stop int64
+		color       string // SGR parameters
+	}
+	var (
+		bars []bar
+		at   int64
+	)
+	for i := range gantt {
+		if gantt[i].Start > at {
+			bars = append(bars, bar{label: gap.label, start: at, stop: gantt[i].Start, color: "2"})
+		}
+		text, color := label(gantt[i].PID, names), "30;"+pidColor(ganttColors, gantt[i].PID)
+		if gantt[i].Switch {
+			text, color = "cs", "7"
+		}
+		bars = append(bars, bar{label: text, start: gantt[i].Start, stop: gantt[i].Stop, color: color})
+		at = gantt[i].Stop
+	}
+	if gap.end > at {
+		bars = append(bars, bar{label: gap.label, start: at, stop: gap.end, color: "2"})
+	}
+
+	var (
+		chart strings.Builder
+		axis  []byte
+	)
+	mark := func(t int64) {
+		col, text := column(t), fmt.Sprint(t)
+		// Skip times that would run into the previous one.
+		if len(axis) > 0 && col <= len(strings.TrimRight(string(axis), " ")) {
+			return
+		}
+		for len(axis) < col {
+			axis = append(axis, ' ')
+		}
+		axis = append(axis[:col], text...)
+	}
+	chart.WriteString("|")
+	for i, b := range bars {
+		width := column(b.stop) - column(b.start) - 1
+		if width < 0 {
+			// Too short to show at this scale.
+			continue
+		}
+		text := b.label
+		if len(text) > width {
+			text = text[:width]
+		}
+		left := (width - len(text)) / 2
+		cell := strings.Repeat(" ", left) + text + strings.Repeat(" ", width-left-len(text))
+		if colored {
+			cell = "\x1b[" + b.color + "m" + cell + "\x1b[0m"
+		}
+		chart.WriteString(cell + "|")
+		mark(b.start)
+		if i == len(bars)-1 {
+			mark(b.stop)
+		}
+	}
+	_, _ = fmt.Fprintln(w, chart.String())
+	_, _ = fmt.Fprintf(w, "%s\n\n", axis)
+}
+
+func outputSchedule(w io.Writer, rows []scheduler.ProcessResult, stats scheduler.Stats, colored bool) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	header, body := scheduleTable(rows)
+	table.SetHeader(header)
+	table.AppendBulk(body)
+	footer := []string{"", "", "",
+		fmt.Sprintf("Fairness\n%.2f", stats.Fairness),
+		fmt.Sprintf("Average\n%.2f", stats.AveWait),
+		fmt.Sprintf("Average\n%.2f", stats.AveResponse),
+		fmt.Sprintf("Average\n%.2f", stats.AveTurnaround),
+		fmt.Sprintf("Throughput\n%.2f/t", stats.AveThroughput)}
+	if header[1] == "Name" {
+		footer = append([]string{""}, footer...)
+	}
+	if header[len(header)-1] == "Deadline" {
+		footer = append(footer, fmt.Sprintf("Misses\n%d", stats.DeadlineMisses))
+	}
+	table.SetFooter(footer)
+	if colored {
+		colors := make([]tablewriter.Colors, len(footer))
+		for i := range colors {
+			colors[i] = tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}
+		}
+		table.SetFooterColor(colors...)
+	}
+	table.Render()
+}
+
+// scheduleTable returns the header and rows of a schedule table. Only workloads that have names and deadlines
+// get a Name and a Deadline column.
+func scheduleTable(rows []scheduler.ProcessResult) ([]string, [][]string) {
+	names, deadlines := false, false
+	for i := range rows {
+		names = names || rows[i].Name != ""
+		deadlines = deadlines || rows[i].Deadline > 0
+	}
+
+	header := []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Response", "Turnaround", "Exit"}
+	if names {
+		header = append(header[:1], append([]string{"Name"}, header[1:]...)...)
+	}
+	if deadlines {
+		header = append(header, "Deadline")
+	}
+	body := make([][]string, len(rows))
+	for i := range rows {
+		row := []string{
+			fmt.Sprint(rows[i].ProcessID),
+			fmt.Sprint(rows[i].Priority),
+			fmt.Sprint(rows[i].BurstDuration),
+			fmt.Sprint(rows[i].ArrivalTime),
+			fmt.Sprint(rows[i].Wait),
+			fmt.Sprint(rows[i].Response),
+			fmt.Sprint(rows[i].Turnaround),
+			fmt.Sprint(rows[i].Completion),
+		}
+		if names {
+			row = append(row[:1], append([]string{rows[i].Name}, row[1:]...)...)
+		}
+		if deadlines {
+			row = append(row, outputDeadline(rows[i]))
+		}
+		body[i] = row
+	}
+
+	return header, body
+}
+
+func outputUtilization(w io.Writer, stats scheduler.Stats) {
+	_, _ = fmt.Fprintf(w, "Makespan: %d  CPU utilization: %.1f%%  Idle time: %d\n\n",
+		stats.Makespan, stats.Utilization*100, stats.IdleTime)
+}
+
+func outputFragmentation(w io.Writer, stats scheduler.Stats) {
+	_, _ = fmt.Fprintf(w, "Gang fragmentation: %d CPU time idle while a gang waited (%.1f%% of the idle time)\n\n",
+		stats.Fragmentation, float64(stats.Fragmentation)/float64(stats.IdleTime)*100)
+}
+
+func outputEnergy(w io.Writer, stats scheduler.Stats) {
+	_, _ = fmt.Fprintf(w, "Energy: %.2f  Energy-delay product: %.2f\n\n", stats.Energy, stats.EnergyDelay)
+}
+
+func outputSwitches(w io.Writer, gantt []scheduler.TimeSlice, stats scheduler.Stats) {
+	var length int64
+	for i := range gantt {
+		if gantt[i].Stop > length {
+			length = gantt[i].Stop
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Context switches: %d  Time lost: %d (%.1f%% of %d)\n\n",
+		stats.Switches, stats.SwitchTime, float64(stats.SwitchTime)/float64(length)*100, length)
+}
+
+func outputDeadline(row scheduler.ProcessResult) string {
+	switch {
+	case row.Deadline == 0:
+		return "-"
+	case row.MissedDeadline():
+		return fmt.Sprintf("%d MISSED", row.Deadline)
+	default:
+		return fmt.Sprint(row.Deadline)
+	}
+}
+
+func outputCgroups(w io.Writer, stats []scheduler.CgroupStats) {
+	_, _ = fmt.Fprintln(w, "Cgroup table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Group", "Quota", "Period", "Usage", "Throttled", "Throttled time"})
+	for _, s := range stats {
+		quota, period := "max", "-"
+		if s.Quota > 0 {
+			quota, period = fmt.Sprint(s.Quota), fmt.Sprint(s.Period)
+		}
+		table.Append([]string{
+			s.Path,
+			quota,
+			period,
+			fmt.Sprint(s.Usage),
+			fmt.Sprint(s.NrThrottled),
+			fmt.Sprint(s.ThrottledTime),
+		})
+	}
+	table.Render()
+}
+
+func outputShares(w io.Writer, shares []scheduler.Share) {
+	_, _ = fmt.Fprintln(w, "Share table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Tickets", "Ticket share", "CPU share"})
+	for _, s := range shares {
+		table.Append([]string{
+			fmt.Sprint(s.PID),
+			fmt.Sprint(s.Tickets),
+			fmt.Sprintf("%.1f%%", s.TicketShare*100),
+			fmt.Sprintf("%.1f%%", s.CPUShare*100),
+		})
+	}
+	table.Render()
+}
+
+func outputSchedulability(w io.Writer, s scheduler.Schedulability, misses int) {
+	verdict := "guaranteed schedulable (U <= bound)"
+	switch {
+	case !s.Feasible():
+		verdict = "not schedulable (U > 1)"
+	case !s.Guaranteed():
+		verdict = "inconclusive (bound < U <= 1), see simulated misses"
+	}
+
+	_, _ = fmt.Fprintln(w, "Schedulability")
+	_, _ = fmt.Fprintf(w, "Tasks: %d  Hyperperiod: %d\n", s.Tasks, s.Hyperperiod)
+	_, _ = fmt.Fprintf(w, "Utilization: %.3f  Bound: %.3f  %s\n", s.Utilization, s.Bound, verdict)
+	_, _ = fmt.Fprintf(w, "Deadline misses: %d\n\n", misses)
+}
+
+// outputCores writes the CPU table, with a Pinned column of the time spent running processes with an affinity
+// when any ran, and the load imbalance.
+func outputCores(w io.Writer, cores []scheduler.CoreStats, stats scheduler.Stats) {
+	var pinned bool
+	for _, c := range cores {
+		pinned = pinned || c.Pinned > 0
+	}
+	_, _ = fmt.Fprintln(w, "CPU table")
+	table := tablewriter.NewWriter(w)
+	header := []string{"CPU", "Busy", "Utilization"}
+	if pinned {
+		header = append(header, "Pinned")
+	}
+	table.SetHeader(header)
+	for _, c := range cores {
+		row := []string{
+			fmt.Sprint(c.CPU),
+			fmt.Sprint(c.Busy),
+			fmt.Sprintf("%.1f%%", c.Utilization*100),
+		}
+		if pinned {
+			row = append(row, fmt.Sprint(c.Pinned))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Load imbalance: %.1f%% (the busiest CPU over the average)\n\n", stats.LoadImbalance*100)
+}
+
+func outputPredictions(w io.Writer, predictions []scheduler.Prediction, stats scheduler.Stats) {
+	_, _ = fmt.Fprintln(w, "Prediction table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Burst", "Predicted", "Actual", "Error"})
+	for _, p := range predictions {
+		table.Append([]string{
+			fmt.Sprint(p.PID),
+			fmt.Sprint(p.Burst),
+			fmt.Sprintf("%.2f", p.Predicted),
+			fmt.Sprint(p.Actual),
+			fmt.Sprintf("%+.2f", p.Predicted-float64(p.Actual)),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Mean absolute prediction error: %.2f\n\n", stats.PredictionError)
+}
+
+func outputDispatches(w io.Writer, dispatches []scheduler.Dispatch) {
+	_, _ = fmt.Fprintln(w, "Dispatch table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Time", "ID", "Priority", "Effective priority"})
+	for _, d := range dispatches {
+		table.Append([]string{
+			fmt.Sprint(d.Time),
+			fmt.Sprint(d.PID),
+			fmt.Sprint(d.Priority),
+			fmt.Sprint(d.EffectivePriority),
+		})
+	}
+	table.Render()
+}
+
+//endregion
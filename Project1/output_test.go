@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_outputGanttRows(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		gantt   []scheduler.TimeSlice
+		names   map[int64]string
+		colored bool
+		want    string
+	}{
+		{
+			name: "idle gap",
+			gantt: []scheduler.TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 5, Stop: 7},
+			},
+			want: "|     1     | idle  |   2   |\n" +
+				"0           3       5       7\n\n",
+		},
+		{
+			name: "long schedule",
+			gantt: []scheduler.TimeSlice{
+				{PID: 1, Start: 0, Stop: 100},
+				{PID: 2, Start: 100, Stop: 101},
+				{PID: 3, Start: 101, Stop: 160},
+			},
+			names: map[int64]string{1: "compiler"},
+			want: "|                    compiler                     ||             3              |\n" +
+				"0                                                 100                           160\n\n",
+		},
+		{
+			name: "two CPUs",
+			gantt: []scheduler.TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 1, Stop: 3, CPU: 1},
+				{PID: 3, Start: 3, Stop: 4, CPU: 1, Switch: true},
+			},
+			want: "CPU 0\n" +
+				"|       1       |\n" +
+				"0               4\n\n" +
+				"CPU 1\n" +
+				"|idl|   2   |cs |\n" +
+				"0   1       3   4\n\n",
+		},
+		{
+			name: "colored",
+			gantt: []scheduler.TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 2, Stop: 3, Switch: true},
+				{PID: 2, Start: 3, Stop: 4},
+			},
+			colored: true,
+			want: "|\x1b[30;43m 1 \x1b[0m|\x1b[2midl\x1b[0m|\x1b[7mcs \x1b[0m|\x1b[30;41m 2 \x1b[0m|\n" +
+				"0   1   2   3   4\n\n",
+		},
+		{
+			name: "colored negative PID",
+			gantt: []scheduler.TimeSlice{
+				{PID: -1, Start: 0, Stop: 2},
+			},
+			colored: true,
+			want: "|\x1b[30;105m  -1   \x1b[0m|\n" +
+				"0       2\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGanttRows(&w, tt.gantt, tt.names, tt.colored)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputGanttRows() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
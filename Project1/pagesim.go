@@ -0,0 +1,173 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/paging"
+)
+
+// pageTitles holds the report title of each replacement policy.
+var pageTitles = map[string]string{
+	paging.FIFO:    "FIFO",
+	paging.LRU:     "LRU",
+	paging.Clock:   "Clock",
+	paging.Optimal: "Optimal",
+}
+
+//region Simulating page replacement
+
+// runPagesim runs the pagesim subcommand: it serves a reference string, given with -refs or in a file, from a
+// number of frames under each replacement policy, and writes a table of the frames after each reference and the
+// faults of each to w, followed by a comparison of the policies when there are several.
+func runPagesim(args []string, w io.Writer) error {
+	var (
+		fs       = flag.NewFlagSet("pagesim", flag.ContinueOnError)
+		frames   int
+		policies string
+		refList  string
+		format   string
+	)
+	fs.IntVar(&frames, "frames", 3, "number of page frames")
+	fs.StringVar(&policies, "policy", strings.Join(paging.Policies, ","), "comma separated replacement policies to compare, fifo, lru, clock or optimal")
+	fs.StringVar(&refList, "refs", "", "reference string of page numbers separated by commas or spaces, instead of a file")
+	fs.StringVar(&format, "format", "", "reference file format, csv or json; detected from the file's extension if empty")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if frames <= 0 {
+		return fmt.Errorf("%w: frames must be positive", ErrInvalidArgs)
+	}
+	names := parseAlgorithms(policies)
+	for _, policy := range names {
+		if err := paging.CheckPolicy(policy); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+	}
+	refs, err := pageReferences(refList, format, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	results := make([]paging.Result, len(names))
+	for i, policy := range names {
+		results[i] = paging.Pager{Frames: frames, Policy: policy}.Simulate(refs)
+		outputPaging(w, fmt.Sprintf("%s (%d frames)", pageTitles[policy], frames), frames, results[i])
+	}
+	if len(names) > 1 {
+		outputPagingComparison(w, names, frames, results)
+	}
+
+	return nil
+}
+
+// pageReferences returns the reference string given with -refs, or else read from the file named in args.
+func pageReferences(refList, format string, args []string) ([]int64, error) {
+	if refList != "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%w: give a reference string with -refs or a file, not both", ErrInvalidArgs)
+		}
+		return loadPageReferences(strings.NewReader(refList), formatCSV)
+	}
+	f, closeFile, err := openProcessingFile(append([]string{"pagesim"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+	if format, err = workloadFormat(format, f.Name()); err != nil {
+		return nil, err
+	}
+
+	return loadPageReferences(f, format)
+}
+
+//endregion
+
+//region Loading reference strings
+
+// loadPageReferences reads a reference string in the given format and checks it.
+func loadPageReferences(r io.Reader, format string) ([]int64, error) {
+	refs, err := loadNumbers(r, format, "page", paging.ErrInvalidReference)
+	if err != nil {
+		return nil, err
+	}
+	if err := paging.CheckReferences(refs); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+//endregion
+
+//region Paging output
+
+// outputPaging outputs the outcome of a replacement policy as a table of the page in each frame after each
+// reference, marking faults and the page each one evicted, followed by the fault count and rate. Under Clock a
+// "*" marks a page whose reference bit is set and a ">" the frame the hand points to.
+func outputPaging(w io.Writer, title string, frames int, result paging.Result) {
+	outputTitle(w, title)
+	_, _ = fmt.Fprintln(w, "Frame table")
+	table := tablewriter.NewWriter(w)
+	header := []string{"#", "Page"}
+	for i := 1; i <= frames; i++ {
+		header = append(header, fmt.Sprintf("Frame %d", i))
+	}
+	table.SetHeader(append(header, "Fault", "Evicted"))
+	// Clock's markers would otherwise push its pages to the left of the others.
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	for i, s := range result.Steps {
+		row := []string{strconv.Itoa(i + 1), strconv.FormatInt(s.Page, 10)}
+		for f, page := range s.Frames {
+			var cell string
+			if page >= 0 {
+				cell = strconv.FormatInt(page, 10)
+			}
+			if s.Referenced != nil {
+				if s.Referenced[f] {
+					cell += "*"
+				}
+				if s.Hand == f {
+					cell = ">" + cell
+				}
+			}
+			row = append(row, cell)
+		}
+		fault, evicted := "", ""
+		if s.Fault {
+			fault = "fault"
+		}
+		if s.Evicted >= 0 {
+			evicted = strconv.FormatInt(s.Evicted, 10)
+		}
+		table.Append(append(row, fault, evicted))
+	}
+	table.Render()
+
+	stats := result.Stats
+	_, _ = fmt.Fprintf(w, "References: %d  Faults: %d  Hits: %d  Fault rate: %.1f%%\n\n",
+		stats.References, stats.Faults, stats.Hits, 100*stats.FaultRate)
+}
+
+// outputPagingComparison outputs a table comparing the faults of the replacement policies.
+func outputPagingComparison(w io.Writer, policies []string, frames int, results []paging.Result) {
+	outputTitle(w, fmt.Sprintf("Replacement policies (%d frames)", frames))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Policy", "Faults", "Hits", "Fault Rate"})
+	for i, r := range results {
+		table.Append([]string{
+			policies[i],
+			strconv.Itoa(r.Stats.Faults),
+			strconv.Itoa(r.Stats.Hits),
+			fmt.Sprintf("%.1f%%", 100*r.Stats.FaultRate),
+		})
+	}
+	table.Render()
+}
+
+//endregion
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/paging"
+)
+
+func Test_loadPageReferences(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []int64
+		wantErr error
+	}{
+		{name: "CSV", format: formatCSV, input: "7,0,1\n2, 0\n", want: []int64{7, 0, 1, 2, 0}},
+		{name: "spaces", format: formatCSV, input: "\uFEFF7 0 1  2\n0\n", want: []int64{7, 0, 1, 2, 0}},
+		{name: "JSON", format: formatJSON, input: "[7, 0, 1, 2, 0]", want: []int64{7, 0, 1, 2, 0}},
+		{name: "not a number", format: formatCSV, input: "7,a,1", wantErr: paging.ErrInvalidReference},
+		{name: "negative page", format: formatJSON, input: "[7, -1]", wantErr: paging.ErrInvalidReference},
+		{name: "empty", format: formatCSV, input: "\n", wantErr: paging.ErrInvalidReference},
+		{name: "YAML", format: formatYAML, input: "- 7\n", wantErr: ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadPageReferences(strings.NewReader(tt.input), tt.format)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadPageReferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadPageReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runPagesim(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	if err := runPagesim([]string{"-refs", "7,0,1,2,0,3,0,4,2,3,0,3,2,1,2,0,1,7,0,1"}, &b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"FIFO (3 frames)",
+		"Clock (3 frames)",
+		"|  4 |    2 |       2 |       0 |       1 | fault |       7 |",
+		"References: 20  Faults: 15  Hits: 5  Fault rate: 75.0%",
+		"| optimal |      9 |   11 | 45.0%      |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runPagesim() output is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func Test_runPagesim_invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "unknown policy", args: []string{"-policy", "mru", "-refs", "1"}, wantErr: ErrInvalidArgs},
+		{name: "no frames", args: []string{"-frames", "0", "-refs", "1"}, wantErr: ErrInvalidArgs},
+		{name: "references and a file", args: []string{"-refs", "1", "refs.csv"}, wantErr: ErrInvalidArgs},
+		{name: "bad reference", args: []string{"-refs", "1,x"}, wantErr: paging.ErrInvalidReference},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := runPagesim(tt.args, &bytes.Buffer{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("runPagesim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
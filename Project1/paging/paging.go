@@ -0,0 +1,196 @@
+// Package paging simulates demand paging: a string of page references is served from a fixed number of frames,
+// and a reference to a page that isn't in one faults, replacing a page chosen by the replacement policy once the
+// frames are full.
+package paging
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Replacement policies, which pick the page a fault evicts.
+const (
+	// FIFO evicts the page that was loaded first.
+	FIFO = "fifo"
+	// LRU evicts the page that was referenced least recently.
+	LRU = "lru"
+	// Clock gives each page a second chance: a hand sweeps the frames in order, clearing the reference bit of
+	// every page that has one and evicting the first that doesn't.
+	Clock = "clock"
+	// Optimal evicts the page that won't be referenced for the longest, which needs the future reference string,
+	// so it is a lower bound on faults for the others rather than a real policy.
+	Optimal = "optimal"
+)
+
+// Policies lists the replacement policies.
+var Policies = []string{FIFO, LRU, Clock, Optimal}
+
+var (
+	ErrInvalidReference = errors.New("invalid page reference")
+	ErrUnknownPolicy    = errors.New("unknown replacement policy")
+)
+
+type (
+	// Step is the outcome of a reference and the state of the frames after it.
+	Step struct {
+		Page  int64
+		Fault bool
+		// Evicted is the page the fault replaced, or -1 when it went in an empty frame or there was no fault.
+		Evicted int64
+		// Frames holds the page in each frame, -1 for an empty one.
+		Frames []int64
+		// Referenced holds each frame's reference bit under Clock, and Hand the frame the hand points to next;
+		// Referenced is nil under the other policies.
+		Referenced []bool
+		Hand       int
+	}
+	// Result is the outcome of a reference string.
+	Result struct {
+		Steps []Step
+		Stats Stats
+	}
+	// Stats are the aggregate statistics of a reference string.
+	Stats struct {
+		References int
+		Faults     int
+		Hits       int
+		// FaultRate is the fraction of references that faulted.
+		FaultRate float64
+	}
+)
+
+// Pager serves references from Frames frames, replacing pages by Policy; empty means FIFO.
+type Pager struct {
+	Frames int
+	Policy string
+}
+
+// Simulate runs the references in order and returns their outcome. The frames start empty, and fill in order
+// before any page is replaced.
+func (p Pager) Simulate(refs []int64) Result {
+	var (
+		frames     = make([]int64, max(p.Frames, 0))
+		loaded     = make([]int, len(frames)) // when each frame's page was loaded
+		used       = make([]int, len(frames)) // when each frame's page was last referenced
+		referenced = make([]bool, len(frames))
+		hand       int
+		steps      = make([]Step, 0, len(refs))
+		stats      = Stats{References: len(refs)}
+	)
+	for i := range frames {
+		frames[i] = -1
+	}
+	for t, page := range refs {
+		frame := index(frames, page)
+		step := Step{Page: page, Fault: frame < 0, Evicted: -1}
+		if step.Fault {
+			stats.Faults++
+		} else {
+			stats.Hits++
+		}
+		if step.Fault && len(frames) > 0 {
+			if frame = index(frames, -1); frame < 0 {
+				frame, hand = p.victim(frames, loaded, used, referenced, hand, refs[t+1:])
+				step.Evicted = frames[frame]
+			} else if p.Policy == Clock {
+				hand = (frame + 1) % len(frames)
+			}
+			frames[frame], loaded[frame] = page, t
+		}
+		if frame >= 0 {
+			used[frame], referenced[frame] = t, true
+		}
+		step.Frames = append([]int64(nil), frames...)
+		if p.Policy == Clock {
+			step.Referenced, step.Hand = append([]bool(nil), referenced...), hand
+		}
+		steps = append(steps, step)
+	}
+	if stats.References > 0 {
+		stats.FaultRate = float64(stats.Faults) / float64(stats.References)
+	}
+
+	return Result{Steps: steps, Stats: stats}
+}
+
+// victim returns the full frame to replace under the pager's policy, and where Clock's hand points after it.
+// future holds the references after the current one. Ties go to the lowest frame.
+func (p Pager) victim(frames []int64, loaded, used []int, referenced []bool, hand int, future []int64) (int, int) {
+	switch p.Policy {
+	case LRU:
+		return oldest(used), hand
+	case Clock:
+		for referenced[hand] {
+			referenced[hand] = false
+			hand = (hand + 1) % len(frames)
+		}
+		return hand, (hand + 1) % len(frames)
+	case Optimal:
+		victim, furthest := 0, -1
+		for i, page := range frames {
+			next := index(future, page)
+			if next < 0 {
+				return i, hand
+			}
+			if next > furthest {
+				victim, furthest = i, next
+			}
+		}
+		return victim, hand
+	default:
+		return oldest(loaded), hand
+	}
+}
+
+// oldest returns the index of the smallest time, the first of equals.
+func oldest(times []int) int {
+	first := 0
+	for i, t := range times {
+		if t < times[first] {
+			first = i
+		}
+	}
+
+	return first
+}
+
+// index returns the index of the first page in pages equal to page, or -1 if there is none.
+func index(pages []int64, page int64) int {
+	for i, p := range pages {
+		if p == page {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// CheckPolicy returns an ErrUnknownPolicy error unless policy is a replacement policy.
+func CheckPolicy(policy string) error {
+	for _, p := range Policies {
+		if policy == p {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q, want one of %s", ErrUnknownPolicy, policy, strings.Join(Policies, ", "))
+}
+
+// CheckReferences returns an ErrInvalidReference error for the first reference to a negative page.
+func CheckReferences(refs []int64) error {
+	for i, page := range refs {
+		if page < 0 {
+			return fmt.Errorf("%w: reference %d: page %d is negative", ErrInvalidReference, i+1, page)
+		}
+	}
+
+	return nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,127 @@
+package paging
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPager_Simulate(t *testing.T) {
+	t.Parallel()
+	textbook := []int64{7, 0, 1, 2, 0, 3, 0, 4, 2, 3, 0, 3, 2, 1, 2, 0, 1, 7, 0, 1}
+	stallings := []int64{2, 3, 2, 1, 5, 2, 4, 5, 3, 2, 5, 2}
+	belady := []int64{1, 2, 3, 4, 1, 2, 5, 1, 2, 3, 4, 5}
+	tests := []struct {
+		name       string
+		pager      Pager
+		refs       []int64
+		wantFaults int
+	}{
+		{name: "FIFO", pager: Pager{Frames: 3, Policy: FIFO}, refs: textbook, wantFaults: 15},
+		{name: "LRU", pager: Pager{Frames: 3, Policy: LRU}, refs: textbook, wantFaults: 12},
+		{name: "Optimal", pager: Pager{Frames: 3, Policy: Optimal}, refs: textbook, wantFaults: 9},
+		{name: "FIFO, Stallings", pager: Pager{Frames: 3, Policy: FIFO}, refs: stallings, wantFaults: 9},
+		{name: "LRU, Stallings", pager: Pager{Frames: 3, Policy: LRU}, refs: stallings, wantFaults: 7},
+		{name: "Clock, Stallings", pager: Pager{Frames: 3, Policy: Clock}, refs: stallings, wantFaults: 8},
+		{name: "Optimal, Stallings", pager: Pager{Frames: 3, Policy: Optimal}, refs: stallings, wantFaults: 6},
+		// Belady's anomaly: FIFO faults more with more frames.
+		{name: "FIFO, 3 frames", pager: Pager{Frames: 3}, refs: belady, wantFaults: 9},
+		{name: "FIFO, 4 frames", pager: Pager{Frames: 4}, refs: belady, wantFaults: 10},
+		{name: "no frames", pager: Pager{Policy: LRU}, refs: []int64{1, 1}, wantFaults: 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.pager.Simulate(tt.refs)
+			if got.Stats.Faults != tt.wantFaults || got.Stats.Hits != len(tt.refs)-tt.wantFaults {
+				t.Errorf("Simulate() stats = %+v, want %d faults", got.Stats, tt.wantFaults)
+			}
+			var faults int
+			for _, s := range got.Steps {
+				if s.Fault {
+					faults++
+				}
+			}
+			if faults != tt.wantFaults {
+				t.Errorf("Simulate() has %d faulting steps, want %d", faults, tt.wantFaults)
+			}
+		})
+	}
+}
+
+func TestPager_Simulate_steps(t *testing.T) {
+	t.Parallel()
+	refs := []int64{1, 2, 1, 3, 4}
+	tests := []struct {
+		name  string
+		pager Pager
+		want  []Step
+	}{
+		{
+			name:  "FIFO evicts the first loaded, even though it was just used",
+			pager: Pager{Frames: 2, Policy: FIFO},
+			want: []Step{
+				{Page: 1, Fault: true, Evicted: -1, Frames: []int64{1, -1}},
+				{Page: 2, Fault: true, Evicted: -1, Frames: []int64{1, 2}},
+				{Page: 1, Evicted: -1, Frames: []int64{1, 2}},
+				{Page: 3, Fault: true, Evicted: 1, Frames: []int64{3, 2}},
+				{Page: 4, Fault: true, Evicted: 2, Frames: []int64{3, 4}},
+			},
+		},
+		{
+			name:  "LRU keeps the page just used",
+			pager: Pager{Frames: 2, Policy: LRU},
+			want: []Step{
+				{Page: 1, Fault: true, Evicted: -1, Frames: []int64{1, -1}},
+				{Page: 2, Fault: true, Evicted: -1, Frames: []int64{1, 2}},
+				{Page: 1, Evicted: -1, Frames: []int64{1, 2}},
+				{Page: 3, Fault: true, Evicted: 2, Frames: []int64{1, 3}},
+				{Page: 4, Fault: true, Evicted: 1, Frames: []int64{4, 3}},
+			},
+		},
+		{
+			name:  "Clock clears reference bits as the hand passes",
+			pager: Pager{Frames: 2, Policy: Clock},
+			want: []Step{
+				{Page: 1, Fault: true, Evicted: -1, Frames: []int64{1, -1}, Referenced: []bool{true, false}, Hand: 1},
+				{Page: 2, Fault: true, Evicted: -1, Frames: []int64{1, 2}, Referenced: []bool{true, true}},
+				{Page: 1, Evicted: -1, Frames: []int64{1, 2}, Referenced: []bool{true, true}},
+				// Both have a second chance, so the hand goes all the way round to frame 0.
+				{Page: 3, Fault: true, Evicted: 1, Frames: []int64{3, 2}, Referenced: []bool{true, false}, Hand: 1},
+				{Page: 4, Fault: true, Evicted: 2, Frames: []int64{3, 4}, Referenced: []bool{true, true}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.pager.Simulate(refs); !reflect.DeepEqual(got.Steps, tt.want) {
+				t.Errorf("Simulate() steps =\n%+v\nwant\n%+v", got.Steps, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckReferences(t *testing.T) {
+	t.Parallel()
+	if err := CheckReferences([]int64{0, 1, 2}); err != nil {
+		t.Errorf("CheckReferences() error = %v", err)
+	}
+	if err := CheckReferences([]int64{1, -1}); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("CheckReferences() error = %v, want %v", err, ErrInvalidReference)
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	t.Parallel()
+	for _, policy := range Policies {
+		if err := CheckPolicy(policy); err != nil {
+			t.Errorf("CheckPolicy(%q) error = %v", policy, err)
+		}
+	}
+	if err := CheckPolicy("mru"); !errors.Is(err, ErrUnknownPolicy) {
+		t.Errorf("CheckPolicy(\"mru\") error = %v, want %v", err, ErrUnknownPolicy)
+	}
+}
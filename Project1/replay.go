@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// eventVerbs describe each kind of event.
+var eventVerbs = map[scheduler.EventKind]string{
+	scheduler.EventComplete: "completes",
+	scheduler.EventBlock:    "blocks on I/O",
+	scheduler.EventPreempt:  "is preempted",
+	scheduler.EventArrive:   "arrives",
+	scheduler.EventDispatch: "is dispatched",
+}
+
+//region Replay
+
+// replay prints a schedule's events to w as they happen, at speed time units a second, calling sleep to wait
+// out the time between them.
+func replay(w io.Writer, title string, result scheduler.ScheduleResult, speed float64, sleep func(time.Duration)) {
+	outputTitle(w, title)
+	names := processNames(result.Processes)
+	var now int64
+	for _, e := range result.Events() {
+		if e.Time > now {
+			sleep(time.Duration(float64(e.Time-now) / speed * float64(time.Second)))
+			now = e.Time
+		}
+		where := "      "
+		if e.CPU >= 0 {
+			where = fmt.Sprintf("CPU %-2d", e.CPU)
+		}
+		_, _ = fmt.Fprintf(w, "[%5d] %s %s %s\n", e.Time, where, label(e.PID, names), eventVerbs[e.Kind])
+	}
+	_, _ = fmt.Fprintf(w, "Done at %d.\n\n", result.Stats.Makespan)
+}
+
+//endregion
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_replay(t *testing.T) {
+	t.Parallel()
+	result := scheduler.FCFS{}.Schedule([]scheduler.Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 2},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 3},
+	})
+	var (
+		out    bytes.Buffer
+		slept  []time.Duration
+		record = func(d time.Duration) { slept = append(slept, d) }
+	)
+	replay(&out, "FCFS", result, 2, record)
+
+	want := "--------\n   FCFS\n--------\n" +
+		"[    0]        editor arrives\n" +
+		"[    0] CPU 0  editor is dispatched\n" +
+		"[    2] CPU 0  editor completes\n" +
+		"[    3]        2 arrives\n" +
+		"[    3] CPU 0  2 is dispatched\n" +
+		"[    4] CPU 0  2 completes\n" +
+		"Done at 4.\n\n"
+	if got := out.String(); got != want {
+		t.Errorf("replay() =\n%s\nwant\n%s", got, want)
+	}
+	if wantSlept := []time.Duration{time.Second, time.Second / 2, time.Second / 2}; !reflect.DeepEqual(slept, wantSlept) {
+		t.Errorf("replay() slept %v, want %v", slept, wantSlept)
+	}
+}
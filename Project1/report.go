@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// Report output formats.
+const (
+	outputText     = "text"
+	outputJSON     = "json"
+	outputCSV      = "csv"
+	outputMarkdown = "markdown"
+	outputSVG      = "svg"
+	outputHTML     = "html"
+	outputChrome   = "chrome"
+)
+
+// renderer writes the report of the named algorithm's schedule.
+type renderer func(w io.Writer, name, title string, result scheduler.ScheduleResult) error
+
+// reportRenderer returns the renderer of the output format of s, and the extension of its report files.
+func reportRenderer(s settings) (renderer, string, error) {
+	switch s.Output {
+	case outputText, "":
+		return func(w io.Writer, _, title string, result scheduler.ScheduleResult) error {
+			outputResult(w, title, result, useColor(s.Color, w))
+			if s.Verbose {
+				outputTimelines(w, result)
+			}
+			if s.Worst > 0 {
+				outputWorst(w, result, s.Worst)
+			}
+			return nil
+		}, "txt", nil
+	case outputJSON:
+		return outputResultJSON, "json", nil
+	case outputCSV:
+		return csvRenderer(), "csv", nil
+	case outputMarkdown:
+		return outputResultMarkdown, "md", nil
+	case outputSVG:
+		return outputGanttSVG, "svg", nil
+	case outputHTML:
+		return htmlRenderer(), "html", nil
+	case outputChrome:
+		return chromeRenderer(), "trace.json", nil
+	default:
+		return nil, "", fmt.Errorf("%w: unknown output format %q", ErrInvalidArgs, s.Output)
+	}
+}
+
+//region JSON reports
+
+// jsonReport is the JSON report of an algorithm's schedule.
+type jsonReport struct {
+	Algorithm string        `json:"algorithm"`
+	Title     string        `json:"title"`
+	Gantt     []jsonSlice   `json:"gantt"`
+	Processes []jsonProcess `json:"processes"`
+	Stats     jsonStats     `json:"stats"`
+}
+
+type jsonSlice struct {
+	PID   int64 `json:"pid"`
+	Start int64 `json:"start"`
+	Stop  int64 `json:"stop"`
+	CPU   int   `json:"cpu"`
+	// Switch marks context switch overhead.
+	Switch bool `json:"switch,omitempty"`
+}
+
+type jsonProcess struct {
+	PID        int64  `json:"pid"`
+	Name       string `json:"name,omitempty"`
+	Priority   int64  `json:"priority"`
+	Burst      int64  `json:"burst"`
+	Arrival    int64  `json:"arrival"`
+	Wait       int64  `json:"wait"`
+	Response   int64  `json:"response"`
+	Turnaround int64  `json:"turnaround"`
+	Completion int64  `json:"completion"`
+	Deadline   int64  `json:"deadline,omitempty"`
+	Suspended  int64  `json:"suspended,omitempty"`
+}
+
+type jsonStats struct {
+	AveWait        float64 `json:"average_wait"`
+	AveResponse    float64 `json:"average_response"`
+	AveTurnaround  float64 `json:"average_turnaround"`
+	AveThroughput  float64 `json:"throughput"`
+	Fairness       float64 `json:"fairness"`
+	DeadlineMisses int     `json:"deadline_misses"`
+	Switches       int     `json:"switches"`
+	SwitchTime     int64   `json:"switch_time"`
+	Makespan       int64   `json:"makespan"`
+	IdleTime       int64   `json:"idle_time"`
+	Utilization    float64 `json:"utilization"`
+	LoadImbalance  float64 `json:"load_imbalance,omitempty"`
+	Fragmentation  int64   `json:"fragmentation,omitempty"`
+	// PredictionError is only reported by schedulers that predict bursts.
+	PredictionError float64 `json:"prediction_error,omitempty"`
+	// Energy and EnergyDelay are only reported under an energy model.
+	Energy      float64 `json:"energy,omitempty"`
+	EnergyDelay float64 `json:"energy_delay,omitempty"`
+}
+
+// outputResultJSON writes a schedule as a JSON object on one line, so reports of several algorithms written to
+// the same output can be read back a line at a time.
+func outputResultJSON(w io.Writer, name, title string, result scheduler.ScheduleResult) error {
+	return json.NewEncoder(w).Encode(newJSONReport(name, title, result))
+}
+
+// newJSONReport returns the JSON report of the named algorithm's schedule.
+func newJSONReport(name, title string, result scheduler.ScheduleResult) jsonReport {
+	report := jsonReport{
+		Algorithm: name,
+		Title:     title,
+		Gantt:     make([]jsonSlice, len(result.Gantt)),
+		Processes: make([]jsonProcess, len(result.Processes)),
+		Stats: jsonStats{
+			AveWait:         result.Stats.AveWait,
+			AveResponse:     result.Stats.AveResponse,
+			AveTurnaround:   result.Stats.AveTurnaround,
+			AveThroughput:   result.Stats.AveThroughput,
+			Fairness:        result.Stats.Fairness,
+			DeadlineMisses:  result.Stats.DeadlineMisses,
+			Switches:        result.Stats.Switches,
+			SwitchTime:      result.Stats.SwitchTime,
+			Makespan:        result.Stats.Makespan,
+			IdleTime:        result.Stats.IdleTime,
+			Utilization:     result.Stats.Utilization,
+			LoadImbalance:   result.Stats.LoadImbalance,
+			Fragmentation:   result.Stats.Fragmentation,
+			PredictionError: result.Stats.PredictionError,
+			Energy:          result.Stats.Energy,
+			EnergyDelay:     result.Stats.EnergyDelay,
+		},
+	}
+	for i, s := range result.Gantt {
+		report.Gantt[i] = jsonSlice{PID: s.PID, Start: s.Start, Stop: s.Stop, CPU: s.CPU, Switch: s.Switch}
+	}
+	for i, p := range result.Processes {
+		report.Processes[i] = jsonProcess{
+			PID:        p.ProcessID,
+			Name:       p.Name,
+			Priority:   p.Priority,
+			Burst:      p.BurstDuration,
+			Arrival:    p.ArrivalTime,
+			Wait:       p.Wait,
+			Response:   p.Response,
+			Turnaround: p.Turnaround,
+			Completion: p.Completion,
+			Deadline:   p.Deadline,
+			Suspended:  p.Suspended,
+		}
+	}
+
+	return report
+}
+
+//endregion
+
+//region CSV reports
+
+// csvHeader is the header of CSV reports.
+var csvHeader = []string{"algorithm", "id", "name", "priority", "burst", "arrival", "wait", "response", "turnaround", "completion", "deadline"}
+
+// csvRenderer returns a renderer writing a schedule's table as CSV rows followed by a summary row of averages.
+// Rows are labelled with the algorithm, and the header is only written once per writer, so the reports of
+// several algorithms written to the same output make a single table.
+func csvRenderer() renderer {
+	headed := make(map[io.Writer]bool)
+	return func(w io.Writer, name, _ string, result scheduler.ScheduleResult) error {
+		cw := csv.NewWriter(w)
+		if !headed[w] {
+			headed[w] = true
+			_ = cw.Write(csvHeader)
+		}
+		for _, p := range result.Processes {
+			_ = cw.Write(csvRow(name, p))
+		}
+		_ = cw.Write(csvAverages(name, result.Stats))
+		cw.Flush()
+
+		return cw.Error()
+	}
+}
+
+// csvRow returns the row of a process in a CSV report.
+func csvRow(name string, p scheduler.ProcessResult) []string {
+	deadline := ""
+	if p.Deadline > 0 {
+		deadline = strconv.FormatInt(p.Deadline, 10)
+	}
+
+	return []string{
+		name,
+		strconv.FormatInt(p.ProcessID, 10),
+		p.Name,
+		strconv.FormatInt(p.Priority, 10),
+		strconv.FormatInt(p.BurstDuration, 10),
+		strconv.FormatInt(p.ArrivalTime, 10),
+		strconv.FormatInt(p.Wait, 10),
+		strconv.FormatInt(p.Response, 10),
+		strconv.FormatInt(p.Turnaround, 10),
+		strconv.FormatInt(p.Completion, 10),
+		deadline,
+	}
+}
+
+// csvAverages returns the summary row of a CSV report.
+func csvAverages(name string, stats scheduler.Stats) []string {
+	return []string{
+		name, "average", "", "", "", "",
+		strconv.FormatFloat(stats.AveWait, 'f', 2, 64),
+		strconv.FormatFloat(stats.AveResponse, 'f', 2, 64),
+		strconv.FormatFloat(stats.AveTurnaround, 'f', 2, 64),
+		"", "",
+	}
+}
+
+// streamFCFS schedules the processes of a CSV process file first-come, first-serve while reading them, writing
+// each process's row of a CSV report to w as it's dispatched and the averages at the end. Only the statistics
+// are held in memory, so the file can be larger than memory, but its processes must be in order of arrival,
+// without I/O or dependencies.
+func streamFCFS(w io.Writer, r io.Reader, s settings) error {
+	var (
+		pr = newProcessReader(r)
+		cw = csv.NewWriter(w)
+	)
+	_ = cw.Write(csvHeader)
+	stats, err := scheduler.FCFS{Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: s.tieBreak()}.Stream(pr.Read, func(row scheduler.ProcessResult) error {
+		return cw.Write(csvRow(algoFCFS, row))
+	})
+	if err != nil {
+		return fmt.Errorf("%w: line %d", err, pr.line)
+	}
+	_ = cw.Write(csvAverages(algoFCFS, stats))
+	cw.Flush()
+
+	return cw.Error()
+}
+
+//endregion
+
+//region Markdown reports
+
+// outputResultMarkdown writes a schedule as a Markdown section, with the GANTT chart in a code block and the
+// schedule as a table, ready to paste into a report.
+func outputResultMarkdown(w io.Writer, _, title string, result scheduler.ScheduleResult) error {
+	_, _ = fmt.Fprintf(w, "## %s\n\n", title)
+
+	var gantt bytes.Buffer
+	outputGanttRows(&gantt, result.Gantt, processNames(result.Processes), false)
+	_, _ = fmt.Fprintf(w, "### Gantt schedule\n\n```\n%s\n```\n\n", strings.TrimRight(gantt.String(), "\n"))
+
+	header, body := scheduleTable(result.Processes)
+	_, _ = fmt.Fprint(w, "### Schedule table\n\n")
+	outputMarkdownRow(w, header)
+	rule := make([]string, len(header))
+	for i := range rule {
+		rule[i] = "---"
+	}
+	outputMarkdownRow(w, rule)
+	for _, row := range body {
+		outputMarkdownRow(w, row)
+	}
+	stats := result.Stats
+	_, _ = fmt.Fprintf(w, "\n- **Average wait:** %.2f\n- **Average response:** %.2f\n- **Average turnaround:** %.2f\n"+
+		"- **Throughput:** %.2f/t\n- **Fairness:** %.2f\n",
+		stats.AveWait, stats.AveResponse, stats.AveTurnaround, stats.AveThroughput, stats.Fairness)
+	_, _ = fmt.Fprintf(w, "- **Makespan:** %d\n- **CPU utilization:** %.1f%%\n- **Idle time:** %d\n",
+		stats.Makespan, stats.Utilization*100, stats.IdleTime)
+	if header[len(header)-1] == "Deadline" {
+		_, _ = fmt.Fprintf(w, "- **Deadline misses:** %d\n", stats.DeadlineMisses)
+	}
+	if stats.Switches > 0 {
+		_, _ = fmt.Fprintf(w, "- **Context switches:** %d, costing %d\n", stats.Switches, stats.SwitchTime)
+	}
+	if stats.Fragmentation > 0 {
+		_, _ = fmt.Fprintf(w, "- **Gang fragmentation:** %d\n", stats.Fragmentation)
+	}
+	if stats.Energy > 0 {
+		_, _ = fmt.Fprintf(w, "- **Energy:** %.2f\n- **Energy-delay product:** %.2f\n", stats.Energy, stats.EnergyDelay)
+	}
+	_, err := fmt.Fprintln(w)
+
+	return err
+}
+
+func outputMarkdownRow(w io.Writer, cells []string) {
+	_, _ = fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+}
+
+//endregion
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_reportRenderer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		format  string
+		wantExt string
+		wantErr error
+	}{
+		{name: "text by default", wantExt: "txt"},
+		{name: "json", format: outputJSON, wantExt: "json"},
+		{name: "csv", format: outputCSV, wantExt: "csv"},
+		{name: "markdown", format: outputMarkdown, wantExt: "md"},
+		{name: "svg", format: outputSVG, wantExt: "svg"},
+		{name: "html", format: outputHTML, wantExt: "html"},
+		{name: "unknown", format: "xml", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, ext, err := reportRenderer(settings{Output: tt.format})
+			if ext != tt.wantExt {
+				t.Errorf("reportRenderer() extension = %v, want %v", ext, tt.wantExt)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_outputResultJSON(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 2, Priority: 3},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Deadline: 5},
+	}
+	var out bytes.Buffer
+	err := runSchedules(&out, processes, settings{Quantum: 1, Cores: 1, Output: outputJSON, Algorithms: []string{algoFCFS, algoRoundRobin}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each algorithm's report is a line of JSON.
+	var reports []jsonReport
+	for sc := bufio.NewScanner(&out); sc.Scan(); {
+		var r jsonReport
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("%v: %s", err, sc.Text())
+		}
+		reports = append(reports, r)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2", len(reports))
+	}
+	want := jsonReport{
+		Algorithm: algoFCFS,
+		Title:     "First-come, first-serve",
+		Gantt: []jsonSlice{
+			{PID: 1, Start: 0, Stop: 2},
+			{PID: 2, Start: 2, Stop: 3},
+		},
+		Processes: []jsonProcess{
+			{PID: 1, Name: "editor", Priority: 3, Burst: 2, Turnaround: 2, Completion: 2},
+			{PID: 2, Burst: 1, Arrival: 1, Wait: 1, Response: 1, Turnaround: 2, Completion: 3, Deadline: 5},
+		},
+		Stats: jsonStats{AveWait: 0.5, AveResponse: 0.5, AveTurnaround: 2, AveThroughput: 2.0 / 3, Fairness: 0.9, Makespan: 3, Utilization: 1},
+	}
+	if !reflect.DeepEqual(reports[0], want) {
+		t.Errorf("FCFS report = %+v, want %+v", reports[0], want)
+	}
+	if reports[1].Algorithm != algoRoundRobin {
+		t.Errorf("second report is for %q, want %q", reports[1].Algorithm, algoRoundRobin)
+	}
+}
+
+func Test_outputResultJSON_empty(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := outputResultJSON(&out, algoFCFS, "First-come, first-serve", scheduler.FCFS{}.Schedule(nil)); err != nil {
+		t.Fatal(err)
+	}
+	var r jsonReport
+	if err := json.Unmarshal(out.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Stats != (jsonStats{}) {
+		t.Errorf("stats = %+v, want all zero", r.Stats)
+	}
+}
+
+func Test_csvRenderer(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 2, Priority: 3},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Deadline: 5},
+	}
+	var out bytes.Buffer
+	err := runSchedules(&out, processes, settings{Quantum: 1, Cores: 1, Output: outputCSV, Algorithms: []string{algoFCFS, algoSJF}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		csvHeader,
+		{"fcfs", "1", "editor", "3", "2", "0", "0", "0", "2", "2", ""},
+		{"fcfs", "2", "", "0", "1", "1", "1", "1", "2", "3", "5"},
+		{"fcfs", "average", "", "", "", "", "0.50", "0.50", "2.00", "", ""},
+		{"sjf", "1", "editor", "3", "2", "0", "0", "0", "2", "2", ""},
+		{"sjf", "2", "", "0", "1", "1", "1", "1", "2", "3", "5"},
+		{"sjf", "average", "", "", "", "", "0.50", "0.50", "2.00", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CSV report = %v, want %v", got, want)
+	}
+}
+
+func Test_outputResultMarkdown(t *testing.T) {
+	t.Parallel()
+	result := scheduler.FCFS{}.Schedule([]scheduler.Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 2, Priority: 3},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Deadline: 2},
+	})
+	var out bytes.Buffer
+	if err := outputResultMarkdown(&out, algoFCFS, "First-come, first-serve", result); err != nil {
+		t.Fatal(err)
+	}
+	want := "## First-come, first-serve\n\n" +
+		"### Gantt schedule\n\n" +
+		"```\n|editor | 2 |\n0       2   3\n```\n\n" +
+		"### Schedule table\n\n" +
+		"| ID | Name | Priority | Burst | Arrival | Wait | Response | Turnaround | Exit | Deadline |\n" +
+		"| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+		"| 1 | editor | 3 | 2 | 0 | 0 | 0 | 2 | 2 | - |\n" +
+		"| 2 |  | 0 | 1 | 1 | 1 | 1 | 2 | 3 | 2 MISSED |\n\n" +
+		"- **Average wait:** 0.50\n" +
+		"- **Average response:** 0.50\n" +
+		"- **Average turnaround:** 2.00\n" +
+		"- **Throughput:** 0.67/t\n" +
+		"- **Fairness:** 0.90\n" +
+		"- **Makespan:** 3\n" +
+		"- **CPU utilization:** 100.0%\n" +
+		"- **Idle time:** 0\n" +
+		"- **Deadline misses:** 1\n\n"
+	if got := out.String(); got != want {
+		t.Errorf("outputResultMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_streamFCFS(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "header and rows",
+			file: "pid,burst,arrival,name\n1,2,0,editor\n2,1,1,\n",
+			want: "algorithm,id,name,priority,burst,arrival,wait,response,turnaround,completion,deadline\n" +
+				"fcfs,1,editor,0,2,0,0,0,2,2,\n" +
+				"fcfs,2,,0,1,1,1,1,2,3,\n" +
+				"fcfs,average,,,,,0.50,0.50,2.00,,\n",
+		},
+		{
+			name:    "out of order",
+			file:    "1,2,5\n2,1,1\n",
+			wantErr: scheduler.ErrNotStreamable,
+		},
+		{
+			name:    "bad header",
+			file:    "name,burst\neditor,2\n",
+			wantErr: ErrInvalidProcess,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			err := streamFCFS(&out, strings.NewReader(tt.file), settings{Cores: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && out.String() != tt.want {
+				t.Errorf("streamFCFS() =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// Algorithm names for the algorithms setting of a scenario.
+const (
+	algoFCFS          = "fcfs"
+	algoSJF           = "sjf"
+	algoSRTF          = "srtf"
+	algoSJFPriority   = "sjf-priority"
+	algoPredictiveSJF = "sjf-predict"
+	algoAging         = "aging"
+	algoHRRN          = "hrrn"
+	algoRoundRobin    = "rr"
+	algoLottery       = "lottery"
+	algoStride        = "stride"
+	algoEDF           = "edf"
+	algoRateMonotonic = "rm"
+	algoMLQ           = "mlq"
+	algoMLFQ          = "mlfq"
+	algoCgroup        = "cgroup"
+	algoGang          = "gang"
+)
+
+// builtinAlgorithms are the algorithms named above, whose names custom schedulers can't take.
+var builtinAlgorithms = []string{
+	algoFCFS, algoSJF, algoSRTF, algoSJFPriority, algoPredictiveSJF, algoAging, algoHRRN, algoRoundRobin, algoLottery, algoStride,
+	algoEDF, algoRateMonotonic, algoMLQ, algoMLFQ, algoCgroup, algoGang,
+}
+
+var ErrUnknownAlgorithm = errors.New("unknown algorithm")
+
+// settings are the simulation parameters, given by flags, a config file or a scenario file.
+type settings struct {
+	Quantum    int64  `yaml:"quantum"`
+	Cores      int    `yaml:"cores"`
+	SwitchCost int64  `yaml:"switch_cost"`
+	Aging      int64  `yaml:"aging"`
+	Seed       int64  `yaml:"seed"`
+	MLQ        string `yaml:"mlq"`
+	MLQSlices  string `yaml:"mlq_slices"`
+	MLFQ       string `yaml:"mlfq"`
+	MLFQBoost  int64  `yaml:"mlfq_boost"`
+	Cgroups    string `yaml:"cgroups"`
+	// TieBreak orders processes that arrive together or rank equally: fifo, the default, or arrival.
+	TieBreak string `yaml:"tie_break"`
+	// PredictAlpha, when positive, adds SJF with bursts predicted by exponential averaging with this weight,
+	// starting from PredictInitial.
+	PredictAlpha   float64 `yaml:"predict_alpha"`
+	PredictInitial float64 `yaml:"predict_initial"`
+	// Energy, when set, lists the frequency levels to account for every schedule's energy with, running every CPU
+	// at Frequency, or racing to idle and sleeping at SleepPower when it's zero.
+	Energy     string  `yaml:"energy"`
+	Frequency  float64 `yaml:"frequency"`
+	SleepPower float64 `yaml:"sleep_power"`
+	// Out is a directory to write a report file per algorithm to; empty writes to stdout.
+	Out string `yaml:"out"`
+	// Output is the report format, text, json, csv or markdown, svg or html for GANTT charts only, or a chrome
+	// trace.
+	Output string `yaml:"output"`
+	// Verbose adds each process's timeline to text reports.
+	Verbose bool `yaml:"verbose"`
+	// Worst adds the processes with the worst slowdowns, this many of them, to text reports.
+	Worst int `yaml:"worst"`
+	// Color is when to color text reports: auto, always or never.
+	Color string `yaml:"color"`
+	// Interactive steps through each schedule on stdin's keypresses instead of reporting it.
+	Interactive bool `yaml:"interactive"`
+	// Replay prints each schedule's events in real time, at this many time units a second, if it's positive.
+	Replay float64 `yaml:"replay"`
+	// Check verifies the invariants of every schedule, failing on the first one violated.
+	Check bool `yaml:"check"`
+	// QuantumSweep, as min:max, runs Round-Robin with each quantum in the range instead of the algorithms.
+	QuantumSweep string `yaml:"quantum_sweep"`
+	// Algorithms lists the algorithms to run, in order; empty runs the default set.
+	Algorithms []string `yaml:"algorithms"`
+	// custom holds the custom schedulers; nil means scheduler.Registered.
+	custom *scheduler.Registry
+}
+
+// scenario is a YAML file bundling a workload with the settings to simulate it with.
+type scenario struct {
+	settings  `yaml:",inline"`
+	Processes []workloadProcess `yaml:"processes"`
+	Events    []workloadEvent   `yaml:"events"`
+}
+
+// flagSettings returns the settings given by the flags.
+func flagSettings() settings {
+	return settings{
+		Quantum:        *quantum,
+		Cores:          *cores,
+		SwitchCost:     *switchCost,
+		Aging:          *agingEvery,
+		Seed:           *seed,
+		MLQ:            *mlqQueues,
+		MLQSlices:      *mlqSlices,
+		MLFQ:           *mlfqLevels,
+		MLFQBoost:      *mlfqBoost,
+		Cgroups:        *cgroupsFile,
+		TieBreak:       *tieBreak,
+		PredictAlpha:   *predictAlpha,
+		PredictInitial: *predictInitial,
+		Energy:         *energyLevels,
+		Frequency:      *frequency,
+		SleepPower:     *sleepPower,
+		Out:            *outDir,
+		Output:         *output,
+		Verbose:        *verbose,
+		Worst:          *worst,
+		Color:          *color,
+		Interactive:    *interactive,
+		Replay:         *replaySpeed,
+		QuantumSweep:   *quantumSweepRange,
+		Check:          *check,
+		Algorithms:     parseAlgorithms(*algo),
+	}
+}
+
+// override replaces the setting of the named flag with the flag's value.
+func (s *settings) override(name string) {
+	switch name {
+	case "quantum":
+		s.Quantum = *quantum
+	case "cores":
+		s.Cores = *cores
+	case "switch-cost":
+		s.SwitchCost = *switchCost
+	case "aging":
+		s.Aging = *agingEvery
+	case "seed":
+		s.Seed = *seed
+	case "mlq":
+		s.MLQ = *mlqQueues
+	case "mlq-slices":
+		s.MLQSlices = *mlqSlices
+	case "mlfq":
+		s.MLFQ = *mlfqLevels
+	case "mlfq-boost":
+		s.MLFQBoost = *mlfqBoost
+	case "cgroups":
+		s.Cgroups = *cgroupsFile
+	case "tie-break":
+		s.TieBreak = *tieBreak
+	case "predict-alpha":
+		s.PredictAlpha = *predictAlpha
+	case "predict-initial":
+		s.PredictInitial = *predictInitial
+	case "energy":
+		s.Energy = *energyLevels
+	case "frequency":
+		s.Frequency = *frequency
+	case "sleep-power":
+		s.SleepPower = *sleepPower
+	case "out":
+		s.Out = *outDir
+	case "output":
+		s.Output = *output
+	case "verbose":
+		s.Verbose = *verbose
+	case "worst":
+		s.Worst = *worst
+	case "color":
+		s.Color = *color
+	case "interactive":
+		s.Interactive = *interactive
+	case "replay":
+		s.Replay = *replaySpeed
+	case "quantum-sweep":
+		s.QuantumSweep = *quantumSweepRange
+	case "check":
+		s.Check = *check
+	case "algo":
+		s.Algorithms = parseAlgorithms(*algo)
+	}
+}
+
+func (s settings) validate() error {
+	switch {
+	case s.Cores < 1:
+		return fmt.Errorf("%w: cores must be at least 1", ErrInvalidArgs)
+	case s.SwitchCost < 0:
+		return fmt.Errorf("%w: switch cost must not be negative", ErrInvalidArgs)
+	case s.Quantum < 1:
+		return fmt.Errorf("%w: quantum must be at least 1", ErrInvalidArgs)
+	case s.Aging < 1:
+		return fmt.Errorf("%w: aging interval must be at least 1", ErrInvalidArgs)
+	case s.Color != "" && s.Color != colorAuto && s.Color != colorAlways && s.Color != colorNever:
+		return fmt.Errorf("%w: color must be auto, always or never", ErrInvalidArgs)
+	case s.PredictAlpha < 0 || s.PredictAlpha > 1:
+		return fmt.Errorf("%w: prediction alpha must be from 0 to 1", ErrInvalidArgs)
+	case s.PredictInitial < 0:
+		return fmt.Errorf("%w: initial burst prediction must not be negative", ErrInvalidArgs)
+	case s.Frequency < 0:
+		return fmt.Errorf("%w: frequency must not be negative", ErrInvalidArgs)
+	case s.SleepPower < 0:
+		return fmt.Errorf("%w: sleep power must not be negative", ErrInvalidArgs)
+	case s.MLFQBoost < 0:
+		return fmt.Errorf("%w: MLFQ boost interval must not be negative", ErrInvalidArgs)
+	case s.Worst < 0:
+		return fmt.Errorf("%w: number of worst processes must not be negative", ErrInvalidArgs)
+	case s.Replay < 0:
+		return fmt.Errorf("%w: replay speed must not be negative", ErrInvalidArgs)
+	case s.Replay > 0 && s.Interactive:
+		return fmt.Errorf("%w: replay and interactive modes can't be combined", ErrInvalidArgs)
+	}
+	for _, name := range s.registry().Names() {
+		for _, builtin := range builtinAlgorithms {
+			if name == builtin {
+				return fmt.Errorf("%w: custom algorithm %q has the name of a built-in one", ErrInvalidArgs, name)
+			}
+		}
+	}
+	if _, err := scheduler.ParseTieBreak(s.TieBreak); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if _, _, err := reportRenderer(s); err != nil {
+		return err
+	}
+	if s.QuantumSweep != "" {
+		if _, _, err := parseSweep(s.QuantumSweep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tieBreak returns the tie-break policy of s, which validate has checked.
+func (s settings) tieBreak() scheduler.TieBreak {
+	tb, _ := scheduler.ParseTieBreak(s.TieBreak)
+	return tb
+}
+
+// registry returns the custom schedulers of s.
+func (s settings) registry() *scheduler.Registry {
+	if s.custom == nil {
+		return scheduler.Registered
+	}
+	return s.custom
+}
+
+// parseAlgorithms splits a comma separated list of algorithm names; empty gives none.
+func parseAlgorithms(list string) []string {
+	var algorithms []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			algorithms = append(algorithms, name)
+		}
+	}
+
+	return algorithms
+}
+
+//region Loading scenarios.
+
+// defaultConfigFile is the config file read from the working directory when -config isn't given.
+const defaultConfigFile = "scheduler.yaml"
+
+// loadConfig reads a YAML config file, updating s with the settings it gives. It takes the same settings as a
+// scenario, e.g. to keep the quantum, cores, algorithms and output format of an assignment's runs together,
+// but no processes.
+func loadConfig(r io.Reader, s *settings) error {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(s); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: reading config", err)
+	}
+
+	return nil
+}
+
+// loadScenario reads a YAML scenario, returning its processes and updating s with the settings it gives, e.g.
+//
+//	quantum: 2
+//	cores: 2
+//	algorithms: [fcfs, rr]
+//	processes:
+//	  - {pid: 1, burst: 5}
+//	  - {pid: 2, burst: 3, arrival: 1}
+//	events:
+//	  - {pid: 1, at: 2, for: 3}
+func loadScenario(r io.Reader, s *settings) ([]scheduler.Process, error) {
+	sc := scenario{settings: *s}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&sc); err != nil {
+		return nil, fmt.Errorf("%w: reading YAML", err)
+	}
+	processes, err := workloadProcesses(sc.Processes)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyEvents(processes, sc.Events); err != nil {
+		return nil, err
+	}
+	*s = sc.settings
+
+	return processes, nil
+}
+
+//endregion
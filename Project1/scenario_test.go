@@ -0,0 +1,506 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadScenario(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+		s settings
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         []scheduler.Process
+		wantSettings settings
+		wantErr      error
+	}{
+		{
+			name: "settings override the defaults",
+			args: args{
+				r: strings.NewReader(`
+quantum: 2
+switch_cost: 1
+tie_break: arrival
+algorithms: [fcfs, rr]
+processes:
+  - {pid: 1, burst: 5}
+  - {pid: 2, burst: 3, arrival: 1, deadline: 9}
+`),
+				s: settings{Quantum: 1, Cores: 1, Aging: 5, Seed: 7},
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1, Deadline: 9},
+			},
+			wantSettings: settings{
+				Quantum:    2,
+				Cores:      1,
+				SwitchCost: 1,
+				Aging:      5,
+				Seed:       7,
+				TieBreak:   "arrival",
+				Algorithms: []string{algoFCFS, algoRoundRobin},
+			},
+		},
+		{
+			name: "events suspend processes",
+			args: args{
+				r: strings.NewReader(`
+processes:
+  - {pid: 1, burst: 5}
+events:
+  - {pid: 1, at: 2, for: 3}
+`),
+				s: settings{Quantum: 1},
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5, Suspensions: []scheduler.Suspension{{At: 2, For: 3}}},
+			},
+			wantSettings: settings{Quantum: 1},
+		},
+		{
+			name: "event with a negative time",
+			args: args{
+				r: strings.NewReader("processes:\n  - {pid: 1, burst: 5}\nevents:\n  - {pid: 1, at: -1, for: 3}\n"),
+				s: settings{Quantum: 1},
+			},
+			wantSettings: settings{Quantum: 1},
+			wantErr:      ErrInvalidEvent,
+		},
+		{
+			name: "invalid process",
+			args: args{
+				r: strings.NewReader("processes:\n  - {pid: 1}\n"),
+				s: settings{Quantum: 1},
+			},
+			wantSettings: settings{Quantum: 1},
+			wantErr:      ErrInvalidProcess,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := tt.args.s
+			got, err := loadScenario(tt.args.r, &s)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadScenario() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(s, tt.wantSettings) {
+				t.Errorf("settings = %+v, want %+v", s, tt.wantSettings)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_loadConfig(t *testing.T) {
+	t.Parallel()
+	defaults := settings{Quantum: 1, Cores: 1, Aging: 5, Seed: 7, Output: outputText, Algorithms: []string{algoFCFS}}
+	tests := []struct {
+		name         string
+		config       string
+		wantSettings settings
+		wantErr      bool
+	}{
+		{
+			name: "settings override the defaults",
+			config: `
+quantum: 4
+cores: 2
+tie_break: arrival
+output: csv
+algorithms: [rr, sjf]
+`,
+			wantSettings: settings{
+				Quantum:    4,
+				Cores:      2,
+				Aging:      5,
+				Seed:       7,
+				TieBreak:   "arrival",
+				Output:     outputCSV,
+				Algorithms: []string{algoRoundRobin, algoSJF},
+			},
+		},
+		{
+			name:         "empty",
+			wantSettings: defaults,
+		},
+		{
+			name:    "processes",
+			config:  "processes:\n  - {pid: 1, burst: 5}\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown setting",
+			config:  "quantom: 4\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := defaults
+			s.Algorithms = append([]string(nil), defaults.Algorithms...)
+			err := loadConfig(strings.NewReader(tt.config), &s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(s, tt.wantSettings) {
+				t.Errorf("settings = %+v, want %+v", s, tt.wantSettings)
+			}
+		})
+	}
+}
+
+func Test_loadConfigFile(t *testing.T) {
+	t.Parallel()
+	// There's no default config file in the package directory, which is fine.
+	s := settings{Quantum: 1}
+	if err := loadConfigFile("", &s); err != nil || s.Quantum != 1 {
+		t.Errorf("loadConfigFile() = %v with quantum %d, want nil with 1", err, s.Quantum)
+	}
+	if err := loadConfigFile(path.Join(t.TempDir(), defaultConfigFile), &s); err == nil {
+		t.Error("loadConfigFile() of a missing -config file = nil, want an error")
+	}
+}
+
+func Test_runSchedules(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+	}
+	tests := []struct {
+		name       string
+		s          settings
+		wantTitles []string
+		wantErr    error
+	}{
+		{
+			name:       "chosen algorithms in order",
+			s:          settings{Quantum: 2, Cores: 1, Algorithms: []string{algoRoundRobin, algoFCFS}},
+			wantTitles: []string{"Round-Robin (quantum 2)", "First-come, first-serve"},
+		},
+		{
+			name:    "unknown algorithm",
+			s:       settings{Quantum: 1, Cores: 1, Algorithms: []string{"fifo"}},
+			wantErr: ErrUnknownAlgorithm,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			err := runSchedules(&out, processes, tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			last := -1
+			for _, title := range tt.wantTitles {
+				i := strings.Index(out.String(), title)
+				if i <= last {
+					t.Errorf("output is missing %q in order:\n%s", title, out.String())
+				}
+				last = i
+			}
+		})
+	}
+}
+
+func Test_runSchedules_out(t *testing.T) {
+	t.Parallel()
+	dir := path.Join(t.TempDir(), "reports")
+	processes := []scheduler.Process{{ProcessID: 1, BurstDuration: 2}}
+	s := settings{Quantum: 1, Cores: 1, Out: dir, Algorithms: []string{algoFCFS, algoRoundRobin}}
+
+	var out bytes.Buffer
+	if err := runSchedules(&out, processes, s); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() > 0 {
+		t.Errorf("runSchedules() wrote %q to stdout", out.String())
+	}
+	for file, title := range map[string]string{"fcfs.txt": "First-come, first-serve", "rr.txt": "Round-Robin (quantum 1)"} {
+		if got := loadFixture(t, dir, file); !strings.Contains(got, title) {
+			t.Errorf("%s = %q, want the %s report", file, got, title)
+		}
+	}
+}
+
+func Test_scheduleAll(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 6, Priority: 3},
+	}
+	s := settings{
+		Quantum:      2,
+		Cores:        1,
+		Aging:        5,
+		Seed:         1,
+		MLQ:          "fg:1-1:rr:2,bg:2-50:fcfs",
+		MLFQ:         "rr:2:4,fcfs",
+		MLFQBoost:    10,
+		PredictAlpha: 0.5,
+		Check:        true,
+	}
+	algorithms := defaultAlgorithms(processes, s)
+
+	got, err := scheduleAll(processes, algorithms, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The schedules come back in order, the same as when run one at a time.
+	for i, name := range algorithms {
+		title, sched, err := algorithm(name, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := scheduled{name: name, title: title, result: sched.Schedule(processes)}
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("schedule %d = %+v, want %+v", i, got[i], want)
+		}
+	}
+	if _, err := scheduleAll(processes, []string{algoFCFS, "fifo"}, s); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+	s.MLFQ = "rr:2,lifo"
+	if _, err := scheduleAll(processes, []string{algoMLFQ}, s); !errors.Is(err, scheduler.ErrInvalidQueue) {
+		t.Errorf("error = %v, want %v", err, scheduler.ErrInvalidQueue)
+	}
+}
+
+func Test_checkCompleted(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, BurstDuration: 1, Period: 2},
+	}
+	row := func(pid, arrival, burst, completion int64) scheduler.ProcessResult {
+		return scheduler.ProcessResult{
+			Process:    scheduler.Process{ProcessID: pid, ArrivalTime: arrival, BurstDuration: burst},
+			Completion: completion,
+		}
+	}
+	tests := []struct {
+		name    string
+		rows    []scheduler.ProcessResult
+		wantErr error
+	}{
+		{name: "every process and job", rows: []scheduler.ProcessResult{row(2, 0, 1, 1), row(1, 0, 2, 3), row(2, 2, 1, 4)}},
+		{name: "dropped", rows: []scheduler.ProcessResult{row(2, 0, 1, 1)}, wantErr: scheduler.ErrInvariant},
+		{name: "twice", rows: []scheduler.ProcessResult{row(1, 0, 2, 2), row(1, 0, 2, 4), row(2, 0, 1, 5)}, wantErr: scheduler.ErrInvariant},
+		{name: "too early", rows: []scheduler.ProcessResult{row(1, 0, 2, 1), row(2, 0, 1, 3)}, wantErr: scheduler.ErrInvariant},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkCompleted(processes, scheduler.ScheduleResult{Processes: tt.rows})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkCompleted() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_scheduleAll_energy(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 10},
+	}
+	s := settings{Quantum: 1, Cores: 1, Energy: "1:10:2,0.5:3:1", Frequency: 0.5}
+	got, err := scheduleAll(processes, []string{algoFCFS}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "First-come, first-serve, at frequency 0.5"; got[0].title != want {
+		t.Errorf("title = %q, want %q", got[0].title, want)
+	}
+	// Half speed doubles the bursts, so the CPU is busy 12 of 14 time units at 3 and idle the rest at 1.
+	if stats := got[0].result.Stats; stats.Energy != 38 || stats.EnergyDelay != 38*14 {
+		t.Errorf("energy = %v, energy-delay = %v, want 38, %v", stats.Energy, stats.EnergyDelay, 38*14)
+	}
+
+	s.Energy = "1"
+	if _, err := scheduleAll(processes, []string{algoFCFS}, s); !errors.Is(err, scheduler.ErrInvalidEnergy) {
+		t.Errorf("error = %v, want %v", err, scheduler.ErrInvalidEnergy)
+	}
+}
+
+func Test_scheduleAll_gang(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 1},
+		{ProcessID: 2, BurstDuration: 2, Gang: 1},
+		{ProcessID: 3, BurstDuration: 2, Gang: 1},
+	}
+	s := settings{Quantum: 1, Cores: 2, Check: true}
+	algorithms := defaultAlgorithms(processes, s)
+	if last := algorithms[len(algorithms)-1]; last != algoGang {
+		t.Fatalf("defaultAlgorithms() = %v, want gang scheduling last", algorithms)
+	}
+	got, err := scheduleAll(processes, []string{algoGang}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := got[0].result.Stats; stats.Makespan != 3 || stats.Fragmentation != 1 {
+		t.Errorf("makespan = %d, fragmentation = %d, want 3, 1", stats.Makespan, stats.Fragmentation)
+	}
+	s.Cores = 1
+	if _, err := scheduleAll(processes, []string{algoGang}, s); !errors.Is(err, ErrInvalidProcess) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidProcess)
+	}
+	// Other algorithms ignore gangs, so they run on one CPU all the same.
+	if _, err := scheduleAll(processes, []string{algoFCFS}, s); err != nil {
+		t.Error(err)
+	}
+	s.Cores = 2
+	processes[2].DependsOn = []int64{2}
+	if _, err := scheduleAll(processes, []string{algoGang}, s); !errors.Is(err, ErrInvalidProcess) {
+		t.Errorf("error for a member depending on another = %v, want %v", err, ErrInvalidProcess)
+	}
+}
+
+func Test_scheduleAll_hyperperiod(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 1, Period: 9973},
+		{ProcessID: 2, BurstDuration: 1, Period: 9967},
+		{ProcessID: 3, BurstDuration: 1, Period: 9949},
+	}
+	s := settings{Quantum: 1, Cores: 1}
+	if _, err := scheduleAll(processes, []string{algoRateMonotonic}, s); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if _, err := scheduleAll(processes, []string{algoFCFS}, s); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_scheduleAll_affinity(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 4, Affinity: []int{1}},
+		{ProcessID: 2, BurstDuration: 2},
+	}
+	got, err := scheduleAll(processes, []string{algoFCFS}, settings{Quantum: 1, Cores: 2, Check: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slice := got[0].result.Gantt[0]; slice.PID != 2 || slice.CPU != 0 {
+		t.Errorf("first slice = %+v, want process 2 on CPU 0", slice)
+	}
+	if _, err := scheduleAll(processes, []string{algoFCFS}, settings{Quantum: 1, Cores: 1}); !errors.Is(err, ErrInvalidProcess) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidProcess)
+	}
+	// Every default algorithm places the pinned process.
+	s := settings{Quantum: 1, Aging: 1, Cores: 2, Check: true}
+	all, err := scheduleAll(processes, defaultAlgorithms(processes, s), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sc := range all {
+		if len(sc.result.Processes) != len(processes) {
+			t.Errorf("%s scheduled %d processes, want %d", sc.name, len(sc.result.Processes), len(processes))
+		}
+	}
+	s.MLFQ = "rr:2,fcfs"
+	if _, err := scheduleAll(processes, []string{algoMLFQ}, s); !errors.Is(err, ErrInvalidProcess) {
+		t.Errorf("MLFQ error = %v, want %v", err, ErrInvalidProcess)
+	}
+}
+
+func Test_algorithm_singleCPU(t *testing.T) {
+	t.Parallel()
+	s := settings{Quantum: 1, Aging: 1, Cores: 2, MLFQ: "rr:2,fcfs"}
+	for _, name := range []string{algoHRRN, algoMLFQ} {
+		title, sched, err := algorithm(name, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cores := len(sched.Schedule([]scheduler.Process{{ProcessID: 1, BurstDuration: 1}}).Cores)
+		if flagged := strings.HasSuffix(title, "[1 CPU, no switch cost]"); flagged != (cores == 0) {
+			t.Errorf("%s: title %q, but the schedule has %d per-CPU stats", name, title, cores)
+		}
+	}
+}
+
+func Test_algorithm_quantum(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 6, Priority: 1},
+		{ProcessID: 2, BurstDuration: 6, Priority: 1},
+		{ProcessID: 3, BurstDuration: 6, Priority: 1},
+	}
+	s := settings{Quantum: 6, Cores: 1, Seed: 1}
+	for _, name := range []string{algoLottery, algoStride} {
+		_, sched, err := algorithm(name, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A quantum as long as the bursts runs each process to completion.
+		for _, slice := range sched.Schedule(processes).Gantt {
+			if slice.Stop-slice.Start != 6 {
+				t.Errorf("%s: slice %+v, want each process to run its whole burst of 6", name, slice)
+			}
+		}
+	}
+}
+
+func Test_customAlgorithms(t *testing.T) {
+	t.Parallel()
+	custom := &scheduler.Registry{}
+	custom.Register("ljf", func(o scheduler.Options) scheduler.Scheduler {
+		return scheduler.RoundRobin{Quantum: 100, Cores: o.Cores}
+	})
+	s := settings{Quantum: 2, Cores: 2, Aging: 5, custom: custom}
+
+	algorithms := defaultAlgorithms(nil, s)
+	if last := algorithms[len(algorithms)-1]; last != "ljf" {
+		t.Errorf("defaultAlgorithms() = %v, want the custom algorithm last", algorithms)
+	}
+	title, sched, err := algorithm("ljf", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (scheduler.RoundRobin{Quantum: 100, Cores: 2}); title != "ljf (custom)" || sched != want {
+		t.Errorf("algorithm() = %q, %+v, want %q, %+v", title, sched, "ljf (custom)", want)
+	}
+	if err := s.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+
+	custom.Register(algoRoundRobin, func(scheduler.Options) scheduler.Scheduler { return scheduler.RoundRobin{} })
+	if err := s.validate(); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("validate() of a custom algorithm named %q error = %v, want %v", algoRoundRobin, err, ErrInvalidArgs)
+	}
+}
+
+func Test_parseAlgorithms(t *testing.T) {
+	t.Parallel()
+	if got, want := parseAlgorithms(" fcfs, rr,,ljf "), []string{algoFCFS, algoRoundRobin, "ljf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAlgorithms() = %v, want %v", got, want)
+	}
+	if got := parseAlgorithms(""); got != nil {
+		t.Errorf("parseAlgorithms(\"\") = %v, want none", got)
+	}
+}
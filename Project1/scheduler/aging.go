@@ -0,0 +1,87 @@
+package scheduler
+
+// Aging is preemptive priority scheduling with aging: a ready process's effective priority improves
+// (decreases) by one for every Interval it waits in the ready queue, down to 1, so low-priority processes
+// cannot starve. Arrivals preempt like SJFPriority, as does a ready process whose effective priority improves
+// past a running one's, and equal effective priorities go to the shortest remaining burst. Each dispatch is logged with the effective priority it was made at.
+type Aging struct {
+	// Interval is the waiting time per priority step; zero means one time unit.
+	Interval int64
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the aging priority schedule of processes.
+func (a Aging) Schedule(processes []Process) ScheduleResult {
+	return a.observe(processes, nil)
+}
+
+func (a Aging) observe(processes []Process, obs observers) ScheduleResult {
+	var (
+		dispatches = make([]Dispatch, 0, len(processes))
+		rerank     = int64(-1) // when a waiting process's effective priority next improves
+	)
+	result := simulate(processes, policy{
+		less: func(x, y *task, now int64) bool {
+			px, py := a.effectivePriority(x, now), a.effectivePriority(y, now)
+			if px != py {
+				return px < py
+			}
+			return x.remaining < y.remaining
+		},
+		preemptive: true,
+		tieBreak:   a.TieBreak,
+		cores:      a.Cores,
+		switchCost: a.SwitchCost,
+		dispatched: func(t *task, now int64) {
+			dispatches = append(dispatches, Dispatch{
+				Time:              now,
+				PID:               t.ProcessID,
+				Priority:          t.Priority,
+				EffectivePriority: a.effectivePriority(t, now),
+			})
+		},
+		tick: func(ready []*task, clock *Clock) bool {
+			now := clock.Now()
+			reranked := rerank >= 0 && now >= rerank
+			rerank = -1
+			for _, t := range ready {
+				if at := a.reranks(t, now); at >= 0 && (rerank < 0 || at < rerank) {
+					rerank = at
+				}
+			}
+			clock.At(rerank)
+			return reranked
+		},
+		observers: obs,
+	})
+	result.Dispatches = dispatches
+
+	return result
+}
+
+func (a Aging) effectivePriority(t *task, now int64) int64 {
+	return max(t.Priority-(now-t.readySince)/a.interval(), min(t.Priority, 1))
+}
+
+// reranks returns when the waiting task t's effective priority next improves, or -1 once it can't.
+func (a Aging) reranks(t *task, now int64) int64 {
+	if a.effectivePriority(t, now) <= min(t.Priority, 1) {
+		return -1
+	}
+	interval := a.interval()
+
+	return t.readySince + ((now-t.readySince)/interval+1)*interval
+}
+
+func (a Aging) interval() int64 {
+	if a.Interval <= 0 {
+		return 1
+	}
+
+	return a.Interval
+}
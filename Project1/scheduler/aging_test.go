@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAging_Schedule(t *testing.T) {
+	t.Parallel()
+	// A stream of high-priority arrivals that would starve process 1 under plain priority scheduling.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 3, Priority: 1},
+		{ProcessID: 4, ArrivalTime: 6, BurstDuration: 3, Priority: 1},
+		{ProcessID: 5, ArrivalTime: 9, BurstDuration: 3, Priority: 1},
+	}
+	tests := []struct {
+		name           string
+		interval       int64
+		wantGantt      []TimeSlice
+		wantDispatches []Dispatch
+	}{
+		{
+			name:     "aging lets the low priority process run",
+			interval: 2,
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 3},
+				{PID: 3, Start: 3, Stop: 6},
+				{PID: 4, Start: 6, Stop: 9},
+				{PID: 1, Start: 9, Stop: 11},
+				{PID: 5, Start: 11, Stop: 14},
+			},
+			// By 9 process 1 has waited four intervals and ties with process 5, winning on its shorter burst.
+			wantDispatches: []Dispatch{
+				{Time: 0, PID: 2, Priority: 1, EffectivePriority: 1},
+				{Time: 3, PID: 3, Priority: 1, EffectivePriority: 1},
+				{Time: 6, PID: 4, Priority: 1, EffectivePriority: 1},
+				{Time: 9, PID: 1, Priority: 5, EffectivePriority: 1},
+				{Time: 11, PID: 5, Priority: 1, EffectivePriority: 1},
+			},
+		},
+		{
+			name:     "slow aging behaves like plain priority",
+			interval: 100,
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 3},
+				{PID: 3, Start: 3, Stop: 6},
+				{PID: 4, Start: 6, Stop: 9},
+				{PID: 5, Start: 9, Stop: 12},
+				{PID: 1, Start: 12, Stop: 14},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Aging{Interval: tt.interval}.Schedule(processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if tt.wantDispatches != nil && !reflect.DeepEqual(got.Dispatches, tt.wantDispatches) {
+				t.Errorf("Schedule() dispatches = %+v, want %+v", got.Dispatches, tt.wantDispatches)
+			}
+		})
+	}
+}
+
+func TestAging_Schedule_agedPastRunning(t *testing.T) {
+	t.Parallel()
+	// No process arrives or completes while process 2 ages, so it preempts process 1 when its effective
+	// priority ties at 6, winning on its shorter remaining burst.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 5},
+	}
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 6},
+		{PID: 2, Start: 6, Stop: 8},
+		{PID: 1, Start: 8, Stop: 12},
+	}
+	wantDispatches := []Dispatch{
+		{Time: 0, PID: 1, Priority: 2, EffectivePriority: 2},
+		{Time: 6, PID: 2, Priority: 5, EffectivePriority: 2},
+		{Time: 8, PID: 1, Priority: 2, EffectivePriority: 1},
+	}
+	got := Aging{Interval: 2}.Schedule(processes)
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	if !reflect.DeepEqual(got.Dispatches, wantDispatches) {
+		t.Errorf("Schedule() dispatches = %+v, want %+v", got.Dispatches, wantDispatches)
+	}
+}
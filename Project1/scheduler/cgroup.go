@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"path"
+	"sort"
+)
+
+// Cgroup is a node in a cgroup-style hierarchy. Paths are slash separated ("/", "/web", "/web/api"),
+// and CPU time charged to a group is also charged to every one of its ancestors.
+type Cgroup struct {
+	Path string
+	// Quota is the CPU time the group may use in each Period; zero means unlimited.
+	Quota  int64
+	Period int64
+}
+
+// CgroupStats is a group's CPU accounting at the end of a schedule.
+type CgroupStats struct {
+	Cgroup
+	Usage int64
+	// NrThrottled is the number of periods in which the group hit its quota.
+	NrThrottled int64
+	// ThrottledTime is the time spent throttled while the group had runnable work.
+	ThrottledTime int64
+}
+
+// cgroupState tracks a group's CPU accounting while the simulation runs.
+type cgroupState struct {
+	CgroupStats
+	period    int64 // the current period, counting from the one starting at time zero
+	usage     int64 // usage in the current period
+	throttled bool
+}
+
+// CgroupRoundRobin is Round-Robin scheduling where each process is charged to a cgroup
+// (its Group, defaulting to "/"). A group that uses its quota within a period is
+// throttled, along with everything below it, until the period ends. A process blocks for
+// the I/O between its CPU bursts, and rejoins the back of the ready queue when it completes.
+type CgroupRoundRobin struct {
+	Groups []Cgroup
+	// TieBreak orders simultaneous arrivals.
+	TieBreak TieBreak
+}
+
+// Schedule returns the cgroup-limited Round-Robin schedule of processes.
+func (c CgroupRoundRobin) Schedule(processes []Process) ScheduleResult {
+	return c.observe(processes, nil)
+}
+
+func (c CgroupRoundRobin) observe(processes []Process, obs observers) ScheduleResult {
+	var (
+		states   = buildCgroupStates(processes, c.Groups)
+		holding  []*cgroupState // groups holding back runnable work since the clock last stopped
+		since    int64          // when the clock last stopped
+		runnable int            // ready processes whose group chain is not throttled
+	)
+	result := simulate(processes, policy{
+		// A process only runs while no group in its chain is throttled.
+		runnable: func(t *task) bool {
+			return !isThrottled(states, t.Group)
+		},
+		// Round-Robin hands the CPU on every time unit, so a process only runs longer when no other process can
+		// run, and then only until its quota runs out or the period of a group in its chain ends.
+		limit: func(t *task) int64 {
+			if runnable > 1 {
+				return 1
+			}
+			run := int64(-1)
+			for _, p := range cgroupAncestors(t.Group) {
+				if s := states[p]; s.Quota > 0 {
+					if left := min(s.Quota-s.usage, (s.period+1)*s.Period-since); run < 0 || left < run {
+						run = left
+					}
+				}
+			}
+			return run
+		},
+		// CPU time is charged to the process's group and its ancestors, and each turn ends when the clock stops.
+		ran: func(t *task, step int64) (bool, bool) {
+			for _, p := range cgroupAncestors(t.Group) {
+				s := states[p]
+				s.usage += step
+				s.Usage += step
+				if s.Quota > 0 && s.usage >= s.Quota && !s.throttled {
+					s.throttled = true
+					s.NrThrottled++
+				}
+			}
+			return true, false
+		},
+		tick: func(ready []*task, clock *Clock) bool {
+			now := clock.Now()
+			// Throttled time is charged to groups that are holding back runnable work.
+			for _, s := range holding {
+				s.ThrottledTime += now - since
+			}
+			since = now
+			// Start a new period for any group whose last one has ended.
+			for _, s := range states {
+				if s.Quota > 0 && now/s.Period != s.period {
+					s.period = now / s.Period
+					s.usage = 0
+					s.throttled = false
+				}
+			}
+			runnable = 0
+			for _, t := range ready {
+				if !isThrottled(states, t.Group) {
+					runnable++
+				}
+			}
+			holding = holding[:0]
+			for _, s := range states {
+				if !s.throttled {
+					continue
+				}
+				// Stop when the period ends, to run the work the group holds back.
+				clock.At((now/s.Period + 1) * s.Period)
+				if hasWaitingWork(s.Path, ready) {
+					holding = append(holding, s)
+				}
+			}
+			return false
+		},
+		tieBreak:  c.TieBreak,
+		observers: obs,
+	})
+
+	paths := make([]string, 0, len(states))
+	for p := range states {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	result.Cgroups = make([]CgroupStats, len(paths))
+	for i, p := range paths {
+		result.Cgroups[i] = states[p].CgroupStats
+	}
+
+	return result
+}
+
+// buildCgroupStates indexes the configured groups by path, adding an unlimited root and
+// any intermediate or referenced groups that were not configured explicitly.
+func buildCgroupStates(processes []Process, groups []Cgroup) map[string]*cgroupState {
+	states := make(map[string]*cgroupState)
+	add := func(p string) {
+		for _, a := range cgroupAncestors(p) {
+			if _, ok := states[a]; !ok {
+				states[a] = &cgroupState{CgroupStats: CgroupStats{Cgroup: Cgroup{Path: a}}}
+			}
+		}
+	}
+	for _, g := range groups {
+		add(g.Path)
+		s := states[CleanCgroupPath(g.Path)]
+		s.Quota = g.Quota
+		s.Period = g.Period
+	}
+	for i := range processes {
+		add(processes[i].Group)
+	}
+
+	return states
+}
+
+// cgroupAncestors returns the group itself followed by each of its parents up to the root.
+func cgroupAncestors(p string) []string {
+	p = CleanCgroupPath(p)
+	chain := []string{p}
+	for p != "/" {
+		p = path.Dir(p)
+		chain = append(chain, p)
+	}
+
+	return chain
+}
+
+// CleanCgroupPath returns the canonical form of a cgroup path, e.g. "a/b/" becomes "/a/b".
+func CleanCgroupPath(p string) string {
+	return path.Clean("/" + p)
+}
+
+func isThrottled(states map[string]*cgroupState, group string) bool {
+	for _, p := range cgroupAncestors(group) {
+		if states[p].throttled {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasWaitingWork(group string, ready []*task) bool {
+	for _, t := range ready {
+		for _, p := range cgroupAncestors(t.Group) {
+			if p == group {
+				return true
+			}
+		}
+	}
+
+	return false
+}
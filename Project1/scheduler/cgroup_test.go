@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCgroupRoundRobin_Schedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		groups    []Cgroup
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantGantt   []TimeSlice
+		wantCgroups []CgroupStats
+	}{
+		{
+			name: "unlimited groups behave like round-robin",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, BurstDuration: 2},
+					{ProcessID: 2, BurstDuration: 2},
+				},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 3},
+				{PID: 2, Start: 3, Stop: 4},
+			},
+			wantCgroups: []CgroupStats{
+				{Cgroup: Cgroup{Path: "/"}, Usage: 4},
+			},
+		},
+		{
+			name: "quota throttles the group and its children",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, BurstDuration: 4, Group: "/a/x"},
+					{ProcessID: 2, BurstDuration: 2, Group: "/b"},
+				},
+				groups: []Cgroup{
+					{Path: "/a", Quota: 1, Period: 3},
+				},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 1, Start: 9, Stop: 10},
+			},
+			wantCgroups: []CgroupStats{
+				{Cgroup: Cgroup{Path: "/"}, Usage: 6},
+				{Cgroup: Cgroup{Path: "/a", Quota: 1, Period: 3}, Usage: 4, NrThrottled: 4, ThrottledTime: 6},
+				{Cgroup: Cgroup{Path: "/a/x"}, Usage: 4},
+				{Cgroup: Cgroup{Path: "/b"}, Usage: 2},
+			},
+		},
+		{
+			name: "processes block for I/O",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 2, 1}},
+					{ProcessID: 2, BurstDuration: 3},
+				},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 2, Start: 4, Stop: 5},
+			},
+			wantCgroups: []CgroupStats{
+				{Cgroup: Cgroup{Path: "/"}, Usage: 5},
+			},
+		},
+		{
+			name: "a suspended process takes its turn again when it resumes",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, BurstDuration: 4},
+					{ProcessID: 2, BurstDuration: 2, Suspensions: []Suspension{{At: 1, For: 2}}},
+				},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 4},
+				{PID: 1, Start: 4, Stop: 5},
+				{PID: 2, Start: 5, Stop: 6},
+			},
+			wantCgroups: []CgroupStats{
+				{Cgroup: Cgroup{Path: "/"}, Usage: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CgroupRoundRobin{Groups: tt.args.groups}.Schedule(tt.args.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(got.Cgroups, tt.wantCgroups) {
+				t.Errorf("Schedule() cgroups = %+v, want %+v", got.Cgroups, tt.wantCgroups)
+			}
+		})
+	}
+}
+
+func Test_cgroupAncestors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		p    string
+		want []string
+	}{
+		{name: "empty is root", p: "", want: []string{"/"}},
+		{name: "root", p: "/", want: []string{"/"}},
+		{name: "nested", p: "a/b", want: []string{"/a/b", "/a", "/"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cgroupAncestors(tt.p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cgroupAncestors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrInvariant = errors.New("schedule invariant violated")
+
+// Check verifies the invariants every schedule must hold, returning an ErrInvariant error that names the first
+// one violated:
+//   - no two time slices overlap on one CPU, and no process runs on two CPUs at once;
+//   - every process runs for exactly its burst, and only between its arrival and its completion;
+//   - in multi-core schedules, every process runs only on the CPUs in its affinity;
+//   - no process runs while it is suspended;
+//   - work conservation: no CPU idles while a process is ready to run.
+//
+// A process is ready once it has arrived and its dependencies have completed, except while it is blocked on the
+// I/O after one of its CPU bursts, until the I/O completes or the process next runs, and while it is suspended.
+// Cgroup quotas idle CPUs by design, as do affinities when the only ready processes can't run on the idle CPUs and
+// gangs waiting for their members or free CPUs, so work conservation isn't checked for schedules that enforce any
+// of them.
+func (r ScheduleResult) Check() error {
+	if err := r.checkOverlaps(); err != nil {
+		return err
+	}
+	if err := r.checkRunTimes(); err != nil {
+		return err
+	}
+	if err := r.checkAffinity(); err != nil {
+		return err
+	}
+	if err := r.checkSuspensions(); err != nil {
+		return err
+	}
+
+	return r.checkWorkConservation()
+}
+
+// checkOverlaps checks that each CPU runs one slice at a time and each process runs on one CPU at a time.
+func (r ScheduleResult) checkOverlaps() error {
+	var (
+		gantt  = append([]TimeSlice(nil), r.Gantt...)
+		byCPU  = make(map[int]TimeSlice)
+		byProc = make(map[int64]TimeSlice)
+	)
+	sort.SliceStable(gantt, func(i, j int) bool {
+		return gantt[i].Start < gantt[j].Start
+	})
+	for _, s := range gantt {
+		if s.Stop < s.Start {
+			return fmt.Errorf("%w: process %d's slice on CPU %d ends at %d, before it starts at %d", ErrInvariant, s.PID, s.CPU, s.Stop, s.Start)
+		}
+		if prev, ok := byCPU[s.CPU]; ok && s.Start < prev.Stop {
+			return fmt.Errorf("%w: slices overlap on CPU %d: process %d starts at %d, before process %d stops at %d",
+				ErrInvariant, s.CPU, s.PID, s.Start, prev.PID, prev.Stop)
+		}
+		if prev, ok := byProc[s.PID]; ok && s.Start < prev.Stop {
+			return fmt.Errorf("%w: process %d runs on CPUs %d and %d at once at %d", ErrInvariant, s.PID, prev.CPU, s.CPU, s.Start)
+		}
+		byCPU[s.CPU], byProc[s.PID] = s, s
+	}
+
+	return nil
+}
+
+// checkRunTimes checks that every process runs for its burst, between its arrival and completion. The jobs of a
+// periodic task share its PID, so they are checked together, from the first's release to the last's completion.
+func (r ScheduleResult) checkRunTimes() error {
+	type lifetime struct {
+		arrival, completion, burst, ran int64
+	}
+	var (
+		lifetimes = make(map[int64]*lifetime, len(r.Processes))
+		pids      = make([]int64, 0, len(r.Processes))
+	)
+	for _, p := range r.Processes {
+		l, ok := lifetimes[p.ProcessID]
+		if !ok {
+			l = &lifetime{arrival: p.ArrivalTime, completion: p.Completion}
+			lifetimes[p.ProcessID] = l
+			pids = append(pids, p.ProcessID)
+		}
+		l.arrival, l.completion = min(l.arrival, p.ArrivalTime), max(l.completion, p.Completion)
+		l.burst += p.BurstDuration
+	}
+	for _, s := range r.Gantt {
+		if s.Switch {
+			continue
+		}
+		l, ok := lifetimes[s.PID]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: process %d runs at %d but never completes", ErrInvariant, s.PID, s.Start)
+		case s.Start < l.arrival:
+			return fmt.Errorf("%w: process %d runs at %d, before it arrives at %d", ErrInvariant, s.PID, s.Start, l.arrival)
+		case s.Stop > l.completion:
+			return fmt.Errorf("%w: process %d runs until %d, after it completes at %d", ErrInvariant, s.PID, s.Stop, l.completion)
+		}
+		l.ran += s.Stop - s.Start
+	}
+	for _, pid := range pids {
+		if l := lifetimes[pid]; l.ran != l.burst {
+			return fmt.Errorf("%w: process %d runs for %d, but its burst is %d", ErrInvariant, pid, l.ran, l.burst)
+		}
+	}
+
+	return nil
+}
+
+// checkAffinity checks that the processes of a multi-core schedule only run on the CPUs in their affinity.
+func (r ScheduleResult) checkAffinity() error {
+	if len(r.Cores) == 0 {
+		return nil
+	}
+	processes := make(map[int64]Process, len(r.Processes))
+	for _, p := range r.Processes {
+		processes[p.ProcessID] = p.Process
+	}
+	for _, s := range r.Gantt {
+		if p := processes[s.PID]; !p.RunsOn(s.CPU) {
+			return fmt.Errorf("%w: process %d runs on CPU %d at %d, outside its affinity %v", ErrInvariant, s.PID, s.CPU, s.Start, p.Affinity)
+		}
+	}
+
+	return nil
+}
+
+// checkSuspensions checks that no process runs while it is suspended.
+func (r ScheduleResult) checkSuspensions() error {
+	suspensions := make(map[int64][]TimeSlice)
+	for _, p := range r.Processes {
+		if _, ok := suspensions[p.ProcessID]; !ok {
+			suspensions[p.ProcessID] = p.suspensions()
+		}
+	}
+	for _, s := range r.Gantt {
+		if s.Switch {
+			continue
+		}
+		for _, sus := range suspensions[s.PID] {
+			if s.Start < sus.Stop && sus.Start < s.Stop {
+				return fmt.Errorf("%w: process %d runs at %d, while it is suspended from %d to %d",
+					ErrInvariant, s.PID, max(s.Start, sus.Start), sus.Start, sus.Stop)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkWorkConservation sweeps through the schedule counting the busy CPUs and the processes that could run,
+// and checks that a CPU is only idle when every process that could run is running.
+func (r ScheduleResult) checkWorkConservation() error {
+	for _, g := range r.Cgroups {
+		if g.Quota > 0 {
+			return nil
+		}
+	}
+	if r.Stats.Fragmentation > 0 {
+		return nil
+	}
+	for _, p := range r.Processes {
+		if len(p.Affinity) > 0 && len(r.Cores) > 0 {
+			return nil
+		}
+	}
+	type change struct {
+		at             int64
+		runnable, busy int
+	}
+	var (
+		cpus      = max(int64(len(r.Cores)), 1)
+		changes   = make([]change, 0, 2*len(r.Gantt)+2*len(r.Processes))
+		completed = make(map[int64]int64, len(r.Processes))
+		runs      = make(map[int64][]TimeSlice)
+	)
+	for _, s := range r.Gantt {
+		changes = append(changes, change{at: s.Start, busy: 1}, change{at: s.Stop, busy: -1})
+		if !s.Switch {
+			runs[s.PID] = append(runs[s.PID], s)
+		}
+	}
+	for _, p := range r.Processes {
+		completed[p.ProcessID] = max(completed[p.ProcessID], p.Completion)
+	}
+	for _, p := range r.Processes {
+		ready := p.ArrivalTime
+		for _, dep := range p.DependsOn {
+			ready = max(ready, completed[dep])
+		}
+		changes = append(changes, change{at: ready, runnable: 1}, change{at: p.Completion, runnable: -1})
+		for _, out := range notRunnable(p, runs[p.ProcessID], ready) {
+			changes = append(changes, change{at: out.Start, runnable: -1}, change{at: out.Stop, runnable: 1})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].at < changes[j].at
+	})
+
+	var runnable, busy int
+	for i, c := range changes {
+		runnable += c.runnable
+		busy += c.busy
+		if i+1 < len(changes) && changes[i+1].at == c.at {
+			continue
+		}
+		if int64(busy) < cpus && runnable > busy && i+1 < len(changes) {
+			return fmt.Errorf("%w: work conservation: %d of %d CPUs idle at %d while %d processes are ready",
+				ErrInvariant, cpus-int64(busy), cpus, c.at, runnable-busy)
+		}
+	}
+
+	return nil
+}
+
+// notRunnable returns the intervals, between becoming ready and completing, that process p is blocked on I/O or
+// suspended, merged where they overlap.
+func notRunnable(p ProcessResult, runs []TimeSlice, ready int64) []TimeSlice {
+	intervals := blockedOnIO(p, runs)
+	for _, s := range p.suspensions() {
+		s.Start, s.Stop = max(s.Start, ready), min(s.Stop, p.Completion)
+		if s.Start < s.Stop {
+			intervals = append(intervals, s)
+		}
+	}
+
+	return mergeIntervals(intervals)
+}
+
+// blockedOnIO returns the intervals process p, a job of a periodic task or a one-off process, spends blocked on
+// I/O, given the slices its PID ran in. Each lasts from the end of a CPU burst until the I/O after it completes
+// or the process next runs.
+func blockedOnIO(p ProcessResult, runs []TimeSlice) []TimeSlice {
+	if len(p.Bursts) < 3 {
+		return nil
+	}
+	var (
+		blocked []TimeSlice
+		burst   int
+		need    = p.Bursts[0]
+	)
+	for i, s := range runs {
+		if s.Stop <= p.ArrivalTime || s.Start >= p.Completion {
+			continue
+		}
+		for at := s.Start; at < s.Stop && burst+2 < len(p.Bursts); {
+			if at+need > s.Stop {
+				need -= s.Stop - at
+				break
+			}
+			at += need
+			until := at + p.Bursts[burst+1]
+			if at < s.Stop {
+				until = at
+			} else if i+1 < len(runs) {
+				until = min(until, runs[i+1].Start)
+			}
+			if until > at {
+				blocked = append(blocked, TimeSlice{PID: p.ProcessID, Start: at, Stop: until})
+			}
+			burst += 2
+			need = p.Bursts[burst]
+		}
+	}
+
+	return blocked
+}
@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScheduleResult_Check(t *testing.T) {
+	t.Parallel()
+	processes := []ProcessResult{
+		{Process: Process{ProcessID: 1, BurstDuration: 3}, Completion: 3},
+		{Process: Process{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}, Completion: 5},
+	}
+	tests := []struct {
+		name    string
+		result  ScheduleResult
+		wantMsg string // part of the error message; empty for a valid schedule
+	}{
+		{
+			name: "valid",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}},
+				Processes: processes,
+			},
+		},
+		{
+			name: "overlapping slices",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 2, Stop: 4}},
+				Processes: processes,
+			},
+			wantMsg: "slices overlap on CPU 0: process 2 starts at 2, before process 1 stops at 3",
+		},
+		{
+			name: "on two CPUs at once",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 1, Start: 1, Stop: 2, CPU: 1}},
+				Processes: processes[:1],
+				Cores:     make([]CoreStats, 2),
+			},
+			wantMsg: "process 1 runs on CPUs 0 and 1 at once at 1",
+		},
+		{
+			name: "short of its burst",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}},
+				Processes: processes,
+			},
+			wantMsg: "process 2 runs for 1, but its burst is 2",
+		},
+		{
+			name: "before its arrival",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 5}},
+				Processes: processes,
+			},
+			wantMsg: "process 2 runs at 0, before it arrives at 1",
+		},
+		{
+			name: "outside its affinity",
+			result: ScheduleResult{
+				Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3, CPU: 1}},
+				Processes: []ProcessResult{
+					{Process: Process{ProcessID: 1, BurstDuration: 3, Affinity: []int{0}}, Completion: 3},
+				},
+				Cores: make([]CoreStats, 2),
+			},
+			wantMsg: "process 1 runs on CPU 1 at 0, outside its affinity [0]",
+		},
+		{
+			name: "idle while a process is ready",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 4, Stop: 6}},
+				Processes: []ProcessResult{processes[0], {Process: processes[1].Process, Completion: 6}},
+			},
+			wantMsg: "work conservation: 1 of 1 CPUs idle at 3 while 1 processes are ready",
+		},
+		{
+			name: "idle while blocked on I/O",
+			result: ScheduleResult{
+				Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 3, Stop: 5}},
+				Processes: []ProcessResult{
+					{Process: Process{ProcessID: 1, BurstDuration: 3, Bursts: []int64{1, 2, 2}}, Completion: 5},
+				},
+			},
+		},
+		{
+			name: "idle while throttled",
+			result: ScheduleResult{
+				Gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 2, Stop: 4}},
+				Processes: []ProcessResult{{Process: processes[0].Process, Completion: 4}},
+				Cgroups:   []CgroupStats{{Cgroup: Cgroup{Path: "/", Quota: 1, Period: 2}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.result.Check()
+			if tt.wantMsg == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvariant) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Check() error = %v, want %v: ...%s", err, ErrInvariant, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestSchedulers_check runs every scheduler over random workloads with I/O, on one and three CPUs, and checks
+// the invariants of each schedule.
+func TestSchedulers_check(t *testing.T) {
+	t.Parallel()
+	queues := []Queue{
+		{MinPriority: 1, MaxPriority: 2, Discipline: RRQueue, Quantum: 2},
+		{MinPriority: 3, MaxPriority: 5, Discipline: SJFQueue},
+	}
+	for _, cores := range []int{1, 3} {
+		schedulers := map[string]Scheduler{
+			"FCFS":           FCFS{Cores: cores, SwitchCost: 1},
+			"SJF":            SJF{Cores: cores, SwitchCost: 1},
+			"Predictive SJF": PredictiveSJF{Alpha: 0.5, Cores: cores, SwitchCost: 1},
+			"SRTF":           SRTF{Cores: cores, SwitchCost: 1},
+			"SJF Priority":   SJFPriority{Cores: cores, TieBreak: TieArrival},
+			"Round-Robin":    RoundRobin{Quantum: 3, Cores: cores, SwitchCost: 1},
+			"Gang":           Gang{Cores: cores},
+		}
+		if cores == 1 {
+			schedulers["Aging"] = Aging{Interval: 2}
+			schedulers["HRRN"] = HRRN{}
+			schedulers["Lottery"] = Lottery{Seed: 1}
+			schedulers["Stride"] = Stride{Quantum: 2}
+			schedulers["EDF"] = EDF{}
+			schedulers["RM"] = RateMonotonic{}
+			schedulers["MLQ"] = MLQ{Queues: queues}
+			schedulers["cgroups"] = CgroupRoundRobin{}
+		}
+		for name, scheduler := range schedulers {
+			name, scheduler, cores := name, scheduler, cores
+			t.Run(fmt.Sprintf("%s on %d CPUs", name, cores), func(t *testing.T) {
+				t.Parallel()
+				for seed := int64(1); seed <= 5; seed++ {
+					if err := scheduler.Schedule(randomWorkload(100, seed)).Check(); err != nil {
+						t.Fatalf("seed %d: %v", seed, err)
+					}
+					if err := scheduler.Schedule(pinned(randomWorkload(100, seed), cores)).Check(); err != nil {
+						t.Fatalf("seed %d, pinned: %v", seed, err)
+					}
+					if err := scheduler.Schedule(ganged(randomWorkload(100, seed), cores)).Check(); err != nil {
+						t.Fatalf("seed %d, ganged: %v", seed, err)
+					}
+					if err := scheduler.Schedule(suspending(randomWorkload(100, seed))).Check(); err != nil {
+						t.Fatalf("seed %d, suspending: %v", seed, err)
+					}
+					if err := scheduler.Schedule(ganged(suspending(randomWorkload(100, seed)), cores)).Check(); err != nil {
+						t.Fatalf("seed %d, ganged and suspending: %v", seed, err)
+					}
+				}
+			})
+		}
+	}
+}
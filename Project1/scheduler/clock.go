@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"container/heap"
+	"math/rand"
+)
+
+// Clock is the simulated time a schedule runs on, shared by the schedulers so that none keeps time its own way.
+// It doesn't tick: a scheduler tells it when things are due to happen, such as arrivals, I/O completions and
+// suspensions beginning or ending, and moves it on by however long it runs its processes for, or to the next
+// of those events if that comes first, so every scheduler stops at the same instants. Its random source is
+// seeded when it's made, so that randomized schedules are reproducible.
+//
+// A custom scheduler can run on a Clock too:
+//
+//	clock := scheduler.NewClock(seed)
+//	for _, p := range processes {
+//		clock.At(p.ArrivalTime)
+//	}
+//	for ... {
+//		step := clock.Step(runFor) // runFor is -1 with nothing to run
+//		if step < 0 {
+//			break // nothing is running and nothing else will happen
+//		}
+//		// run for step
+//		clock.Advance(step)
+//	}
+type Clock struct {
+	now int64
+	// due holds the times events are due, each possibly more than once.
+	due  timeHeap
+	rand *rand.Rand
+}
+
+// NewClock returns a clock at time zero, with nothing due, and a random source seeded with seed.
+func NewClock(seed int64) *Clock {
+	return &Clock{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Now returns the current time.
+func (c *Clock) Now() int64 {
+	return c.now
+}
+
+// Rand returns the clock's random source, for schedulers that make random choices.
+func (c *Clock) Rand() *rand.Rand {
+	return c.rand
+}
+
+// At tells the clock that something is due at time t, so that it stops there. Times that have passed, including
+// the current one, are ignored.
+func (c *Clock) At(t int64) {
+	if t > c.now {
+		heap.Push(&c.due, t)
+	}
+}
+
+// Next returns the time of the next event due, or -1 when none is.
+func (c *Clock) Next() int64 {
+	if len(c.due) == 0 {
+		return -1
+	}
+
+	return c.due[0]
+}
+
+// Step returns how far the clock can move on from now: run, the time the scheduler means to run its processes
+// for, or the time until the next event if that comes first. run is -1 when nothing is running, and Step then
+// returns the time until the next event, or -1 when none is due.
+func (c *Clock) Step(run int64) int64 {
+	if at := c.Next(); at >= 0 && (run < 0 || at-c.now < run) {
+		return at - c.now
+	}
+
+	return run
+}
+
+// Advance moves the clock on by step, past the events due by then.
+func (c *Clock) Advance(step int64) {
+	c.now += step
+	for len(c.due) > 0 && c.due[0] <= c.now {
+		heap.Pop(&c.due)
+	}
+}
+
+// timeHeap holds the times events are due as a min-heap.
+type timeHeap []int64
+
+func (h timeHeap) Len() int           { return len(h) }
+func (h timeHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h timeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *timeHeap) Push(x any)        { *h = append(*h, x.(int64)) }
+
+func (h *timeHeap) Pop() any {
+	old := *h
+	t := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return t
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+	c := NewClock(1)
+	if got := c.Step(-1); got != -1 {
+		t.Errorf("Step(-1) with nothing due = %d, want -1", got)
+	}
+	c.At(5)
+	c.At(2)
+	c.At(5)
+	c.At(0) // the current time is ignored
+	tests := []struct {
+		run, wantStep, wantNow int64
+	}{
+		{run: -1, wantStep: 2, wantNow: 2}, // idle until the event at 2
+		{run: 1, wantStep: 1, wantNow: 3},  // running for less than the time until the next event
+		{run: 4, wantStep: 2, wantNow: 5},  // stopped by the event at 5
+		{run: 3, wantStep: 3, wantNow: 8},  // nothing left due
+	}
+	for _, tt := range tests {
+		step := c.Step(tt.run)
+		if step != tt.wantStep {
+			t.Fatalf("Step(%d) at %d = %d, want %d", tt.run, c.Now(), step, tt.wantStep)
+		}
+		c.Advance(step)
+		if c.Now() != tt.wantNow {
+			t.Fatalf("Advance(%d) moved the clock to %d, want %d", step, c.Now(), tt.wantNow)
+		}
+	}
+	if got := c.Next(); got != -1 {
+		t.Errorf("Next() after every event = %d, want -1", got)
+	}
+	c.At(3) // in the past
+	if got := c.Step(-1); got != -1 {
+		t.Errorf("Step(-1) after a time in the past = %d, want -1", got)
+	}
+}
+
+func TestClock_Rand(t *testing.T) {
+	t.Parallel()
+	draw := func(seed int64) []int64 {
+		r := NewClock(seed).Rand()
+		return []int64{r.Int63n(100), r.Int63n(100), r.Int63n(100)}
+	}
+	if a, b := draw(7), draw(7); !reflect.DeepEqual(a, b) {
+		t.Errorf("clocks with the same seed drew %v and %v", a, b)
+	}
+}
+
+// Schedulers step from event to event, so a long burst runs in one step rather than a time unit at a time.
+func TestClock_longBursts(t *testing.T) {
+	t.Parallel()
+	const burst = 1_000_000_000_000
+	processes := []Process{{ProcessID: 1, BurstDuration: burst, Group: "/web"}}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+	}{
+		{name: "mlq", scheduler: MLQ{Queues: []Queue{{Discipline: FCFSQueue, MaxPriority: 9}}}},
+		{name: "mlfq", scheduler: MLFQ{Levels: []Level{{Discipline: RRQueue, Quantum: 8}, {Discipline: FCFSQueue}}}},
+		{name: "cgroup", scheduler: CgroupRoundRobin{Groups: []Cgroup{{Path: "/db", Quota: 1, Period: 10}}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.scheduler.Schedule(processes)
+			want := []TimeSlice{{PID: 1, Start: 0, Stop: burst}}
+			if !reflect.DeepEqual(got.Gantt, want) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, want)
+			}
+		})
+	}
+}
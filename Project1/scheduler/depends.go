@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownDependency = errors.New("unknown dependency")
+	ErrDependencyCycle   = errors.New("dependency cycle")
+)
+
+// CheckDependencies reports a process that depends on a PID that is not scheduled, or processes that depend on
+// each other in a cycle. Either would leave processes waiting forever.
+func CheckDependencies(processes []Process) error {
+	byPID := make(map[int64]*Process, len(processes))
+	for i := range processes {
+		byPID[processes[i].ProcessID] = &processes[i]
+	}
+	for i := range processes {
+		for _, d := range processes[i].DependsOn {
+			if _, ok := byPID[d]; !ok {
+				return fmt.Errorf("%w: process %d depends on %d", ErrUnknownDependency, processes[i].ProcessID, d)
+			}
+		}
+	}
+
+	// Depth-first search, where a dependency still on the path closes a cycle.
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	var (
+		state = make(map[int64]int, len(processes))
+		path  = make([]int64, 0)
+		visit func(pid int64) error
+	)
+	visit = func(pid int64) error {
+		switch state[pid] {
+		case done:
+			return nil
+		case onPath:
+			cycle := make([]string, 0)
+			for i := len(path) - 1; i >= 0; i-- {
+				if path[i] == pid {
+					for _, p := range append(path[i:], pid) {
+						cycle = append(cycle, fmt.Sprint(p))
+					}
+					break
+				}
+			}
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+		state[pid] = onPath
+		path = append(path, pid)
+		for _, d := range byPID[pid].DependsOn {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[pid] = done
+
+		return nil
+	}
+	for i := range processes {
+		if err := visit(processes[i].ProcessID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completions records the processes that have completed, so processes can be held back until their
+// dependencies have.
+type completions map[int64]bool
+
+// met reports whether every dependency of p has completed.
+func (c completions) met(p Process) bool {
+	for _, d := range p.DependsOn {
+		if !c[d] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{
+			name: "chain",
+			processes: []Process{
+				{ProcessID: 1},
+				{ProcessID: 2, DependsOn: []int64{1}},
+				{ProcessID: 3, DependsOn: []int64{1, 2}},
+			},
+		},
+		{
+			name: "unknown PID",
+			processes: []Process{
+				{ProcessID: 1, DependsOn: []int64{9}},
+			},
+			wantErr: ErrUnknownDependency,
+		},
+		{
+			name: "depends on itself",
+			processes: []Process{
+				{ProcessID: 1, DependsOn: []int64{1}},
+			},
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name: "cycle",
+			processes: []Process{
+				{ProcessID: 1},
+				{ProcessID: 2, DependsOn: []int64{1, 4}},
+				{ProcessID: 3, DependsOn: []int64{2}},
+				{ProcessID: 4, DependsOn: []int64{3}},
+			},
+			wantErr: ErrDependencyCycle,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckDependencies(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedulers_holdDependencies(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, DependsOn: []int64{2}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1},
+	}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		want      []TimeSlice
+	}{
+		{
+			name:      "FCFS",
+			scheduler: FCFS{},
+			want: []TimeSlice{
+				{PID: 3, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 6},
+			},
+		},
+		{
+			name:      "MLQ",
+			scheduler: MLQ{},
+			want: []TimeSlice{
+				{PID: 3, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 6},
+			},
+		},
+		{
+			name:      "MLFQ",
+			scheduler: MLFQ{},
+			want: []TimeSlice{
+				{PID: 3, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 6},
+			},
+		},
+		{
+			name:      "cgroup Round-Robin",
+			scheduler: CgroupRoundRobin{},
+			want: []TimeSlice{
+				{PID: 3, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.scheduler.Schedule(processes).Gantt; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Gantt = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
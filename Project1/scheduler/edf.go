@@ -0,0 +1,38 @@
+package scheduler
+
+// EDF is preemptive Earliest Deadline First scheduling: the ready process with the nearest deadline runs,
+// and an arrival with an earlier deadline preempts it. Processes without a deadline run only when no
+// process with one is ready.
+type EDF struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the EDF schedule of processes.
+func (e EDF) Schedule(processes []Process) ScheduleResult {
+	return e.observe(processes, nil)
+}
+
+func (e EDF) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{
+		less: func(a, b *task, _ int64) bool {
+			switch {
+			case a.Deadline == 0:
+				return false
+			case b.Deadline == 0:
+				return true
+			}
+			return a.Deadline < b.Deadline
+		},
+		fixedKeys:  true,
+		preemptive: true,
+		tieBreak:   e.TieBreak,
+		cores:      e.Cores,
+		switchCost: e.SwitchCost,
+		observers:  obs,
+	})
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEDF_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		processes  []Process
+		wantGantt  []TimeSlice
+		wantMisses int
+	}{
+		{
+			name: "earlier deadline preempts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Deadline: 20},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 2, Deadline: 5},
+				{ProcessID: 3, ArrivalTime: 3, BurstDuration: 4},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 7},
+				{PID: 3, Start: 7, Stop: 11},
+			},
+		},
+		{
+			name: "reports misses",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Deadline: 4},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Deadline: 6},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1, Deadline: 7},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 8},
+				{PID: 3, Start: 8, Stop: 9},
+			},
+			wantMisses: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := EDF{}.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if got.Stats.DeadlineMisses != tt.wantMisses {
+				t.Errorf("Schedule() misses = %v, want %v", got.Stats.DeadlineMisses, tt.wantMisses)
+			}
+		})
+	}
+}
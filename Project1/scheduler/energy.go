@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidEnergy is returned for frequency levels that can't be parsed.
+var ErrInvalidEnergy = errors.New("invalid energy model")
+
+// FrequencyLevel is an operating point of a CPU under dynamic voltage and frequency scaling.
+type FrequencyLevel struct {
+	// Frequency is the CPU's speed relative to the one the workload's bursts were measured at, so a CPU burst of
+	// t takes t/Frequency time units, rounded up. I/O bursts take the same time at any frequency.
+	Frequency float64
+	// Power is drawn while the CPU runs a process or switches context, and IdlePower while it has nothing to run.
+	Power     float64
+	IdlePower float64
+}
+
+// EnergyModel is the frequency levels every CPU can run at.
+type EnergyModel struct {
+	Levels []FrequencyLevel
+	// SleepPower is drawn by an idle CPU in its deep sleep state, which only racing to idle drops into.
+	SleepPower float64
+}
+
+// level returns the slowest level at least as fast as frequency, or the fastest level when there is none or
+// frequency is zero.
+func (m EnergyModel) level(frequency float64) FrequencyLevel {
+	var fastest, best FrequencyLevel
+	for _, l := range m.Levels {
+		if l.Frequency > fastest.Frequency {
+			fastest = l
+		}
+		if frequency > 0 && l.Frequency >= frequency && (best.Frequency == 0 || l.Frequency < best.Frequency) {
+			best = l
+		}
+	}
+	if best.Frequency == 0 {
+		return fastest
+	}
+
+	return best
+}
+
+// DVFS runs Scheduler with every CPU at one of Model's frequency levels and adds the energy the schedule uses,
+// and its energy-delay product, to its stats. A slower level draws less power but stretches every CPU burst, so
+// whether it saves energy depends on how much of the schedule is idle anyway.
+type DVFS struct {
+	Scheduler Scheduler
+	Model     EnergyModel
+	// Frequency picks the slowest level at least this fast, which idles at its IdlePower. Zero means racing to
+	// idle: running at the fastest level and sleeping whenever a CPU has nothing to run.
+	Frequency float64
+}
+
+// Schedule returns the schedule of processes with their CPU bursts scaled to the chosen frequency level.
+func (d DVFS) Schedule(processes []Process) ScheduleResult {
+	return d.observe(processes, nil)
+}
+
+func (d DVFS) observe(processes []Process, obs observers) ScheduleResult {
+	level := d.Model.level(d.Frequency)
+	idlePower := level.IdlePower
+	if d.Frequency == 0 {
+		idlePower = d.Model.SleepPower
+	}
+
+	scaled := make([]Process, len(processes))
+	for i, p := range processes {
+		scaled[i] = p.atFrequency(level.Frequency)
+	}
+	result := schedule(d.Scheduler, scaled, obs)
+
+	var active int64
+	for _, slice := range result.Gantt {
+		active += slice.Stop - slice.Start
+	}
+	result.Stats.Energy = float64(active)*level.Power + float64(result.Stats.IdleTime)*idlePower
+	result.Stats.EnergyDelay = result.Stats.Energy * float64(result.Stats.Makespan)
+
+	return result
+}
+
+// atFrequency returns p with its CPU bursts stretched, or shrunk, to run at frequency.
+func (p Process) atFrequency(frequency float64) Process {
+	if frequency <= 0 || frequency == 1 {
+		return p
+	}
+	scale := func(burst int64) int64 {
+		return max(int64(math.Ceil(float64(burst)/frequency)), 1)
+	}
+	if len(p.Bursts) == 0 {
+		p.BurstDuration = scale(p.BurstDuration)
+		return p
+	}
+	bursts := make([]int64, len(p.Bursts))
+	copy(bursts, p.Bursts)
+	p.BurstDuration = 0
+	for i := 0; i < len(bursts); i += 2 {
+		bursts[i] = scale(bursts[i])
+		p.BurstDuration += bursts[i]
+	}
+	p.Bursts = bursts
+
+	return p
+}
+
+// ParseFrequencyLevels parses a comma separated list of frequency levels, each written as
+// <frequency>:<power>[:<idle power>], e.g. "1:10:2,0.5:3:1". Idle power defaults to zero.
+func ParseFrequencyLevels(spec string) ([]FrequencyLevel, error) {
+	levels := make([]FrequencyLevel, 0)
+	for _, def := range strings.Split(spec, ",") {
+		fields := strings.Split(strings.TrimSpace(def), ":")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%w: %q: expected frequency:power[:idle power]", ErrInvalidEnergy, def)
+		}
+		var (
+			l   FrequencyLevel
+			err error
+		)
+		if l.Frequency, err = strconv.ParseFloat(fields[0], 64); err != nil || !finite(l.Frequency) || l.Frequency <= 0 {
+			return nil, fmt.Errorf("%w: %q: frequency must be a positive number", ErrInvalidEnergy, def)
+		}
+		if l.Power, err = strconv.ParseFloat(fields[1], 64); err != nil || !finite(l.Power) || l.Power < 0 {
+			return nil, fmt.Errorf("%w: %q: power must not be negative", ErrInvalidEnergy, def)
+		}
+		if len(fields) > 2 {
+			if l.IdlePower, err = strconv.ParseFloat(fields[2], 64); err != nil || !finite(l.IdlePower) || l.IdlePower < 0 {
+				return nil, fmt.Errorf("%w: %q: idle power must not be negative", ErrInvalidEnergy, def)
+			}
+		}
+		levels = append(levels, l)
+	}
+
+	return levels, nil
+}
+
+// finite reports whether v is neither NaN nor infinite, which ParseFloat accepts.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDVFS_Schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 10, BurstDuration: 2},
+	}
+	model := EnergyModel{Levels: []FrequencyLevel{
+		{Frequency: 1, Power: 10, IdlePower: 2},
+		{Frequency: 0.5, Power: 3, IdlePower: 1},
+	}}
+	tests := []struct {
+		name            string
+		frequency       float64
+		wantGantt       []TimeSlice
+		wantEnergy      float64
+		wantEnergyDelay float64
+	}{
+		{
+			name:            "race to idle sleeps between bursts",
+			wantGantt:       []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 10, Stop: 12}},
+			wantEnergy:      60,
+			wantEnergyDelay: 720,
+		},
+		{
+			name:            "half speed stretches the bursts into the idle time",
+			frequency:       0.5,
+			wantGantt:       []TimeSlice{{PID: 1, Start: 0, Stop: 8}, {PID: 2, Start: 10, Stop: 14}},
+			wantEnergy:      38,
+			wantEnergyDelay: 532,
+		},
+		{
+			name:            "frequencies round up to a level",
+			frequency:       0.7,
+			wantGantt:       []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 10, Stop: 12}},
+			wantEnergy:      72,
+			wantEnergyDelay: 864,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := DVFS{Scheduler: FCFS{}, Model: model, Frequency: tt.frequency}.Schedule(processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if got.Stats.Energy != tt.wantEnergy || got.Stats.EnergyDelay != tt.wantEnergyDelay {
+				t.Errorf("Schedule() energy = %v, energy-delay = %v, want %v, %v",
+					got.Stats.Energy, got.Stats.EnergyDelay, tt.wantEnergy, tt.wantEnergyDelay)
+			}
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestProcess_atFrequency(t *testing.T) {
+	t.Parallel()
+	p := Process{ProcessID: 1, BurstDuration: 5, Bursts: []int64{3, 4, 2}}
+	got := p.atFrequency(0.5)
+	want := Process{ProcessID: 1, BurstDuration: 10, Bursts: []int64{6, 4, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("atFrequency() = %+v, want %+v", got, want)
+	}
+	if p.Bursts[0] != 3 {
+		t.Errorf("atFrequency() modified the process's bursts: %v", p.Bursts)
+	}
+	if got := (Process{BurstDuration: 3}).atFrequency(4); got.BurstDuration != 1 {
+		t.Errorf("atFrequency() burst = %d, want 1", got.BurstDuration)
+	}
+}
+
+func TestParseFrequencyLevels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    []FrequencyLevel
+		wantErr error
+	}{
+		{
+			name: "success",
+			spec: "1:10:2, 0.5:3",
+			want: []FrequencyLevel{{Frequency: 1, Power: 10, IdlePower: 2}, {Frequency: 0.5, Power: 3}},
+		},
+		{name: "missing power", spec: "1", wantErr: ErrInvalidEnergy},
+		{name: "zero frequency", spec: "0:10", wantErr: ErrInvalidEnergy},
+		{name: "negative power", spec: "1:-1", wantErr: ErrInvalidEnergy},
+		{name: "bad idle power", spec: "1:10:x", wantErr: ErrInvalidEnergy},
+		{name: "too many fields", spec: "1:10:2:1", wantErr: ErrInvalidEnergy},
+		{name: "NaN frequency", spec: "NaN:10", wantErr: ErrInvalidEnergy},
+		{name: "infinite frequency", spec: "Inf:10", wantErr: ErrInvalidEnergy},
+		{name: "infinite power", spec: "1:+Inf", wantErr: ErrInvalidEnergy},
+		{name: "NaN idle power", spec: "1:10:NaN", wantErr: ErrInvalidEnergy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseFrequencyLevels(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseFrequencyLevels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFrequencyLevels() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ErrNotStreamable is returned for workloads that can't be scheduled as they're read.
+var ErrNotStreamable = errors.New("workload can't be streamed")
+
+// FCFS is first-come, first-serve scheduling: processes run to completion in the order they arrive.
+type FCFS struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the FCFS schedule of processes.
+func (f FCFS) Schedule(processes []Process) ScheduleResult {
+	return f.observe(processes, nil)
+}
+
+func (f FCFS) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{cores: f.Cores, switchCost: f.SwitchCost, tieBreak: f.TieBreak, observers: obs})
+}
+
+// Stream schedules processes first-come, first-serve as next returns them, in order of arrival, without holding
+// the workload: each process's result is handed to done as soon as it's dispatched, and only the statistics are
+// kept. next returns io.EOF after the last process. Streamed processes can't block on I/O, depend on others or be
+// suspended, and no GANTT chart is kept; otherwise the schedule is the same as Schedule's. Under TieArrival only
+// the processes arriving together are held, to be dispatched in PID order.
+func (f FCFS) Stream(next func() (Process, error), done func(ProcessResult) error) (Stats, error) {
+	type streamCore struct {
+		free int64 // when the CPU's current process completes
+		ran  bool  // whether the CPU has run a process, so that the next costs a context switch
+	}
+	var (
+		cores                      = make([]streamCore, max(int64(f.Cores), 1))
+		stats                      Stats
+		count, busy, lastArrival   int64
+		totalWait, totalTurnaround float64
+		slowdowns, slowdownSquares float64
+		// together holds the processes arriving at lastArrival that are yet to be dispatched.
+		together []Process
+	)
+	run := func(p Process) error {
+		// The process goes to the first CPU that is idle when it arrives, or else the first to become idle.
+		c := &cores[0]
+		for i := range cores {
+			if cores[i].free <= p.ArrivalTime {
+				c = &cores[i]
+				break
+			}
+			if cores[i].free < c.free {
+				c = &cores[i]
+			}
+		}
+		start := max(c.free, p.ArrivalTime)
+		if c.ran && f.SwitchCost > 0 {
+			stats.Switches++
+			stats.SwitchTime += f.SwitchCost
+			start += f.SwitchCost
+		}
+		c.free, c.ran = start+p.BurstDuration, true
+
+		row := ProcessResult{
+			Process:    p,
+			Wait:       start - p.ArrivalTime,
+			Response:   start - p.ArrivalTime,
+			Turnaround: c.free - p.ArrivalTime,
+			Completion: c.free,
+		}
+		count++
+		busy += p.BurstDuration
+		totalWait += float64(row.Wait)
+		totalTurnaround += float64(row.Turnaround)
+		slowdowns += row.Slowdown()
+		slowdownSquares += row.Slowdown() * row.Slowdown()
+		stats.Makespan = max(stats.Makespan, row.Completion)
+		if row.MissedDeadline() {
+			stats.DeadlineMisses++
+		}
+		return done(row)
+	}
+	flush := func() error {
+		sort.SliceStable(together, func(i, j int) bool {
+			return f.TieBreak.before(&together[i], &together[j])
+		})
+		for _, p := range together {
+			if err := run(p); err != nil {
+				return err
+			}
+		}
+		together = together[:0]
+		return nil
+	}
+	for {
+		p, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		switch {
+		case p.ArrivalTime < lastArrival:
+			return stats, fmt.Errorf("%w: process %d arrives before the process ahead of it", ErrNotStreamable, p.ProcessID)
+		case len(p.Bursts) > 0 || len(p.DependsOn) > 0 || len(p.Suspensions) > 0:
+			return stats, fmt.Errorf("%w: process %d has I/O bursts, dependencies or suspensions", ErrNotStreamable, p.ProcessID)
+		}
+		if p.ArrivalTime > lastArrival || f.TieBreak == TieFIFO {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+		lastArrival = p.ArrivalTime
+		together = append(together, p)
+	}
+	if err := flush(); err != nil {
+		return stats, err
+	}
+	if count == 0 {
+		return stats, nil
+	}
+
+	n := float64(count)
+	stats.AveWait = totalWait / n
+	stats.AveResponse = totalWait / n
+	stats.AveTurnaround = totalTurnaround / n
+	stats.AveThroughput = n / float64(stats.Makespan)
+	stats.Fairness = slowdowns * slowdowns / (n * slowdownSquares)
+	capacity := stats.Makespan * int64(len(cores))
+	stats.IdleTime = capacity - busy - stats.SwitchTime
+	stats.Utilization = float64(busy) / float64(capacity)
+
+	return stats, nil
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFCFS_Schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	want := ScheduleResult{
+		Gantt: []TimeSlice{
+			{PID: 1, Start: 0, Stop: 5},
+			{PID: 2, Start: 5, Stop: 14},
+			{PID: 3, Start: 14, Stop: 20},
+		},
+		Processes: []ProcessResult{
+			{Process: processes[0], Wait: 0, Turnaround: 5, Completion: 5},
+			{Process: processes[1], Wait: 2, Response: 2, Turnaround: 11, Completion: 14},
+			{Process: processes[2], Wait: 8, Response: 8, Turnaround: 14, Completion: 20},
+		},
+		Stats: Stats{
+			AveWait:       10.0 / 3,
+			AveResponse:   10.0 / 3,
+			AveTurnaround: 30.0 / 3,
+			AveThroughput: 3.0 / 20,
+			Fairness:      jain(1, 11.0/9, 14.0/6),
+			Makespan:      20,
+			Utilization:   1,
+		},
+	}
+
+	if got := (FCFS{}).Schedule(processes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Schedule() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFCFS_Stream(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		fcfs FCFS
+	}{
+		{name: "one CPU", fcfs: FCFS{}},
+		{name: "switch cost", fcfs: FCFS{SwitchCost: 2}},
+		{name: "three CPUs with switch cost", fcfs: FCFS{Cores: 3, SwitchCost: 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes := randomWorkload(300, 2)
+			for i := range processes {
+				processes[i].Bursts = nil
+			}
+			rows := make(map[int64]ProcessResult)
+			stats, err := tt.fcfs.Stream(processStream(processes), func(row ProcessResult) error {
+				rows[row.ProcessID] = row
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Streaming gives the same schedule as simulating the whole workload.
+			want := tt.fcfs.Schedule(processes)
+			for _, row := range want.Processes {
+				if !reflect.DeepEqual(rows[row.ProcessID], row) {
+					t.Errorf("process %d = %+v, want %+v", row.ProcessID, rows[row.ProcessID], row)
+				}
+			}
+			// The floating point statistics are summed in a different order.
+			for _, f := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"AveWait", stats.AveWait, want.Stats.AveWait},
+				{"AveResponse", stats.AveResponse, want.Stats.AveResponse},
+				{"AveTurnaround", stats.AveTurnaround, want.Stats.AveTurnaround},
+				{"AveThroughput", stats.AveThroughput, want.Stats.AveThroughput},
+				{"Fairness", stats.Fairness, want.Stats.Fairness},
+				{"Utilization", stats.Utilization, want.Stats.Utilization},
+			} {
+				if math.Abs(f.got-f.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+			counts := func(s Stats) []int64 {
+				return []int64{int64(s.DeadlineMisses), int64(s.Switches), s.SwitchTime, s.Makespan, s.IdleTime}
+			}
+			if !reflect.DeepEqual(counts(stats), counts(want.Stats)) {
+				t.Errorf("Stream() stats = %+v, want %+v", stats, want.Stats)
+			}
+		})
+	}
+}
+
+func TestFCFS_Stream_notStreamable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+	}{
+		{
+			name:      "out of order",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 5, BurstDuration: 1}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 1}},
+		},
+		{
+			name:      "I/O",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 3, 1}}},
+		},
+		{
+			name:      "dependencies",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 2, BurstDuration: 1, DependsOn: []int64{1}}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := FCFS{}.Stream(processStream(tt.processes), func(ProcessResult) error { return nil })
+			if !errors.Is(err, ErrNotStreamable) {
+				t.Errorf("error = %v, want %v", err, ErrNotStreamable)
+			}
+		})
+	}
+}
+
+// processStream returns a Stream source of processes.
+func processStream(processes []Process) func() (Process, error) {
+	return func() (Process, error) {
+		if len(processes) == 0 {
+			return Process{}, io.EOF
+		}
+		p := processes[0]
+		processes = processes[1:]
+		return p, nil
+	}
+}
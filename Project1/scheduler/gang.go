@@ -0,0 +1,413 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnschedulableGang is returned by Gang.Check for gangs that could never run.
+var ErrUnschedulableGang = errors.New("unschedulable gang")
+
+// Gang is gang scheduling, or co-scheduling: the processes of a gang, the threads of one program, run at the same
+// time on CPUs of their own, so they can synchronize without waiting on a sibling that isn't running. A gang is
+// ready once all its members have arrived and their dependencies have completed, and it is dispatched only when
+// enough CPUs in the members' affinities are free for every member at once. Gangs and processes that run alone are
+// dispatched first-come, first-serve, so a gang that doesn't fit yet holds up the ones behind it. The CPU time left
+// idle while a gang waits, for its members to arrive, for their dependencies or for free CPUs, is the schedule's
+// Fragmentation. Each member then keeps its CPU until it completes, even while it blocks on the I/O between its CPU
+// bursts, so the gang stays together; the CPU idles meanwhile, which adds to the Fragmentation. A process that runs
+// alone leaves its CPU to block, and rejoins the back of the queue when the I/O completes. Suspending any member
+// suspends its whole gang: the members still running are taken off their CPUs, and the gang rejoins the back of the
+// queue once none of its members is suspended.
+type Gang struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// TieBreak orders simultaneous arrivals.
+	TieBreak TieBreak
+}
+
+// Schedule returns the gang schedule of processes, or an empty result, rather than one missing the processes that
+// could never run, when Check rejects them.
+func (g Gang) Schedule(processes []Process) ScheduleResult {
+	return g.observe(processes, nil)
+}
+
+func (g Gang) observe(processes []Process, obs observers) ScheduleResult {
+	if g.Check(processes) != nil {
+		return ScheduleResult{}
+	}
+	var (
+		clock    = NewClock(0)
+		tasks    = arrivalOrder(processes, g.TieBreak)
+		cores    = make([]*core, max(int64(g.Cores), 1))
+		size     = make(map[int64]int)     // the number of members of each gang
+		arrived  = make(map[int64][]*task) // the members of each gang that have arrived
+		waiting  = make([][]*task, 0)      // gangs and processes that have arrived, held back by dependencies
+		ready    = make([][]*task, 0)      // gangs and processes ready to dispatch, in order
+		paused   = make([]gangPause, 0)    // gangs and processes with a member suspended
+		io       = newBlocked(g.TieBreak, clock)
+		finished = make(completions)
+		rows     = make([]ProcessResult, 0, len(processes))
+		next     int
+		frag     int64
+		partial  int // gangs some, but not all, of whose members have arrived
+	)
+	for i := range cores {
+		cores[i] = &core{id: i}
+	}
+	for _, t := range tasks {
+		t.remaining = t.cpuBurst()
+		if t.Gang != 0 {
+			size[t.Gang]++
+		}
+		clock.At(t.ArrivalTime)
+		for _, s := range t.Suspensions {
+			clock.At(s.At)
+		}
+	}
+	admit := func() {
+		now := clock.Now()
+		for next < len(tasks) && tasks[next].ArrivalTime <= now {
+			t := tasks[next]
+			next++
+			obs.arrive(t)
+			if t.Gang == 0 {
+				waiting = append(waiting, []*task{t})
+				continue
+			}
+			arrived[t.Gang] = append(arrived[t.Gang], t)
+			switch len(arrived[t.Gang]) {
+			case size[t.Gang]:
+				waiting = append(waiting, arrived[t.Gang])
+				if size[t.Gang] > 1 {
+					partial--
+				}
+			case 1:
+				partial++
+			}
+		}
+		for _, t := range io.wake(now) {
+			ready = append(ready, []*task{t})
+		}
+		for i := 0; i < len(waiting); i++ {
+			met := true
+			for _, t := range waiting[i] {
+				met = met && finished.met(t.Process)
+			}
+			if met {
+				ready = append(ready, waiting[i])
+				waiting = append(waiting[:i], waiting[i+1:]...)
+				i--
+			}
+		}
+	}
+	// suspend takes the gangs and processes with a member suspended at now off their CPUs and out of the queue,
+	// and puts back the ones whose members have all resumed.
+	suspend := func() {
+		now := clock.Now()
+		kept := ready[:0]
+		for _, unit := range ready {
+			if gangSuspended(unit, now) {
+				paused = append(paused, gangPause{unit: unit, since: now})
+			} else {
+				kept = append(kept, unit)
+			}
+		}
+		ready = kept
+		for _, c := range cores {
+			if c.t == nil || c.t.suspendedUntil(now) == now {
+				continue
+			}
+			unit := []*task{c.t}
+			for _, o := range cores {
+				if o != c && o.t != nil && c.t.Gang != 0 && o.t.Gang == c.t.Gang {
+					unit = append(unit, o.t)
+				}
+			}
+			for _, o := range cores {
+				for _, t := range unit {
+					if o.t == t {
+						o.preempt(obs, now)
+						o.t = nil
+					}
+				}
+			}
+			paused = append(paused, gangPause{unit: unit, since: now})
+		}
+		for i := 0; i < len(paused); i++ {
+			if gangSuspended(paused[i].unit, now) {
+				continue
+			}
+			for _, t := range paused[i].unit {
+				t.suspended += t.suspendedBetween(paused[i].since, now)
+			}
+			ready = append(ready, paused[i].unit)
+			paused = append(paused[:i], paused[i+1:]...)
+			i--
+		}
+	}
+
+	for len(rows) < len(tasks) {
+		admit()
+		suspend()
+		now := clock.Now()
+		var free []*core
+		for _, c := range cores {
+			if c.t == nil {
+				free = append(free, c)
+			}
+		}
+		for len(ready) > 0 {
+			matched := make(map[*core]*task, len(ready[0]))
+			fits := true
+			for _, t := range ready[0] {
+				fits = fits && match(t, free, matched, make(map[*core]bool))
+			}
+			if !fits {
+				break
+			}
+			rest := free[:0]
+			for _, c := range free {
+				if t, ok := matched[c]; ok {
+					if t.started < 0 {
+						t.started = now
+					}
+					c.t = t
+				} else {
+					rest = append(rest, c)
+				}
+			}
+			free, ready = rest, ready[1:]
+		}
+
+		run := int64(-1)
+		var blocking int64 // CPUs held by gang members blocked on I/O
+		for _, c := range cores {
+			switch {
+			case c.t == nil:
+			case c.t.wake > now:
+				blocking++
+			case run < 0 || c.t.remaining < run:
+				run = c.t.remaining
+			}
+		}
+		// Stop when a suspension may end, as well as at arrivals and when suspensions begin.
+		for _, p := range paused {
+			for _, t := range p.unit {
+				clock.At(t.suspendedUntil(now))
+			}
+		}
+		step := clock.Step(run)
+		if step < 0 {
+			// Only processes waiting on dependencies that can never complete are left.
+			break
+		}
+		if len(ready) > 0 || partial > 0 || gangsHeld(paused, now) || gangsWaiting(waiting, finished) {
+			frag += int64(len(free)) * step
+		}
+		frag += blocking * step
+		for _, c := range cores {
+			if c.t != nil && c.t.wake <= now {
+				c.start(obs, now)
+			}
+		}
+		clock.Advance(step)
+		now = clock.Now()
+		for _, c := range cores {
+			if c.t == nil || c.t.wake > now-step {
+				continue
+			}
+			c.gantt = appendSlice(c.gantt, c.t.ProcessID, now-step, now)
+			c.t.remaining -= step
+			switch {
+			case c.t.remaining > 0:
+			case c.t.blocksOnIO() && c.t.Gang != 0:
+				// The member keeps its CPU while it blocks.
+				c.leave(obs, EventBlock, now)
+				clock.At(c.t.startIO(now))
+			case c.t.blocksOnIO():
+				c.leave(obs, EventBlock, now)
+				io.block(c.t)
+				c.t = nil
+			default:
+				c.leave(obs, EventComplete, now)
+				turnaround := now - c.t.ArrivalTime
+				finished[c.t.ProcessID] = true
+				rows = append(rows, ProcessResult{
+					Process:    c.t.Process,
+					Wait:       turnaround - c.t.BurstDuration - c.t.IOTime() - c.t.suspended,
+					Response:   c.t.started - c.t.ArrivalTime,
+					Turnaround: turnaround,
+					Completion: now,
+					Suspended:  c.t.suspended,
+				})
+				c.t = nil
+			}
+		}
+	}
+
+	result := ScheduleResult{
+		Gantt:     mergeGantts(cores),
+		Processes: rows,
+		Stats:     summarize(rows),
+	}
+	result.Stats.account(result.Gantt, rows, len(cores))
+	result.Stats.Fragmentation = frag
+	if len(cores) > 1 {
+		result.Cores = coreStats(cores, rows)
+		result.Stats.LoadImbalance = loadImbalance(result.Cores)
+	}
+
+	return result
+}
+
+// Check returns an error wrapping ErrUnschedulableGang for a gang that could never run, holding up every process
+// queued behind it: one with more members than CPUs, or whose members' affinities can't all be met at once, or
+// with a member that depends, directly or through other processes and gangs, on another member.
+func (g Gang) Check(processes []Process) error {
+	var (
+		cores   = make([]*core, max(int64(g.Cores), 1))
+		members = make(map[int64][]*task)
+		gangs   = make([]int64, 0)
+	)
+	for i := range cores {
+		cores[i] = &core{id: i}
+	}
+	for i := range processes {
+		if id := processes[i].Gang; id != 0 {
+			if _, ok := members[id]; !ok {
+				gangs = append(gangs, id)
+			}
+			members[id] = append(members[id], &task{Process: processes[i]})
+		}
+	}
+	for _, id := range gangs {
+		if len(members[id]) > len(cores) {
+			return fmt.Errorf("%w: gang %d has more members than the %d CPUs", ErrUnschedulableGang, id, len(cores))
+		}
+		matched := make(map[*core]*task, len(members[id]))
+		for _, t := range members[id] {
+			if !match(t, cores, matched, make(map[*core]bool)) {
+				return fmt.Errorf("%w: the affinities of gang %d's members can't all be met at once", ErrUnschedulableGang, id)
+			}
+		}
+	}
+
+	return checkGangDependencies(processes)
+}
+
+// checkGangDependencies looks for a cycle of dependencies between gangs and processes that run alone, each of which
+// only runs once everything it depends on has completed, so a gang with a member that depends on another member is
+// a cycle of its own.
+func checkGangDependencies(processes []Process) error {
+	type unit struct{ gang, pid int64 }
+	var (
+		byPID = make(map[int64]*Process, len(processes))
+		deps  = make(map[unit][]unit)
+		units = make([]unit, 0, len(processes))
+		state = make(map[unit]int)
+		visit func(u unit) error
+	)
+	unitOf := func(p *Process) unit {
+		if p.Gang != 0 {
+			return unit{gang: p.Gang}
+		}
+		return unit{pid: p.ProcessID}
+	}
+	for i := range processes {
+		byPID[processes[i].ProcessID] = &processes[i]
+	}
+	for i := range processes {
+		u := unitOf(&processes[i])
+		if _, ok := deps[u]; !ok {
+			deps[u] = nil
+			units = append(units, u)
+		}
+		for _, d := range processes[i].DependsOn {
+			if p, ok := byPID[d]; ok {
+				deps[u] = append(deps[u], unitOf(p))
+			}
+		}
+	}
+
+	// Depth-first search, where a unit still on the path closes a cycle.
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	visit = func(u unit) error {
+		switch state[u] {
+		case done:
+			return nil
+		case onPath:
+			if u.gang != 0 {
+				return fmt.Errorf("%w: gang %d depends on itself through its members' dependencies", ErrUnschedulableGang, u.gang)
+			}
+			return fmt.Errorf("%w: process %d depends on itself", ErrUnschedulableGang, u.pid)
+		}
+		state[u] = onPath
+		for _, d := range deps[u] {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		state[u] = done
+
+		return nil
+	}
+	for _, u := range units {
+		if err := visit(u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gangPause is a gang, or a process, held back since a member was suspended.
+type gangPause struct {
+	unit  []*task
+	since int64
+}
+
+// gangSuspended reports whether any member of unit is suspended at now.
+func gangSuspended(unit []*task, now int64) bool {
+	for _, t := range unit {
+		if t.suspendedUntil(now) > now {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gangsWaiting reports whether any of the gangs held back by dependencies has a member whose own dependencies have
+// completed, which could run but for its siblings'.
+func gangsWaiting(waiting [][]*task, finished completions) bool {
+	for _, unit := range waiting {
+		if len(unit) < 2 {
+			continue
+		}
+		for _, t := range unit {
+			if finished.met(t.Process) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// gangsHeld reports whether any of the paused gangs has a member that could run but for a suspended sibling.
+func gangsHeld(paused []gangPause, now int64) bool {
+	for _, p := range paused {
+		for _, t := range p.unit {
+			if t.suspendedUntil(now) == now {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGang_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name              string
+		gang              Gang
+		processes         []Process
+		wantGantt         []TimeSlice
+		wantFragmentation int64
+	}{
+		{
+			name: "a gang waits for enough free CPUs and holds up the processes behind it",
+			gang: Gang{Cores: 4},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Gang: 1},
+				{ProcessID: 2, BurstDuration: 2, Gang: 1},
+				{ProcessID: 3, BurstDuration: 3},
+				{ProcessID: 4, ArrivalTime: 1, BurstDuration: 2, Gang: 2},
+				{ProcessID: 5, ArrivalTime: 1, BurstDuration: 2, Gang: 2},
+				{ProcessID: 6, ArrivalTime: 1, BurstDuration: 2, Gang: 2},
+				{ProcessID: 7, ArrivalTime: 1, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 0, Stop: 2, CPU: 1},
+				{PID: 3, Start: 0, Stop: 3, CPU: 2},
+				{PID: 4, Start: 3, Stop: 5, CPU: 1},
+				{PID: 5, Start: 3, Stop: 5, CPU: 2},
+				{PID: 6, Start: 3, Stop: 5, CPU: 3},
+				{PID: 7, Start: 4, Stop: 5},
+			},
+			// One CPU idles from 1 to 2 and two from 2 to 3 while gang 2 waits.
+			wantFragmentation: 3,
+		},
+		{
+			name: "a gang is ready once its last member arrives",
+			gang: Gang{Cores: 2},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Gang: 7},
+				{ProcessID: 2, BurstDuration: 1},
+				{ProcessID: 3, ArrivalTime: 3, BurstDuration: 2, Gang: 7},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 1},
+				{PID: 1, Start: 3, Stop: 5},
+				{PID: 3, Start: 3, Stop: 5, CPU: 1},
+			},
+			wantFragmentation: 5,
+		},
+		{
+			name: "without gangs it is FCFS",
+			gang: Gang{},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 3, 1}},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 4, Stop: 5},
+			},
+		},
+		{
+			name: "a gang member keeps its CPU while it blocks on I/O",
+			gang: Gang{Cores: 2},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 2, 1}, Gang: 7},
+				{ProcessID: 2, BurstDuration: 4, Gang: 7},
+				{ProcessID: 3, BurstDuration: 2, Bursts: []int64{1, 2, 1}},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 0, Stop: 4, CPU: 1},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 5},
+				{PID: 3, Start: 7, Stop: 8},
+			},
+			wantFragmentation: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.gang.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if got.Stats.Fragmentation != tt.wantFragmentation {
+				t.Errorf("Schedule() fragmentation = %d, want %d", got.Stats.Fragmentation, tt.wantFragmentation)
+			}
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGang_Check(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{
+			name: "fits",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Gang: 1, Affinity: []int{0}},
+				{ProcessID: 2, BurstDuration: 1, Gang: 1},
+				{ProcessID: 3, BurstDuration: 1, DependsOn: []int64{1}},
+			},
+		},
+		{
+			name: "too large",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Gang: 1},
+				{ProcessID: 2, BurstDuration: 1, Gang: 1},
+				{ProcessID: 3, BurstDuration: 1, Gang: 1},
+			},
+			wantErr: ErrUnschedulableGang,
+		},
+		{
+			name: "affinities clash",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Gang: 1, Affinity: []int{1}},
+				{ProcessID: 2, BurstDuration: 1, Gang: 1, Affinity: []int{1}},
+			},
+			wantErr: ErrUnschedulableGang,
+		},
+		{
+			name: "a member depends on another",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Gang: 1},
+				{ProcessID: 2, BurstDuration: 1, Gang: 1, DependsOn: []int64{1}},
+			},
+			wantErr: ErrUnschedulableGang,
+		},
+		{
+			name: "a member depends on another through a process",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Gang: 1},
+				{ProcessID: 2, BurstDuration: 1, Gang: 1, DependsOn: []int64{3}},
+				{ProcessID: 3, BurstDuration: 1, DependsOn: []int64{1}},
+			},
+			wantErr: ErrUnschedulableGang,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := Gang{Cores: 2}
+			if err := g.Check(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := g.Schedule(tt.processes); tt.wantErr != nil && !reflect.DeepEqual(got, ScheduleResult{}) {
+				t.Errorf("Schedule() = %+v, want an empty result", got)
+			}
+		})
+	}
+}
+
+func TestGang_Schedule_waitingOnDependencies(t *testing.T) {
+	t.Parallel()
+	got := Gang{Cores: 2}.Schedule([]Process{
+		{ProcessID: 1, BurstDuration: 2, Gang: 7},
+		{ProcessID: 2, BurstDuration: 2, Gang: 7, DependsOn: []int64{3}},
+		{ProcessID: 3, BurstDuration: 2},
+	})
+	// CPU 1 idles from 0 to 2 while gang 7 waits on process 3.
+	if got.Stats.Fragmentation != 2 {
+		t.Errorf("Fragmentation = %d, want 2", got.Stats.Fragmentation)
+	}
+	if err := got.Check(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,32 @@
+package scheduler
+
+// HRRN is Highest Response Ratio Next scheduling: at each dispatch the ready process with the highest
+// response ratio, (wait + burst) / burst, runs to completion. Long jobs age towards the front of the queue,
+// so unlike SJF they cannot starve.
+type HRRN struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the HRRN schedule of processes.
+func (h HRRN) Schedule(processes []Process) ScheduleResult {
+	return h.observe(processes, nil)
+}
+
+func (h HRRN) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{
+		less: func(a, b *task, now int64) bool {
+			// Compare (wa+ba)/ba > (wb+bb)/bb without dividing.
+			ba, bb := a.cpuBurst(), b.cpuBurst()
+			return (now-a.readySince+ba)*bb > (now-b.readySince+bb)*ba
+		},
+		tieBreak:   h.TieBreak,
+		cores:      h.Cores,
+		switchCost: h.SwitchCost,
+		observers:  obs,
+	})
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHRRN_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "textbook example",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 6},
+				{ProcessID: 3, ArrivalTime: 4, BurstDuration: 4},
+				{ProcessID: 4, ArrivalTime: 6, BurstDuration: 5},
+				{ProcessID: 5, ArrivalTime: 8, BurstDuration: 2},
+			},
+			// At 9: P3 (5+4)/4=2.25, P4 (3+5)/5=1.6, P5 (1+2)/2=1.5.
+			// At 13: P4 (7+5)/5=2.4, P5 (5+2)/2=3.5.
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 9},
+				{PID: 3, Start: 9, Stop: 13},
+				{PID: 5, Start: 13, Stop: 15},
+				{PID: 4, Start: 15, Stop: 20},
+			},
+		},
+		{
+			name: "runs to completion without preemption",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 10},
+				{PID: 2, Start: 10, Stop: 11},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (HRRN{}).Schedule(tt.processes); !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
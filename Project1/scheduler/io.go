@@ -0,0 +1,56 @@
+package scheduler
+
+import "sort"
+
+// blocksOnIO reports whether an I/O burst follows the task's current CPU burst.
+func (t *task) blocksOnIO() bool {
+	return t.burst+1 < len(t.Bursts)
+}
+
+// startIO moves the task past its current CPU burst and the I/O burst that follows, on to its next CPU burst, and
+// returns when the I/O completes.
+func (t *task) startIO(now int64) int64 {
+	t.wake = now + t.Bursts[t.burst+1]
+	t.burst += 2
+	t.remaining = t.cpuBurst()
+
+	return t.wake
+}
+
+// blocked holds the tasks of a simulation that are blocked on I/O, until it completes. The clock is told when each
+// task's I/O completes.
+type blocked struct {
+	tasks    []*task
+	tieBreak TieBreak
+	clock    *Clock
+}
+
+func newBlocked(tb TieBreak, clock *Clock) *blocked {
+	return &blocked{tieBreak: tb, clock: clock}
+}
+
+// block blocks t on the I/O burst that follows the CPU burst it has just finished.
+func (b *blocked) block(t *task) {
+	b.clock.At(t.startIO(b.clock.Now()))
+	b.tasks = append(b.tasks, t)
+}
+
+// wake returns the tasks whose I/O has completed by now, in the order it completed, with ties ordered by the
+// tie-break policy. Each is ready from when its I/O completed.
+func (b *blocked) wake(now int64) []*task {
+	sort.SliceStable(b.tasks, func(i, j int) bool {
+		if b.tasks[i].wake != b.tasks[j].wake {
+			return b.tasks[i].wake < b.tasks[j].wake
+		}
+		return b.tieBreak.before(&b.tasks[i].Process, &b.tasks[j].Process)
+	})
+	var woken []*task
+	for len(b.tasks) > 0 && b.tasks[0].wake <= now {
+		t := b.tasks[0]
+		t.readySince = t.wake
+		woken = append(woken, t)
+		b.tasks = b.tasks[1:]
+	}
+
+	return woken
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import "sort"
+
+// Share compares the CPU share a process was entitled to by its tickets with the share it actually received.
+// Both are measured over the process's lifetime, from arrival to completion.
+type Share struct {
+	PID     int64
+	Tickets int64
+	// TicketShare is the process's average fraction of the tickets held by processes in the system.
+	TicketShare float64
+	// CPUShare is the fraction of busy CPU time the process received.
+	CPUShare float64
+}
+
+// Lottery is lottery scheduling: each process holds its Priority as a number of tickets (at least one),
+// and every quantum the CPU goes to the holder of a randomly drawn ticket. The same Seed always produces
+// the same schedule.
+type Lottery struct {
+	Seed int64
+	// Quantum is the time between draws; zero means one time unit.
+	Quantum int64
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns a lottery schedule of processes.
+func (l Lottery) Schedule(processes []Process) ScheduleResult {
+	return l.observe(processes, nil)
+}
+
+func (l Lottery) observe(processes []Process, obs observers) ScheduleResult {
+	quantum := l.Quantum
+	if quantum <= 0 {
+		quantum = 1
+	}
+
+	result := simulate(processes, policy{
+		choose: func(ready []*task, clock *Clock) int {
+			var total int64
+			for _, t := range ready {
+				total += tickets(t.Process)
+			}
+			winner := clock.Rand().Int63n(total)
+			for i, t := range ready {
+				if winner < tickets(t.Process) {
+					return i
+				}
+				winner -= tickets(t.Process)
+			}
+			return len(ready) - 1
+		},
+		preemptive: true,
+		quantum:    quantum,
+		tieBreak:   l.TieBreak,
+		cores:      l.Cores,
+		switchCost: l.SwitchCost,
+		seed:       l.Seed,
+		observers:  obs,
+	})
+	result.Shares = shares(result, tickets)
+
+	return result
+}
+
+func tickets(p Process) int64 {
+	if p.Priority < 1 {
+		return 1
+	}
+	return p.Priority
+}
+
+// shares measures each process's entitled and received CPU share over its lifetime. It sweeps through the
+// arrivals, completions and GANTT slices, between which the tickets held and the busy CPUs don't change, keeping
+// running totals of the ticket share per ticket and of the busy CPU time, so each process's shares are the
+// difference of the totals at its arrival and its completion.
+func shares(result ScheduleResult, weight func(Process) int64) []Share {
+	type change struct {
+		at            int64
+		tickets, busy int64
+	}
+	var (
+		rows    = result.Processes
+		out     = make([]Share, len(rows))
+		ran     = make(map[int64]int64, len(rows))
+		changes = make([]change, 0, 2*len(rows)+2*len(result.Gantt))
+	)
+	for _, r := range rows {
+		changes = append(changes, change{at: r.ArrivalTime, tickets: weight(r.Process)},
+			change{at: r.Completion, tickets: -weight(r.Process)})
+	}
+	for _, s := range result.Gantt {
+		if s.Switch {
+			continue
+		}
+		ran[s.PID] += s.Stop - s.Start
+		changes = append(changes, change{at: s.Start, busy: 1}, change{at: s.Stop, busy: -1})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].at < changes[j].at
+	})
+
+	// perTicket[i] and busy[i] are the running totals up to times[i].
+	var (
+		times         = make([]int64, 0, len(changes))
+		perTicket     = make([]float64, 0, len(changes))
+		busy          = make([]int64, 0, len(changes))
+		tickets, cpus int64
+		share         float64
+		busyTime      int64
+	)
+	for i, c := range changes {
+		tickets += c.tickets
+		cpus += c.busy
+		if i+1 < len(changes) && changes[i+1].at == c.at {
+			continue
+		}
+		times, perTicket, busy = append(times, c.at), append(perTicket, share), append(busy, busyTime)
+		if i+1 < len(changes) {
+			span := changes[i+1].at - c.at
+			if tickets > 0 {
+				share += float64(span) / float64(tickets)
+			}
+			busyTime += cpus * span
+		}
+	}
+	// at returns the index of t in times.
+	at := func(t int64) int {
+		return sort.Search(len(times), func(i int) bool { return times[i] >= t })
+	}
+
+	for i, r := range rows {
+		from, to := at(r.ArrivalTime), at(r.Completion)
+		out[i].PID = r.ProcessID
+		out[i].Tickets = weight(r.Process)
+		if lifetime := r.Completion - r.ArrivalTime; lifetime > 0 {
+			out[i].TicketShare = float64(out[i].Tickets) * (perTicket[to] - perTicket[from]) / float64(lifetime)
+		}
+		if b := busy[to] - busy[from]; b > 0 {
+			out[i].CPUShare = float64(ran[r.ProcessID]) / float64(b)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLottery_Schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1000, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1000, Priority: 3},
+	}
+
+	t.Run("same seed same schedule", func(t *testing.T) {
+		t.Parallel()
+		a := Lottery{Seed: 42}.Schedule(processes)
+		b := Lottery{Seed: 42}.Schedule(processes)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("Schedule() is not reproducible for a fixed seed")
+		}
+	})
+
+	t.Run("CPU share follows ticket share", func(t *testing.T) {
+		t.Parallel()
+		got := Lottery{Seed: 7}.Schedule(processes)
+		for _, s := range got.Shares {
+			if s.PID != 2 {
+				continue
+			}
+			if s.TicketShare != 0.75 {
+				t.Errorf("TicketShare = %v, want 0.75", s.TicketShare)
+			}
+			if math.Abs(s.CPUShare-s.TicketShare) > 0.05 {
+				t.Errorf("CPUShare = %v, want about %v", s.CPUShare, s.TicketShare)
+			}
+			return
+		}
+		t.Errorf("Schedule() shares missing PID 2: %+v", got.Shares)
+	})
+
+	t.Run("every process completes", func(t *testing.T) {
+		t.Parallel()
+		got := Lottery{Seed: 1, Quantum: 4}.Schedule([]Process{
+			{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+			{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 10},
+			{ProcessID: 3, ArrivalTime: 30, BurstDuration: 2, Priority: 5},
+		})
+		if len(got.Processes) != 3 {
+			t.Fatalf("Schedule() completed %d processes, want 3", len(got.Processes))
+		}
+		var ran int64
+		for _, s := range got.Gantt {
+			ran += s.Stop - s.Start
+		}
+		if ran != 16 {
+			t.Errorf("Schedule() ran for %d, want 16", ran)
+		}
+	})
+
+	t.Run("shares skip idle time", func(t *testing.T) {
+		t.Parallel()
+		got := Lottery{Seed: 1}.Schedule([]Process{
+			{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+			{ProcessID: 2, ArrivalTime: 3_000_000_000, BurstDuration: 4, Priority: 3},
+		})
+		if len(got.Shares) != 2 {
+			t.Fatalf("Schedule() shares = %+v, want one per process", got.Shares)
+		}
+		for _, s := range got.Shares {
+			if math.Abs(s.TicketShare-1) > 1e-9 || math.Abs(s.CPUShare-1) > 1e-9 {
+				t.Errorf("share of PID %d = %+v, want all of the tickets and the CPU", s.PID, s)
+			}
+		}
+	})
+}
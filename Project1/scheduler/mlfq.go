@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Level is one level of a multi-level feedback queue.
+type Level struct {
+	// Discipline is how the level picks its next process: FCFSQueue, RRQueue, SJFQueue or PriorityQueue.
+	// Only RRQueue preempts within the level.
+	Discipline string
+	// Quantum is the RRQueue time slice; zero means one time unit.
+	Quantum int64
+	// Allotment is the CPU time a process may use at the level, over however many turns, before it is demoted to
+	// the next level; zero means one quantum. Processes are never demoted from the last level.
+	Allotment int64
+}
+
+// allotment returns the CPU time a process may use at the level before it is demoted.
+func (l Level) allotment() int64 {
+	if l.Allotment > 0 {
+		return l.Allotment
+	}
+
+	return max(l.Quantum, 1)
+}
+
+// MLFQ is multi-level feedback queue scheduling: every process arrives at the first (highest) level, and moves
+// down a level each time it uses up its allotment at one, so long-running processes sink below short and
+// interactive ones. A process that blocks for I/O before using up its allotment keeps its level and what is left
+// of the allotment, and rejoins the back of its level when the I/O completes, which is what keeps interactive
+// processes at the top. A higher level always preempts a lower one. Every Boost time units, when positive, all
+// processes move back to the first level with fresh allotments, so the ones at the bottom can't starve. A
+// suspended process rejoins the back of its level when it resumes.
+type MLFQ struct {
+	Levels []Level
+	Boost  int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the MLFQ schedule of processes.
+func (m MLFQ) Schedule(processes []Process) ScheduleResult {
+	return m.observe(processes, nil)
+}
+
+func (m MLFQ) observe(processes []Process, obs observers) ScheduleResult {
+	var (
+		levels    = m.Levels
+		level     = make(map[*task]int)   // the level of each process, the first until it's demoted
+		used      = make(map[*task]int64) // CPU time used at the current level
+		nextBoost = m.Boost
+		told      int64 // the boost the clock was told of
+	)
+	if len(levels) == 0 {
+		levels = []Level{{Discipline: FCFSQueue}}
+	}
+	last := len(levels) - 1
+	pol := policy{
+		less: func(a, b *task, _ int64) bool {
+			if la, lb := level[a], level[b]; la != lb {
+				return la < lb
+			}
+			return queueLess(levels[level[a]].Discipline, a, b)
+		},
+		preemptive: true,
+		quantumOf: func(t *task) int64 {
+			return queueQuantum(levels[level[t]].Discipline, levels[level[t]].Quantum)
+		},
+		limit: func(t *task) int64 {
+			if level[t] == last {
+				return -1
+			}
+			return levels[level[t]].allotment() - used[t]
+		},
+		// Using up its allotment demotes a process to the back of the next level. One that blocks for I/O first
+		// keeps its level.
+		ran: func(t *task, step int64) (bool, bool) {
+			used[t] += step
+			if level[t] == last || used[t] < levels[level[t]].allotment() {
+				return false, false
+			}
+			level[t]++
+			used[t] = 0
+			return true, true
+		},
+		tieBreak:  m.TieBreak,
+		observers: obs,
+	}
+	if m.Boost > 0 {
+		pol.tick = func(_ []*task, clock *Clock) bool {
+			now := clock.Now()
+			boosted := now >= nextBoost
+			if boosted {
+				for t := range level {
+					// Processes boosted from a lower level start a fresh quantum at the first.
+					t.used = 0
+					delete(level, t)
+				}
+				for t := range used {
+					delete(used, t)
+				}
+				for nextBoost <= now {
+					nextBoost += m.Boost
+				}
+			}
+			if told != nextBoost {
+				clock.At(nextBoost)
+				told = nextBoost
+			}
+			return boosted
+		}
+	}
+
+	return simulate(processes, pol)
+}
+
+// ParseLevels parses a comma separated list of feedback queue levels, highest first, each written as
+// <discipline>[:<quantum>[:<allotment>]], e.g. "rr:8,rr:16,fcfs" or "rr:2:6,rr:4:12,fcfs". The quantum can be left
+// empty to give just an allotment, e.g. "fcfs::10".
+func ParseLevels(spec string) ([]Level, error) {
+	levels := make([]Level, 0)
+	for _, def := range strings.Split(spec, ",") {
+		fields := strings.Split(strings.TrimSpace(def), ":")
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("%w: %q: expected discipline[:quantum[:allotment]]", ErrInvalidQueue, def)
+		}
+		l := Level{Discipline: strings.ToLower(fields[0])}
+		switch l.Discipline {
+		case FCFSQueue, RRQueue, SJFQueue, PriorityQueue:
+		default:
+			return nil, fmt.Errorf("%w: %q: unknown discipline %q", ErrInvalidQueue, def, l.Discipline)
+		}
+		var err error
+		if len(fields) > 1 && fields[1] != "" {
+			if l.Quantum, err = strconv.ParseInt(fields[1], 10, 64); err != nil || l.Quantum <= 0 {
+				return nil, fmt.Errorf("%w: %q: quantum must be a positive integer", ErrInvalidQueue, def)
+			}
+		}
+		if len(fields) > 2 {
+			if l.Allotment, err = strconv.ParseInt(fields[2], 10, 64); err != nil || l.Allotment <= 0 {
+				return nil, fmt.Errorf("%w: %q: allotment must be a positive integer", ErrInvalidQueue, def)
+			}
+		}
+		levels = append(levels, l)
+	}
+
+	return levels, nil
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMLFQ_Schedule(t *testing.T) {
+	t.Parallel()
+	sinking := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 6},
+	}
+	twoLevels := []Level{{Discipline: RRQueue, Quantum: 2}, {Discipline: FCFSQueue}}
+	tests := []struct {
+		name      string
+		mlfq      MLFQ
+		processes []Process
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "processes sink a level per allotment",
+			mlfq: MLFQ{Levels: []Level{
+				{Discipline: RRQueue, Quantum: 1},
+				{Discipline: RRQueue, Quantum: 2},
+				{Discipline: FCFSQueue},
+			}},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 4},
+				{PID: 2, Start: 4, Stop: 5},
+				{PID: 1, Start: 5, Stop: 7},
+			},
+		},
+		{
+			name: "allotments span turns, and higher levels preempt",
+			mlfq: MLFQ{Levels: []Level{{Discipline: RRQueue, Quantum: 1, Allotment: 3}, {Discipline: FCFSQueue}}},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 3},
+				{PID: 2, Start: 3, Stop: 4},
+				{PID: 1, Start: 4, Stop: 5},
+				{PID: 3, Start: 5, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8},
+			},
+		},
+		{
+			name: "blocking for I/O keeps the level",
+			mlfq: MLFQ{Levels: []Level{{Discipline: RRQueue, Quantum: 2, Allotment: 4}, {Discipline: FCFSQueue}}},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Bursts: []int64{1, 2, 1, 2, 1}},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 10},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 2, Start: 7, Stop: 13},
+			},
+		},
+		{
+			name:      "without boosts the bottom level runs FCFS",
+			mlfq:      MLFQ{Levels: twoLevels},
+			processes: sinking,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 8},
+				{PID: 2, Start: 8, Stop: 12},
+			},
+		},
+		{
+			name:      "boosts move every process back to the top",
+			mlfq:      MLFQ{Levels: twoLevels, Boost: 5},
+			processes: sinking,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 7},
+				{PID: 2, Start: 7, Stop: 9},
+				{PID: 1, Start: 9, Stop: 10},
+				{PID: 2, Start: 10, Stop: 12},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.mlfq.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestParseLevels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    []Level
+		wantErr error
+	}{
+		{
+			name: "success",
+			spec: "rr:2:6, RR:4, fcfs::10, sjf",
+			want: []Level{
+				{Discipline: RRQueue, Quantum: 2, Allotment: 6},
+				{Discipline: RRQueue, Quantum: 4},
+				{Discipline: FCFSQueue, Allotment: 10},
+				{Discipline: SJFQueue},
+			},
+		},
+		{name: "unknown discipline", spec: "rr:2,lifo", wantErr: ErrInvalidQueue},
+		{name: "bad quantum", spec: "rr:0", wantErr: ErrInvalidQueue},
+		{name: "bad allotment", spec: "rr:2:x", wantErr: ErrInvalidQueue},
+		{name: "too many fields", spec: "rr:2:4:8", wantErr: ErrInvalidQueue},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseLevels(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseLevels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLevels() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
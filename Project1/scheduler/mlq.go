@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Queue disciplines for the queues of a multi-level queue.
+const (
+	FCFSQueue     = "fcfs"
+	RRQueue       = "rr"
+	SJFQueue      = "sjf"
+	PriorityQueue = "priority"
+)
+
+var ErrInvalidQueue = errors.New("invalid queue")
+
+// Queue is one class of a multi-level queue.
+type Queue struct {
+	Name string
+	// MinPriority and MaxPriority are the (inclusive) range of process priorities assigned to the queue.
+	MinPriority int64
+	MaxPriority int64
+	// Discipline is how the queue picks its next process: FCFSQueue, RRQueue, SJFQueue or PriorityQueue.
+	// Only RRQueue preempts within the queue.
+	Discipline string
+	// Quantum is the RRQueue time slice; zero means one time unit.
+	Quantum int64
+	// Slice is the queue's CPU time per round when the MLQ is time-sliced.
+	Slice int64
+}
+
+// MLQ is multi-level queue scheduling: every process is assigned to the first queue whose priority range
+// contains its priority (or the last queue), and each queue schedules its own processes.
+// Between queues, arbitration is strict (a higher queue always preempts a lower one) unless TimeSliced,
+// where queues take turns for their Slice of CPU time, skipping queues with nothing ready. A process blocks for
+// the I/O between its CPU bursts, and it rejoins the back of its queue when the I/O completes. A suspended process
+// also rejoins the back of its queue when it resumes.
+type MLQ struct {
+	Queues     []Queue
+	TimeSliced bool
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the MLQ schedule of processes.
+func (m MLQ) Schedule(processes []Process) ScheduleResult {
+	return m.observe(processes, nil)
+}
+
+func (m MLQ) observe(processes []Process, obs observers) ScheduleResult {
+	var (
+		queues = m.Queues
+		turn   int   // queue whose turn it is when time-sliced
+		spent  int64 // CPU time the turn's queue has used
+	)
+	if len(queues) == 0 {
+		queues = []Queue{{Discipline: FCFSQueue}}
+	}
+	// rank orders the queues: highest first, or, when time-sliced, starting from the one whose turn it is.
+	rank := func(t *task) int {
+		q := m.classify(t.Process, len(queues))
+		if m.TimeSliced {
+			return (q - turn + len(queues)) % len(queues)
+		}
+		return q
+	}
+	pol := policy{
+		less: func(a, b *task, _ int64) bool {
+			if ra, rb := rank(a), rank(b); ra != rb {
+				return ra < rb
+			}
+			return queueLess(queues[m.classify(a.Process, len(queues))].Discipline, a, b)
+		},
+		preemptive: true,
+		quantumOf: func(t *task) int64 {
+			q := queues[m.classify(t.Process, len(queues))]
+			return queueQuantum(q.Discipline, q.Quantum)
+		},
+		tieBreak:  m.TieBreak,
+		observers: obs,
+	}
+	if m.TimeSliced {
+		// Skipping to a later queue, because the ones before it have nothing ready, starts its turn.
+		pol.assigned = func(t *task) {
+			if q := m.classify(t.Process, len(queues)); q != turn {
+				turn, spent = q, 0
+			}
+		}
+		pol.limit = func(*task) int64 {
+			return max(queues[turn].Slice, 1) - spent
+		}
+		pol.ran = func(_ *task, step int64) (bool, bool) {
+			if spent += step; spent < max(queues[turn].Slice, 1) {
+				return false, false
+			}
+			turn, spent = (turn+1)%len(queues), 0
+			return false, true
+		}
+	}
+
+	return simulate(processes, pol)
+}
+
+// classify returns the index of the queue a process belongs to.
+func (m MLQ) classify(p Process, queues int) int {
+	for i, q := range m.Queues {
+		if q.MinPriority <= p.Priority && p.Priority <= q.MaxPriority {
+			return i
+		}
+	}
+
+	return queues - 1
+}
+
+// queueQuantum returns the quantum of a queue with the given discipline and quantum: at least one for RRQueue, the
+// only discipline that preempts within a queue, and zero, unbounded, for the others.
+func queueQuantum(discipline string, quantum int64) int64 {
+	if discipline == RRQueue {
+		return max(quantum, 1)
+	}
+
+	return 0
+}
+
+// queueLess reports whether a goes before b in a queue with the given discipline, ties going by tie-break. The
+// process the queue was running when another queue took the CPU keeps its place at the head, as only RRQueue
+// preempts within a queue.
+func queueLess(discipline string, a, b *task) bool {
+	switch {
+	case (a.seq < 0) != (b.seq < 0):
+		// Only the running process is put back at the head of the queue.
+		return a.seq < 0
+	case discipline == SJFQueue:
+		return a.remaining < b.remaining
+	case discipline == PriorityQueue:
+		return a.Priority < b.Priority
+	default:
+		return a.seq < b.seq
+	}
+}
+
+// ParseQueues parses a comma separated list of queues, highest first, each written as
+// <name>:<min priority>-<max priority>:<discipline>[:<quantum>], e.g. "fg:1-10:rr:2,bg:11-50:fcfs".
+func ParseQueues(spec string) ([]Queue, error) {
+	queues := make([]Queue, 0)
+	for _, def := range strings.Split(spec, ",") {
+		fields := strings.Split(strings.TrimSpace(def), ":")
+		if len(fields) < 3 || len(fields) > 4 {
+			return nil, fmt.Errorf("%w: %q: expected name:min-max:discipline[:quantum]", ErrInvalidQueue, def)
+		}
+		q := Queue{Name: fields[0], Discipline: strings.ToLower(fields[2])}
+		lo, hi, ok := strings.Cut(fields[1], "-")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q: priority range must be min-max", ErrInvalidQueue, def)
+		}
+		var err error
+		if q.MinPriority, err = strconv.ParseInt(lo, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidQueue, def, err)
+		}
+		if q.MaxPriority, err = strconv.ParseInt(hi, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidQueue, def, err)
+		}
+		switch q.Discipline {
+		case FCFSQueue, RRQueue, SJFQueue, PriorityQueue:
+		default:
+			return nil, fmt.Errorf("%w: %q: unknown discipline %q", ErrInvalidQueue, def, q.Discipline)
+		}
+		if len(fields) == 4 {
+			if q.Quantum, err = strconv.ParseInt(fields[3], 10, 64); err != nil || q.Quantum <= 0 {
+				return nil, fmt.Errorf("%w: %q: quantum must be a positive integer", ErrInvalidQueue, def)
+			}
+		}
+		queues = append(queues, q)
+	}
+
+	return queues, nil
+}
+
+// ParseSlices sets the time slices of queues from a comma separated list, one per queue in order.
+func ParseSlices(queues []Queue, spec string) error {
+	slices := strings.Split(spec, ",")
+	if len(slices) != len(queues) {
+		return fmt.Errorf("%w: %d slices given for %d queues", ErrInvalidQueue, len(slices), len(queues))
+	}
+	for i, s := range slices {
+		slice, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil || slice <= 0 {
+			return fmt.Errorf("%w: slice %q must be a positive integer", ErrInvalidQueue, s)
+		}
+		queues[i].Slice = slice
+	}
+
+	return nil
+}
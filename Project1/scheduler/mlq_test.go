@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMLQ_Schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+	}
+	queues := []Queue{
+		{Name: "fg", MinPriority: 1, MaxPriority: 1, Discipline: RRQueue, Quantum: 2, Slice: 2},
+		{Name: "bg", MinPriority: 2, MaxPriority: 50, Discipline: FCFSQueue, Slice: 1},
+	}
+	tests := []struct {
+		name      string
+		mlq       MLQ
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "strict arbitration preempts the background queue",
+			mlq:  MLQ{Queues: queues},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 3, Start: 3, Stop: 5},
+				{PID: 2, Start: 5, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8},
+			},
+		},
+		{
+			name: "time-sliced arbitration shares the CPU",
+			mlq:  MLQ{Queues: queues, TimeSliced: true},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 2, Start: 7, Stop: 8},
+			},
+		},
+		{
+			name: "unmatched priorities fall into the last queue",
+			mlq: MLQ{Queues: []Queue{
+				{MinPriority: 1, MaxPriority: 1, Discipline: FCFSQueue},
+				{MinPriority: 5, MaxPriority: 5, Discipline: SJFQueue},
+			}},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.mlq.Schedule(processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
+
+func TestMLQ_Schedule_io(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2, Priority: 1, Bursts: []int64{1, 3, 1}},
+		{ProcessID: 2, BurstDuration: 4, Priority: 2},
+	}
+	mlq := MLQ{Queues: []Queue{
+		{Name: "fg", MinPriority: 1, MaxPriority: 1, Discipline: RRQueue, Quantum: 2},
+		{Name: "bg", MinPriority: 2, MaxPriority: 50, Discipline: FCFSQueue},
+	}}
+	got := mlq.Schedule(processes)
+	// Process 1 leaves the CPU to the background queue for its I/O, then preempts it when the I/O completes.
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 4},
+		{PID: 1, Start: 4, Stop: 5},
+		{PID: 2, Start: 5, Stop: 6},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	waits := map[int64]int64{}
+	for _, r := range got.Processes {
+		waits[r.ProcessID] = r.Wait
+	}
+	if want := map[int64]int64{1: 0, 2: 2}; !reflect.DeepEqual(waits, want) {
+		t.Errorf("Schedule() waits = %v, want %v", waits, want)
+	}
+	if err := got.Check(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseQueues(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    []Queue
+		wantErr error
+	}{
+		{
+			name: "success",
+			spec: "fg:1-10:rr:2, bg:11-50:FCFS",
+			want: []Queue{
+				{Name: "fg", MinPriority: 1, MaxPriority: 10, Discipline: RRQueue, Quantum: 2},
+				{Name: "bg", MinPriority: 11, MaxPriority: 50, Discipline: FCFSQueue},
+			},
+		},
+		{name: "missing discipline", spec: "fg:1-10", wantErr: ErrInvalidQueue},
+		{name: "bad range", spec: "fg:1:rr", wantErr: ErrInvalidQueue},
+		{name: "unknown discipline", spec: "fg:1-10:lifo", wantErr: ErrInvalidQueue},
+		{name: "bad quantum", spec: "fg:1-10:rr:0", wantErr: ErrInvalidQueue},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseQueues(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseQueues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQueues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSlices(t *testing.T) {
+	t.Parallel()
+	queues := []Queue{{Name: "fg"}, {Name: "bg"}}
+	if err := ParseSlices(queues, "8, 2"); err != nil {
+		t.Fatalf("ParseSlices() unexpected error: %v", err)
+	}
+	if queues[0].Slice != 8 || queues[1].Slice != 2 {
+		t.Errorf("ParseSlices() = %+v, want slices 8 and 2", queues)
+	}
+	if err := ParseSlices(queues, "8"); !errors.Is(err, ErrInvalidQueue) {
+		t.Errorf("ParseSlices() error = %v, wantErr %v", err, ErrInvalidQueue)
+	}
+}
@@ -0,0 +1,237 @@
+package scheduler
+
+import "sort"
+
+// EventKind is what happened to a process in an Event. Kinds are ordered as Events orders events at the same time:
+// processes leave their CPUs before arrivals are queued and the CPUs are dispatched again.
+type EventKind int
+
+const (
+	EventComplete EventKind = iota
+	EventBlock
+	EventPreempt
+	EventArrive
+	EventDispatch
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventComplete:
+		return "complete"
+	case EventBlock:
+		return "block"
+	case EventPreempt:
+		return "preempt"
+	case EventArrive:
+		return "arrive"
+	default:
+		return "dispatch"
+	}
+}
+
+// Event is a change in a process's state during a schedule. CPU is -1 for arrivals, which happen off the CPUs.
+type Event struct {
+	Time int64
+	CPU  int
+	PID  int64
+	Kind EventKind
+}
+
+// Observer is notified of the events of a schedule, in the order they happen. A process taken off its CPU is
+// preempted unless it completes or blocks on the I/O after one of its CPU bursts; a suspended process is preempted.
+type Observer interface {
+	OnArrive(e Event)
+	OnDispatch(e Event)
+	OnPreempt(e Event)
+	OnBlock(e Event)
+	OnComplete(e Event)
+}
+
+// Hooks is an Observer that calls the functions it's given, skipping the nil ones, e.g.
+//
+//	scheduler.Hooks{Complete: func(e scheduler.Event) { fmt.Println(e.PID, "done at", e.Time) }}
+type Hooks struct {
+	Arrive, Dispatch, Preempt, Block, Complete func(e Event)
+}
+
+func (h Hooks) OnArrive(e Event)   { call(h.Arrive, e) }
+func (h Hooks) OnDispatch(e Event) { call(h.Dispatch, e) }
+func (h Hooks) OnPreempt(e Event)  { call(h.Preempt, e) }
+func (h Hooks) OnBlock(e Event)    { call(h.Block, e) }
+func (h Hooks) OnComplete(e Event) { call(h.Complete, e) }
+
+func call(hook func(Event), e Event) {
+	if hook != nil {
+		hook(e)
+	}
+}
+
+// Observe returns a Scheduler that schedules with s and notifies the observers of the schedule's events, so any
+// scheduler, built-in or registered, can be logged, visualized or graded without changing it. The built-in
+// schedulers notify them during the simulation, as each event happens; others once they've finished, of the
+// events their result's Events works out.
+func Observe(s Scheduler, observers ...Observer) Scheduler {
+	return observed{Scheduler: s, observers: observers}
+}
+
+type observed struct {
+	Scheduler
+	observers observers
+}
+
+func (o observed) Schedule(processes []Process) ScheduleResult {
+	return schedule(o.Scheduler, processes, o.observers)
+}
+
+func (o observed) observe(processes []Process, obs observers) ScheduleResult {
+	return schedule(o.Scheduler, processes, append(o.observers[:len(o.observers):len(o.observers)], obs...))
+}
+
+// observable is a Scheduler that notifies observers of its events as its simulation makes them.
+type observable interface {
+	observe(processes []Process, obs observers) ScheduleResult
+}
+
+// schedule schedules processes with s, notifying obs of the events as they happen if s is observable, or else
+// once the schedule is finished.
+func schedule(s Scheduler, processes []Process, obs observers) ScheduleResult {
+	if o, ok := s.(observable); ok {
+		return o.observe(processes, obs)
+	}
+	result := s.Schedule(processes)
+	result.Notify(obs...)
+
+	return result
+}
+
+// observers are the observers of a simulation.
+type observers []Observer
+
+// notify notifies each observer of e, in order.
+func (obs observers) notify(e Event) {
+	for _, o := range obs {
+		switch e.Kind {
+		case EventArrive:
+			o.OnArrive(e)
+		case EventDispatch:
+			o.OnDispatch(e)
+		case EventPreempt:
+			o.OnPreempt(e)
+		case EventBlock:
+			o.OnBlock(e)
+		case EventComplete:
+			o.OnComplete(e)
+		}
+	}
+}
+
+// arrive notifies the observers that t has arrived.
+func (obs observers) arrive(t *task) {
+	obs.notify(Event{Time: t.ArrivalTime, CPU: -1, PID: t.ProcessID, Kind: EventArrive})
+}
+
+// start notifies the observers that c.t is dispatched at now, unless they know it already, and that the process
+// they were told is on c, if another, was preempted.
+func (c *core) start(obs observers, now int64) {
+	if c.shown == c.t {
+		return
+	}
+	c.preempt(obs, now)
+	obs.notify(Event{Time: now, CPU: c.id, PID: c.t.ProcessID, Kind: EventDispatch})
+	c.shown = c.t
+}
+
+// leave notifies the observers that the process they were told is on c, if any, left it at now for the reason
+// kind.
+func (c *core) leave(obs observers, kind EventKind, now int64) {
+	if c.shown != nil {
+		obs.notify(Event{Time: now, CPU: c.id, PID: c.shown.ProcessID, Kind: kind})
+		c.shown = nil
+	}
+}
+
+// preempt notifies the observers that the process they were told is on c, if any, was preempted at now.
+func (c *core) preempt(obs observers, now int64) {
+	c.leave(obs, EventPreempt, now)
+}
+
+// Notify notifies the observers of the events Events works out from the schedule, in order. Each event goes to
+// every observer, in order, before the next.
+func (r ScheduleResult) Notify(obs ...Observer) {
+	for _, e := range r.Events() {
+		observers(obs).notify(e)
+	}
+}
+
+// Events returns the arrivals of the schedule's processes and their dispatches, preemptions, blocking on I/O and
+// completions, from the GANTT chart, ordered by time, then kind and then CPU. A process runs from a dispatch until
+// it next leaves its CPU; one that moves straight to another CPU is preempted on the first and dispatched on the
+// second. A context switch to a process comes before its dispatch. The chart can't tell a process preempted and
+// dispatched again at once from one that kept its CPU, nor, when they overlap, periodic jobs of one task apart;
+// observers of a built-in scheduler are notified of the events as the simulation makes them instead.
+func (r ScheduleResult) Events() []Event {
+	var (
+		slices = make(map[int64][]TimeSlice) // each process's slices, context switches included, in order
+		runs   = make(map[int64][]TimeSlice) // each process's slices on the CPU
+	)
+	for _, s := range r.Gantt {
+		slices[s.PID] = append(slices[s.PID], s)
+		if !s.Switch {
+			runs[s.PID] = append(runs[s.PID], s)
+		}
+	}
+	events := make([]Event, 0, 2*len(r.Gantt)+len(r.Processes))
+	for _, row := range r.Processes {
+		events = append(events, Event{Time: row.ArrivalTime, CPU: -1, PID: row.ProcessID, Kind: EventArrive})
+		blocks := make(map[int64]bool)
+		for _, b := range blockedOnIO(row, runs[row.ProcessID]) {
+			blocks[b.Start] = true
+		}
+		var (
+			dispatch *Event
+			stop     int64
+		)
+		leave := func() {
+			if dispatch == nil {
+				return
+			}
+			e := Event{Time: stop, CPU: dispatch.CPU, PID: row.ProcessID, Kind: EventPreempt}
+			switch {
+			case stop >= row.Completion:
+				e.Kind = EventComplete
+			case blocks[stop]:
+				e.Kind = EventBlock
+			}
+			events = append(events, *dispatch, e)
+			dispatch = nil
+		}
+		for _, s := range slices[row.ProcessID] {
+			if s.Stop <= row.ArrivalTime || s.Start >= row.Completion {
+				continue
+			}
+			if s.Switch || dispatch != nil && (s.Start != stop || s.CPU != dispatch.CPU) {
+				leave()
+			}
+			if s.Switch {
+				continue
+			}
+			if dispatch == nil {
+				dispatch = &Event{Time: s.Start, CPU: s.CPU, PID: row.ProcessID, Kind: EventDispatch}
+			}
+			stop = s.Stop
+		}
+		leave()
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.CPU < b.CPU
+	})
+
+	return events
+}
@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestScheduleResult_Events(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		result ScheduleResult
+		want   []Event
+	}{
+		{
+			name: "preempted",
+			result: RoundRobin{Quantum: 1}.Schedule([]Process{
+				{ProcessID: 1, BurstDuration: 2},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+			}),
+			want: []Event{
+				{Time: 0, CPU: -1, PID: 1, Kind: EventArrive},
+				{Time: 0, CPU: 0, PID: 1, Kind: EventDispatch},
+				{Time: 1, CPU: 0, PID: 1, Kind: EventPreempt},
+				{Time: 1, CPU: -1, PID: 2, Kind: EventArrive},
+				{Time: 1, CPU: 0, PID: 2, Kind: EventDispatch},
+				{Time: 2, CPU: 0, PID: 2, Kind: EventComplete},
+				{Time: 2, CPU: 0, PID: 1, Kind: EventDispatch},
+				{Time: 3, CPU: 0, PID: 1, Kind: EventComplete},
+			},
+		},
+		{
+			name: "blocked on I/O on two CPUs",
+			result: FCFS{Cores: 2}.Schedule([]Process{
+				{ProcessID: 1, BurstDuration: 3, Bursts: []int64{2, 2, 1}},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+			}),
+			want: []Event{
+				{Time: 0, CPU: -1, PID: 1, Kind: EventArrive},
+				{Time: 0, CPU: 0, PID: 1, Kind: EventDispatch},
+				{Time: 1, CPU: -1, PID: 2, Kind: EventArrive},
+				{Time: 1, CPU: 1, PID: 2, Kind: EventDispatch},
+				{Time: 2, CPU: 1, PID: 2, Kind: EventComplete},
+				{Time: 2, CPU: 0, PID: 1, Kind: EventBlock},
+				{Time: 4, CPU: 0, PID: 1, Kind: EventDispatch},
+				{Time: 5, CPU: 0, PID: 1, Kind: EventComplete},
+			},
+		},
+		{
+			name: "migrated straight to another CPU",
+			result: ScheduleResult{
+				Gantt: []TimeSlice{
+					{PID: 1, Start: 0, Stop: 2},
+					{PID: 1, Start: 2, Stop: 3, CPU: 1},
+				},
+				Processes: []ProcessResult{
+					{Process: Process{ProcessID: 1, BurstDuration: 3}, Turnaround: 3, Completion: 3},
+				},
+			},
+			want: []Event{
+				{Time: 0, CPU: -1, PID: 1, Kind: EventArrive},
+				{Time: 0, CPU: 0, PID: 1, Kind: EventDispatch},
+				{Time: 2, CPU: 0, PID: 1, Kind: EventPreempt},
+				{Time: 2, CPU: 1, PID: 1, Kind: EventDispatch},
+				{Time: 3, CPU: 1, PID: 1, Kind: EventComplete},
+			},
+		},
+		{
+			name: "dispatched after a context switch",
+			result: FCFS{SwitchCost: 1}.Schedule([]Process{
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 2, BurstDuration: 1},
+			}),
+			want: []Event{
+				{Time: 0, CPU: -1, PID: 1, Kind: EventArrive},
+				{Time: 0, CPU: -1, PID: 2, Kind: EventArrive},
+				{Time: 0, CPU: 0, PID: 1, Kind: EventDispatch},
+				{Time: 1, CPU: 0, PID: 1, Kind: EventComplete},
+				{Time: 2, CPU: 0, PID: 2, Kind: EventDispatch},
+				{Time: 3, CPU: 0, PID: 2, Kind: EventComplete},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.result.Events(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Events() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// recorder records the events it's notified of, as "kind pid@time".
+type recorder []string
+
+func (r *recorder) record(e Event)     { *r = append(*r, fmt.Sprintf("%v %d@%d", e.Kind, e.PID, e.Time)) }
+func (r *recorder) OnArrive(e Event)   { r.record(e) }
+func (r *recorder) OnDispatch(e Event) { r.record(e) }
+func (r *recorder) OnPreempt(e Event)  { r.record(e) }
+func (r *recorder) OnBlock(e Event)    { r.record(e) }
+func (r *recorder) OnComplete(e Event) { r.record(e) }
+
+func TestObserve(t *testing.T) {
+	t.Parallel()
+	var (
+		events    recorder
+		completed []int64
+		hooks     = Hooks{Complete: func(e Event) { completed = append(completed, e.PID) }}
+		processes = []Process{
+			{ProcessID: 1, BurstDuration: 3},
+			{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+		}
+	)
+	got := Observe(SRTF{}, &events, hooks).Schedule(processes)
+	if want := (SRTF{}).Schedule(processes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Schedule() = %+v, want the observed scheduler's %+v", got, want)
+	}
+	want := recorder{
+		"arrive 1@0", "dispatch 1@0", "arrive 2@1", "preempt 1@1", "dispatch 2@1", "complete 2@2", "dispatch 1@2",
+		"complete 1@4",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Observe() notified %q, want %q", events, want)
+	}
+	if !reflect.DeepEqual(completed, []int64{2, 1}) {
+		t.Errorf("Observe() called the completion hook for %v, want [2 1]", completed)
+	}
+}
+
+func TestObserve_live(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		processes []Process
+		want      recorder
+	}{
+		{
+			name:      "preempted where its slices meet",
+			scheduler: RoundRobin{Quantum: 1},
+			processes: []Process{{ProcessID: 1, BurstDuration: 2}},
+			want:      recorder{"arrive 1@0", "dispatch 1@0", "preempt 1@1", "dispatch 1@1", "complete 1@2"},
+		},
+		{
+			name:      "periodic jobs of one task",
+			scheduler: RateMonotonic{},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 2},
+				{ProcessID: 2, BurstDuration: 2, Period: 4},
+			},
+			want: recorder{
+				"arrive 1@0", "arrive 2@0", "dispatch 1@0", "complete 1@1", "dispatch 2@1", "arrive 1@2", "preempt 2@2",
+				"dispatch 1@2", "complete 1@3", "dispatch 2@3", "complete 2@4",
+			},
+		},
+		{
+			name:      "context switch before the dispatch",
+			scheduler: SRTF{SwitchCost: 1},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+			},
+			want: recorder{
+				"arrive 1@0", "dispatch 1@0", "arrive 2@1", "preempt 1@1", "dispatch 2@2", "complete 2@3", "dispatch 1@4",
+				"complete 1@6",
+			},
+		},
+		{
+			name:      "multi-level queue",
+			scheduler: MLQ{Queues: []Queue{{MaxPriority: 1, Discipline: RRQueue, Quantum: 2}, {MaxPriority: 9, Discipline: FCFSQueue}}},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, Priority: 5},
+				{ProcessID: 2, BurstDuration: 3, Priority: 1, ArrivalTime: 1},
+			},
+			want: recorder{
+				"arrive 1@0", "dispatch 1@0", "arrive 2@1", "preempt 1@1", "dispatch 2@1", "preempt 2@3", "dispatch 2@3",
+				"complete 2@4", "dispatch 1@4", "complete 1@6",
+			},
+		},
+		{
+			name:      "cgroups",
+			scheduler: CgroupRoundRobin{},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2},
+				{ProcessID: 2, BurstDuration: 2},
+			},
+			want: recorder{
+				"arrive 1@0", "arrive 2@0", "dispatch 1@0", "preempt 1@1", "dispatch 2@1", "preempt 2@2", "dispatch 1@2",
+				"complete 1@3", "dispatch 2@3", "complete 2@4",
+			},
+		},
+		{
+			name:      "gang member blocked on its CPU",
+			scheduler: Gang{Cores: 2},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 1, 1}, Gang: 7},
+				{ProcessID: 2, BurstDuration: 3, Gang: 7},
+			},
+			want: recorder{
+				"arrive 1@0", "arrive 2@0", "dispatch 1@0", "dispatch 2@0", "block 1@1", "dispatch 1@2", "complete 1@3",
+				"complete 2@3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var events recorder
+			got := Observe(tt.scheduler, &events).Schedule(tt.processes)
+			if want := tt.scheduler.Schedule(tt.processes); !reflect.DeepEqual(got, want) {
+				t.Errorf("Schedule() = %+v, want the observed scheduler's %+v", got, want)
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("Observe() notified %q, want %q", events, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import "math"
+
+// defaultInitialPrediction is the textbook's prediction of a process's first CPU burst, τ₀.
+const defaultInitialPrediction = 10
+
+// Prediction is a CPU burst predicted by exponential averaging, next to the burst it turned out to be.
+type Prediction struct {
+	PID int64
+	// Burst numbers the process's CPU bursts from 1.
+	Burst     int
+	Predicted float64
+	Actual    int64
+}
+
+// PredictiveSJF is Shortest Job First without knowing the bursts ahead of time, as a real scheduler must: the
+// ready process with the shortest predicted next CPU burst runs next, to the end of that burst. Each process's
+// first burst is predicted to be Initial, and each later one by exponential averaging over the bursts it has
+// run, τₙ₊₁ = α·tₙ + (1−α)·τₙ. The result lists every prediction with the actual burst, and their mean absolute
+// error is in its stats.
+type PredictiveSJF struct {
+	// Alpha, from 0 to 1, weighs the last burst against the previous prediction. At 0 the first prediction
+	// stands forever, and at 1 the next burst is predicted to be the same as the last.
+	Alpha float64
+	// Initial is the prediction of each process's first burst, τ₀; zero means 10, the textbook's.
+	Initial float64
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the predictive SJF schedule of processes.
+func (s PredictiveSJF) Schedule(processes []Process) ScheduleResult {
+	return s.observe(processes, nil)
+}
+
+func (s PredictiveSJF) observe(processes []Process, obs observers) ScheduleResult {
+	result := simulate(processes, policy{
+		less: func(a, b *task, _ int64) bool {
+			return s.predict(a.Process, a.burst/2) < s.predict(b.Process, b.burst/2)
+		},
+		fixedKeys:  true,
+		cores:      s.Cores,
+		switchCost: s.SwitchCost,
+		tieBreak:   s.TieBreak,
+		observers:  obs,
+	})
+
+	var totalError float64
+	for _, row := range result.Processes {
+		bursts := row.Bursts
+		if len(bursts) == 0 {
+			bursts = []int64{row.BurstDuration}
+		}
+		for i := 0; i < len(bursts); i += 2 {
+			p := Prediction{PID: row.ProcessID, Burst: i/2 + 1, Predicted: s.predict(row.Process, i/2), Actual: bursts[i]}
+			result.Predictions = append(result.Predictions, p)
+			totalError += math.Abs(p.Predicted - float64(p.Actual))
+		}
+	}
+	if len(result.Predictions) > 0 {
+		result.Stats.PredictionError = totalError / float64(len(result.Predictions))
+	}
+
+	return result
+}
+
+// predict returns the prediction of p's n-th CPU burst, counting from 0, from the bursts before it.
+func (s PredictiveSJF) predict(p Process, n int) float64 {
+	tau := s.Initial
+	if tau <= 0 {
+		tau = defaultInitialPrediction
+	}
+	for i := 0; i < n; i++ {
+		tau = s.Alpha*float64(p.Bursts[2*i]) + (1-s.Alpha)*tau
+	}
+
+	return tau
+}
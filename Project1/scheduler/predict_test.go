@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPredictiveSJF_Schedule(t *testing.T) {
+	t.Parallel()
+	// Process 1 looks short after its first burst, but its next one is the longest.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10, Bursts: []int64{2, 1, 8}},
+		{ProcessID: 2, BurstDuration: 6},
+		{ProcessID: 3, BurstDuration: 5},
+	}
+	got := PredictiveSJF{Alpha: 1}.Schedule(processes)
+
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 8},
+		{PID: 1, Start: 8, Stop: 16},
+		{PID: 3, Start: 16, Stop: 21},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	wantPredictions := []Prediction{
+		{PID: 2, Burst: 1, Predicted: 10, Actual: 6},
+		{PID: 1, Burst: 1, Predicted: 10, Actual: 2},
+		{PID: 1, Burst: 2, Predicted: 2, Actual: 8},
+		{PID: 3, Burst: 1, Predicted: 10, Actual: 5},
+	}
+	if !reflect.DeepEqual(got.Predictions, wantPredictions) {
+		t.Errorf("Schedule() predictions = %+v, want %+v", got.Predictions, wantPredictions)
+	}
+	if want := 23.0 / 4; got.Stats.PredictionError != want {
+		t.Errorf("Schedule() prediction error = %v, want %v", got.Stats.PredictionError, want)
+	}
+	if err := got.Check(); err != nil {
+		t.Error(err)
+	}
+
+	// SJF, knowing the bursts, runs process 3 before process 2 and process 1's long burst last.
+	if sjf := (SJF{}).Schedule(processes); reflect.DeepEqual(sjf.Gantt, got.Gantt) {
+		t.Errorf("SJF schedule = %v, the same as predictive SJF's", sjf.Gantt)
+	}
+}
+
+func TestPredictiveSJF_predict(t *testing.T) {
+	t.Parallel()
+	// The textbook example: τ₀ = 10 and α = ½ over bursts of 6, 4, 6, 4, 13, 13 and 13.
+	p := Process{ProcessID: 1, BurstDuration: 59, Bursts: []int64{6, 1, 4, 1, 6, 1, 4, 1, 13, 1, 13, 1, 13}}
+	got := PredictiveSJF{Alpha: 0.5, Initial: 10}.Schedule([]Process{p}).Predictions
+	want := []float64{10, 8, 6, 6, 5, 9, 11}
+	if len(got) != len(want) {
+		t.Fatalf("Schedule() made %d predictions, want %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if p.Burst != i+1 || p.Predicted != want[i] {
+			t.Errorf("prediction %d = burst %d predicted %v, want burst %d predicted %v", i, p.Burst, p.Predicted, i+1, want[i])
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+// SJFPriority is preemptive Shortest Job First Priority scheduling: the ready process with the highest
+// priority (lowest number) runs, and an arriving process with a higher priority preempts it.
+// Processes with equal priority are ordered by shortest remaining burst.
+type SJFPriority struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the SJF Priority schedule of processes.
+func (s SJFPriority) Schedule(processes []Process) ScheduleResult {
+	return s.observe(processes, nil)
+}
+
+func (s SJFPriority) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{
+		less:       highestPriority,
+		fixedKeys:  true,
+		preemptive: true,
+		cores:      s.Cores,
+		switchCost: s.SwitchCost,
+		tieBreak:   s.TieBreak,
+		observers:  obs,
+	})
+}
+
+// highestPriority orders tasks by priority, and then by shortest remaining burst.
+func highestPriority(a, b *task, now int64) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	return shortestRemaining(a, b, now)
+}
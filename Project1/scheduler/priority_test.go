@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSJFPriority_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      ScheduleResult
+	}{
+		{
+			name: "higher priority arrival preempts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+			},
+			want: ScheduleResult{
+				Gantt: []TimeSlice{
+					{PID: 1, Start: 0, Stop: 3},
+					{PID: 2, Start: 3, Stop: 12},
+					{PID: 1, Start: 12, Stop: 14},
+					{PID: 3, Start: 14, Stop: 20},
+				},
+				Processes: []ProcessResult{
+					{Process: Process{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1}, Wait: 0, Turnaround: 9, Completion: 12},
+					{Process: Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2}, Wait: 9, Turnaround: 14, Completion: 14},
+					{Process: Process{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3}, Wait: 8, Response: 8, Turnaround: 14, Completion: 20},
+				},
+				Stats: Stats{
+					AveWait:       17.0 / 3,
+					AveResponse:   8.0 / 3,
+					AveTurnaround: 37.0 / 3,
+					AveThroughput: 3.0 / 20,
+					Fairness:      jain(1, 14.0/5, 14.0/6),
+					Makespan:      20,
+					Utilization:   1,
+				},
+			},
+		},
+		{
+			name: "equal priority prefers the shortest remaining burst",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 5, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+			},
+			want: ScheduleResult{
+				Gantt: []TimeSlice{
+					{PID: 1, Start: 0, Stop: 2},
+					{PID: 3, Start: 2, Stop: 5},
+					{PID: 2, Start: 5, Stop: 10},
+				},
+				Processes: []ProcessResult{
+					{Process: Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1}, Wait: 0, Turnaround: 2, Completion: 2},
+					{Process: Process{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3, Priority: 2}, Wait: 1, Response: 1, Turnaround: 4, Completion: 5},
+					{Process: Process{ProcessID: 2, ArrivalTime: 1, BurstDuration: 5, Priority: 2}, Wait: 4, Response: 4, Turnaround: 9, Completion: 10},
+				},
+				Stats: Stats{
+					AveWait:       5.0 / 3,
+					AveResponse:   5.0 / 3,
+					AveTurnaround: 15.0 / 3,
+					AveThroughput: 3.0 / 10,
+					Fairness:      jain(1, 4.0/3, 9.0/5),
+					Makespan:      10,
+					Utilization:   1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (SJFPriority{}).Schedule(tt.processes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Schedule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
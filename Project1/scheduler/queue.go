@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// readyQueue holds the tasks that are ready to run. Tasks are kept in queue order and scanned for the next
+// one to dispatch, or, for policies with fixed keys, kept as a heap ordered by the policy and then as
+// pick breaks ties, so large workloads don't pay for a scan of the whole queue on every dispatch.
+type readyQueue struct {
+	pol   policy
+	tasks []*task
+	// front and back are the sequence numbers last given to a task queued at the front and back.
+	front, back int64
+}
+
+func newReadyQueue(pol policy, capacity int) *readyQueue {
+	return &readyQueue{pol: pol, tasks: make([]*task, 0, capacity)}
+}
+
+// heaped reports whether the queue is kept as a heap.
+func (q *readyQueue) heaped() bool {
+	return q.pol.fixedKeys && q.pol.less != nil && q.pol.choose == nil
+}
+
+func (q *readyQueue) Len() int {
+	return len(q.tasks)
+}
+
+// pushBack queues t behind every ready task.
+func (q *readyQueue) pushBack(t *task) {
+	q.back++
+	t.seq = q.back
+	if q.heaped() {
+		heap.Push((*taskHeap)(q), t)
+		return
+	}
+	q.tasks = append(q.tasks, t)
+}
+
+// pushFront queues ts, in order, ahead of every ready task.
+func (q *readyQueue) pushFront(ts []*task) {
+	for i := len(ts) - 1; i >= 0; i-- {
+		q.front--
+		ts[i].seq = q.front
+	}
+	if q.heaped() {
+		for _, t := range ts {
+			heap.Push((*taskHeap)(q), t)
+		}
+		return
+	}
+	q.tasks = append(append(make([]*task, 0, len(ts)+len(q.tasks)), ts...), q.tasks...)
+}
+
+// requeue puts back tasks popped but not dispatched, in their old places in the queue.
+func (q *readyQueue) requeue(ts []*task) {
+	for _, t := range ts {
+		if q.heaped() {
+			heap.Push((*taskHeap)(q), t)
+			continue
+		}
+		// The queue is in sequence order, since tasks are only added at its ends.
+		i := sort.Search(len(q.tasks), func(i int) bool {
+			return q.tasks[i].seq > t.seq
+		})
+		q.tasks = append(q.tasks[:i], append([]*task{t}, q.tasks[i:]...)...)
+	}
+}
+
+// remove takes the tasks drop reports true for out of the queue.
+func (q *readyQueue) remove(drop func(t *task) bool) {
+	kept := q.tasks[:0]
+	for _, t := range q.tasks {
+		if !drop(t) {
+			kept = append(kept, t)
+		}
+	}
+	for i := len(kept); i < len(q.tasks); i++ {
+		q.tasks[i] = nil
+	}
+	q.tasks = kept
+	if q.heaped() {
+		heap.Init((*taskHeap)(q))
+	}
+}
+
+// pop removes and returns the task to dispatch at the clock's time.
+func (q *readyQueue) pop(clock *Clock) *task {
+	if q.heaped() {
+		return heap.Pop((*taskHeap)(q)).(*task)
+	}
+	i := q.pol.pick(q.tasks, clock)
+	t := q.tasks[i]
+	q.tasks = append(q.tasks[:i], q.tasks[i+1:]...)
+
+	return t
+}
+
+// taskHeap is a ready queue as a heap.Interface.
+type taskHeap readyQueue
+
+func (h *taskHeap) Len() int {
+	return len(h.tasks)
+}
+
+func (h *taskHeap) Less(i, j int) bool {
+	a, b := h.tasks[i], h.tasks[j]
+	switch {
+	case h.pol.less(a, b, 0):
+		return true
+	case h.pol.less(b, a, 0):
+		return false
+	}
+	return h.pol.tied(a, b)
+}
+
+func (h *taskHeap) Swap(i, j int) {
+	h.tasks[i], h.tasks[j] = h.tasks[j], h.tasks[i]
+}
+
+func (h *taskHeap) Push(x any) {
+	h.tasks = append(h.tasks, x.(*task))
+}
+
+func (h *taskHeap) Pop() any {
+	n := len(h.tasks)
+	t := h.tasks[n-1]
+	h.tasks[n-1] = nil
+	h.tasks = h.tasks[:n-1]
+
+	return t
+}
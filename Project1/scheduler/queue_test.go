@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// heapPolicies are the policies whose ready queues are heaps.
+var heapPolicies = []struct {
+	name string
+	pol  policy
+}{
+	{name: "SJF", pol: policy{less: shortestBurst, fixedKeys: true}},
+	{name: "SRTF", pol: policy{less: shortestRemaining, fixedKeys: true, preemptive: true}},
+	{name: "SJF Priority", pol: policy{less: highestPriority, fixedKeys: true, preemptive: true}},
+}
+
+// randomWorkload returns n processes arriving about every other time unit, with priorities from 1 to 5 so
+// many tie, and I/O between the CPU bursts of every third.
+func randomWorkload(n int, seed int64) []Process {
+	rng := rand.New(rand.NewSource(seed))
+	processes := make([]Process, n)
+	var arrival int64
+	for i := range processes {
+		arrival += rng.Int63n(4)
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   arrival,
+			BurstDuration: 1 + rng.Int63n(10),
+			Priority:      1 + rng.Int63n(5),
+		}
+		if i%3 == 0 {
+			first := 1 + rng.Int63n(processes[i].BurstDuration)
+			processes[i].Bursts = []int64{first, 1 + rng.Int63n(5), processes[i].BurstDuration - first}
+			if first == processes[i].BurstDuration {
+				processes[i].Bursts = nil
+			}
+		}
+	}
+
+	return processes
+}
+
+// pinned returns processes with every fourth pinned to one of cores CPUs and every fourth after it to two.
+func pinned(processes []Process, cores int) []Process {
+	for i := range processes {
+		switch i % 4 {
+		case 0:
+			processes[i].Affinity = []int{i % cores}
+		case 1:
+			processes[i].Affinity = []int{i % cores, (i + 1) % cores}
+		}
+	}
+
+	return processes
+}
+
+// ganged returns processes in gangs of up to cores members, each gang of processes listed together.
+func ganged(processes []Process, cores int) []Process {
+	for i := range processes {
+		processes[i].Gang = int64(i/cores + 1)
+	}
+
+	return processes
+}
+
+// suspending returns processes with every fifth suspended twice, once around its arrival and once later on.
+func suspending(processes []Process) []Process {
+	for i := range processes {
+		if i%5 == 0 {
+			at := processes[i].ArrivalTime
+			processes[i].Suspensions = []Suspension{{At: at - 1, For: 3}, {At: at + 4 + int64(i%7), For: 1 + int64(i%4)}}
+		}
+	}
+
+	return processes
+}
+
+func TestReadyQueue_heapMatchesScan(t *testing.T) {
+	t.Parallel()
+	for _, tt := range heapPolicies {
+		tt := tt
+		for _, cores := range []int{1, 3} {
+			for _, tb := range []TieBreak{TieFIFO, TieArrival} {
+				cores, tb := cores, tb
+				t.Run(fmt.Sprintf("%s on %d CPUs, %v ties", tt.name, cores, tb), func(t *testing.T) {
+					t.Parallel()
+					processes := pinned(randomWorkload(500, int64(cores)), cores)
+					heaped := tt.pol
+					heaped.cores, heaped.switchCost, heaped.tieBreak = cores, 1, tb
+					scanned := heaped
+					scanned.fixedKeys = false
+
+					got, want := simulate(processes, heaped), simulate(processes, scanned)
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("heap schedule differs from scanned schedule:\n%+v\nwant\n%+v", got.Stats, want.Stats)
+					}
+				})
+			}
+		}
+	}
+}
+
+// BenchmarkReadyQueue compares dispatching from a heap with scanning the ready queue, on workloads whose ready
+// queues grow with their size.
+func BenchmarkReadyQueue(b *testing.B) {
+	for _, tt := range heapPolicies {
+		for _, n := range []int{1000, 10000} {
+			processes := randomWorkload(n, 1)
+			for _, fixedKeys := range []bool{false, true} {
+				pol := tt.pol
+				pol.fixedKeys = fixedKeys
+				queue := "scan"
+				if fixedKeys {
+					queue = "heap"
+				}
+				b.Run(fmt.Sprintf("%s/%d/%s", tt.name, n, queue), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						simulate(processes, pol)
+					}
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkSJF_100k(b *testing.B) {
+	processes := randomWorkload(100000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SJF{}.Schedule(processes)
+	}
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Options are the common settings a custom scheduler is built with, as given on the command line.
+type Options struct {
+	Quantum    int64
+	Cores      int
+	SwitchCost int64
+	Seed       int64
+	TieBreak   TieBreak
+}
+
+// Factory builds a custom scheduler from the common options.
+type Factory func(Options) Scheduler
+
+// Registry holds custom schedulers by name. The zero value is an empty registry ready to use.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// Registered is the registry Register adds to, which the command line picks custom schedulers up from.
+var Registered = &Registry{}
+
+// Register adds a custom scheduler to Registered under name, typically from an init function, e.g.
+//
+//	func init() {
+//		scheduler.Register("ljf", func(o scheduler.Options) scheduler.Scheduler { return LJF{Cores: o.Cores} })
+//	}
+//
+// It panics if the name is empty or already registered, or if factory is nil.
+func Register(name string, factory Factory) {
+	Registered.Register(name, factory)
+}
+
+// Register adds a custom scheduler to the registry under name. It panics if the name is empty or already
+// registered, or if factory is nil.
+func (r *Registry) Register(name string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case name == "":
+		panic("scheduler: Register with an empty name")
+	case factory == nil:
+		panic(fmt.Sprintf("scheduler: Register %q with a nil factory", name))
+	}
+	if _, ok := r.factories[name]; ok {
+		panic(fmt.Sprintf("scheduler: Register called twice for %q", name))
+	}
+	if r.factories == nil {
+		r.factories = make(map[string]Factory)
+	}
+	r.factories[name] = factory
+}
+
+// Lookup returns the factory registered under name.
+func (r *Registry) Lookup(name string) (Factory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, ok := r.factories[name]
+
+	return factory, ok
+}
+
+// Names returns the registered names in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
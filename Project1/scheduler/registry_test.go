@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	var r Registry
+	if _, ok := r.Lookup("ljf"); ok {
+		t.Error("Lookup() found a scheduler in an empty registry")
+	}
+	r.Register("rr-4", func(o Options) Scheduler { return RoundRobin{Quantum: 4, Cores: o.Cores} })
+	r.Register("fcfs-2", func(o Options) Scheduler { return FCFS{Cores: 2, TieBreak: o.TieBreak} })
+
+	if got, want := r.Names(), []string{"fcfs-2", "rr-4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	factory, ok := r.Lookup("rr-4")
+	if !ok {
+		t.Fatal("Lookup() didn't find a registered scheduler")
+	}
+	if got, want := factory(Options{Cores: 3}), (RoundRobin{Quantum: 4, Cores: 3}); got != want {
+		t.Errorf("factory() = %+v, want %+v", got, want)
+	}
+
+	for name, register := range map[string]func(){
+		"twice":       func() { r.Register("rr-4", func(Options) Scheduler { return RoundRobin{} }) },
+		"empty name":  func() { r.Register("", func(Options) Scheduler { return RoundRobin{} }) },
+		"nil factory": func() { r.Register("nil", nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register() %s didn't panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
+
+func ExampleRegister() {
+	// Round-Robin with a quantum twice the one configured, to compare against the plain one.
+	Register("rr-double", func(o Options) Scheduler {
+		return RoundRobin{Quantum: 2 * o.Quantum, Cores: o.Cores, SwitchCost: o.SwitchCost, TieBreak: o.TieBreak}
+	})
+
+	factory, _ := Registered.Lookup("rr-double")
+	result := factory(Options{Quantum: 1}).Schedule([]Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, BurstDuration: 2},
+	})
+	fmt.Println(result.Gantt)
+	// Output: [{1 0 2 0 false} {2 2 4 0 false} {1 4 5 0 false}]
+}
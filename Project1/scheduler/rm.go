@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MaxPeriodicJobs is the most jobs a rate-monotonic schedule releases over its hyperperiod. Coprime periods make
+// the hyperperiod, and with it the number of jobs, grow with their product, so task sets over it are rejected by
+// CheckPeriodic.
+const MaxPeriodicJobs = 100_000
+
+// ErrTooManyJobs is returned for periodic task sets that release more than MaxPeriodicJobs jobs over their
+// hyperperiod, or whose hyperperiod doesn't fit in an int64.
+var ErrTooManyJobs = errors.New("too many periodic jobs")
+
+// Schedulability is the Liu & Layland utilization analysis of a periodic task set.
+type Schedulability struct {
+	Tasks       int
+	Utilization float64
+	// Bound is n(2^(1/n) - 1); a task set at or under it is guaranteed schedulable by rate-monotonic.
+	Bound float64
+	// Hyperperiod is the least common multiple of the periods, or 0 when it doesn't fit in an int64.
+	Hyperperiod int64
+}
+
+// Guaranteed reports whether the utilization bound guarantees the task set is schedulable.
+func (s Schedulability) Guaranteed() bool {
+	return s.Utilization <= s.Bound
+}
+
+// Feasible reports whether the task set fits on one CPU at all; between the bound and 100% utilization
+// only the simulation can tell.
+func (s Schedulability) Feasible() bool {
+	return s.Utilization <= 1
+}
+
+// RateMonotonic is preemptive rate-monotonic scheduling of periodic tasks (processes with a Period).
+// Every task releases a job each period from its arrival time, due by the start of its next period, and
+// tasks with shorter periods have higher priority. The schedule covers one hyperperiod after the last
+// task's first release; processes without a period only run when no periodic job is ready. Task sets
+// CheckPeriodic rejects are only scheduled for as long as it takes to release about MaxPeriodicJobs jobs.
+type RateMonotonic struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the rate-monotonic schedule of processes, one result row per job.
+func (r RateMonotonic) Schedule(processes []Process) ScheduleResult {
+	return r.observe(processes, nil)
+}
+
+func (r RateMonotonic) observe(processes []Process, obs observers) ScheduleResult {
+	analysis := analyzePeriodic(processes)
+	horizon, err := periodicHorizon(processes)
+	if err != nil {
+		horizon = truncatedHorizon(processes)
+	}
+
+	jobs := make([]Process, 0, len(processes))
+	for _, p := range processes {
+		if p.Period <= 0 {
+			jobs = append(jobs, p)
+			continue
+		}
+		for release := p.ArrivalTime; release < horizon; release += p.Period {
+			job := p
+			job.ArrivalTime = release
+			job.Deadline = release + p.Period
+			jobs = append(jobs, job)
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].ArrivalTime < jobs[j].ArrivalTime
+	})
+
+	result := simulate(jobs, policy{
+		less: func(a, b *task, _ int64) bool {
+			switch {
+			case a.Period == 0:
+				return false
+			case b.Period == 0:
+				return true
+			}
+			return a.Period < b.Period
+		},
+		fixedKeys:  true,
+		preemptive: true,
+		tieBreak:   r.TieBreak,
+		cores:      r.Cores,
+		switchCost: r.SwitchCost,
+		observers:  obs,
+	})
+	result.Schedulability = &analysis
+
+	return result
+}
+
+// CheckPeriodic returns an error wrapping ErrTooManyJobs when the rate-monotonic schedule of processes would
+// release more than MaxPeriodicJobs jobs, so callers can reject the task set before scheduling it.
+func CheckPeriodic(processes []Process) error {
+	_, err := periodicHorizon(processes)
+
+	return err
+}
+
+// periodicHorizon returns when the rate-monotonic schedule of processes stops releasing jobs: one hyperperiod
+// after the last task's first release.
+func periodicHorizon(processes []Process) (int64, error) {
+	var (
+		last        int64
+		hyperperiod int64 = 1
+		ok          bool
+	)
+	for i := range processes {
+		p := &processes[i]
+		if p.Period <= 0 {
+			continue
+		}
+		last = max(last, p.ArrivalTime)
+		if hyperperiod, ok = lcm(hyperperiod, p.Period); !ok {
+			return 0, fmt.Errorf("%w: the hyperperiod of the periods overflows", ErrTooManyJobs)
+		}
+	}
+	if last > math.MaxInt64-hyperperiod {
+		return 0, fmt.Errorf("%w: the hyperperiod of %d overflows", ErrTooManyJobs, hyperperiod)
+	}
+	horizon := last + hyperperiod
+	var jobs int64
+	for i := range processes {
+		p := &processes[i]
+		if p.Period <= 0 {
+			continue
+		}
+		released := (horizon-p.ArrivalTime-1)/p.Period + 1
+		if released > MaxPeriodicJobs-jobs {
+			return 0, fmt.Errorf("%w: a hyperperiod of %d releases over %d jobs", ErrTooManyJobs, hyperperiod,
+				MaxPeriodicJobs)
+		}
+		jobs += released
+	}
+
+	return horizon, nil
+}
+
+// truncatedHorizon returns a horizon after the last task's first release that releases about MaxPeriodicJobs
+// jobs, for task sets whose hyperperiod releases too many.
+func truncatedHorizon(processes []Process) int64 {
+	var (
+		last int64
+		rate float64 // jobs released per unit of time
+	)
+	for i := range processes {
+		if p := &processes[i]; p.Period > 0 {
+			last = max(last, p.ArrivalTime)
+			rate += 1 / float64(p.Period)
+		}
+	}
+	window := float64(MaxPeriodicJobs) / rate
+	if window >= float64(math.MaxInt64-last) {
+		return math.MaxInt64
+	}
+
+	return last + int64(window)
+}
+
+func analyzePeriodic(processes []Process) Schedulability {
+	s := Schedulability{Hyperperiod: 1}
+	ok := true
+	for i := range processes {
+		if processes[i].Period <= 0 {
+			continue
+		}
+		s.Tasks++
+		s.Utilization += float64(processes[i].BurstDuration) / float64(processes[i].Period)
+		if ok {
+			s.Hyperperiod, ok = lcm(s.Hyperperiod, processes[i].Period)
+		}
+	}
+	if s.Tasks > 0 {
+		n := float64(s.Tasks)
+		s.Bound = n * (math.Pow(2, 1/n) - 1)
+	}
+	if s.Tasks == 0 || !ok {
+		s.Hyperperiod = 0
+	}
+
+	return s
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lcm returns the least common multiple of a and b, and false if it overflows an int64.
+func lcm(a, b int64) (int64, bool) {
+	m := a / gcd(a, b)
+	if m > math.MaxInt64/b {
+		return 0, false
+	}
+
+	return m * b, true
+}
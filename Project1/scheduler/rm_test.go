@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRateMonotonic_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		processes       []Process
+		wantGantt       []TimeSlice
+		wantMisses      int
+		wantHyperperiod int64
+		wantGuaranteed  bool
+		wantFeasible    bool
+		wantUtilization float64
+	}{
+		{
+			name: "schedulable under the bound",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 4},
+				{ProcessID: 2, BurstDuration: 2, Period: 6},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 4, Stop: 5},
+				{PID: 2, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 9},
+			},
+			wantHyperperiod: 12,
+			wantGuaranteed:  true,
+			wantFeasible:    true,
+			wantUtilization: 1.0/4 + 2.0/6,
+		},
+		{
+			name: "over the bound misses a deadline",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Period: 4},
+				{ProcessID: 2, BurstDuration: 3, Period: 6},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 6},
+				{PID: 2, Start: 6, Stop: 8},
+				{PID: 1, Start: 8, Stop: 10},
+				{PID: 2, Start: 10, Stop: 12},
+			},
+			wantMisses:      1,
+			wantHyperperiod: 12,
+			wantFeasible:    true,
+			wantUtilization: 2.0/4 + 3.0/6,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := RateMonotonic{}.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if got.Stats.DeadlineMisses != tt.wantMisses {
+				t.Errorf("Schedule() misses = %v, want %v", got.Stats.DeadlineMisses, tt.wantMisses)
+			}
+			s := got.Schedulability
+			if s == nil {
+				t.Fatal("Schedule() schedulability is unexpectedly nil")
+			}
+			if s.Hyperperiod != tt.wantHyperperiod {
+				t.Errorf("Hyperperiod = %v, want %v", s.Hyperperiod, tt.wantHyperperiod)
+			}
+			if math.Abs(s.Utilization-tt.wantUtilization) > 1e-9 {
+				t.Errorf("Utilization = %v, want %v", s.Utilization, tt.wantUtilization)
+			}
+			if s.Guaranteed() != tt.wantGuaranteed || s.Feasible() != tt.wantFeasible {
+				t.Errorf("Guaranteed() = %v, Feasible() = %v, want %v, %v",
+					s.Guaranteed(), s.Feasible(), tt.wantGuaranteed, tt.wantFeasible)
+			}
+		})
+	}
+}
+
+func TestCheckPeriodic(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{
+			name: "textbook task set",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 4},
+				{ProcessID: 2, BurstDuration: 2, Period: 6},
+				{ProcessID: 3, BurstDuration: 5},
+			},
+		},
+		{
+			name: "coprime periods",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 9973},
+				{ProcessID: 2, BurstDuration: 1, Period: 9967},
+				{ProcessID: 3, BurstDuration: 1, Period: 9949},
+			},
+			wantErr: ErrTooManyJobs,
+		},
+		{
+			name: "hyperperiod overflows",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 1_000_000_007},
+				{ProcessID: 2, BurstDuration: 1, Period: 1_000_000_009},
+				{ProcessID: 3, BurstDuration: 1, Period: 998_244_353},
+			},
+			wantErr: ErrTooManyJobs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckPeriodic(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckPeriodic() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateMonotonic_Schedule_coprimePeriods(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 1, Period: 9973},
+		{ProcessID: 2, BurstDuration: 1, Period: 9967},
+		{ProcessID: 3, BurstDuration: 1, Period: 9949},
+	}
+	got := RateMonotonic{}.Schedule(processes)
+	if n := len(got.Processes); n == 0 || n > MaxPeriodicJobs+len(processes) {
+		t.Errorf("Schedule() released %d jobs, want at most about %d", n, MaxPeriodicJobs)
+	}
+	if got.Schedulability.Hyperperiod != 9973*9967*9949 {
+		t.Errorf("Hyperperiod = %d, want %d", got.Schedulability.Hyperperiod, 9973*9967*9949)
+	}
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+// RoundRobin is Round-Robin scheduling: arrived processes take turns on the CPU for a quantum at a time.
+type RoundRobin struct {
+	// Quantum is the time slice; zero means one time unit.
+	Quantum int64
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the Round-Robin schedule of processes.
+func (r RoundRobin) Schedule(processes []Process) ScheduleResult {
+	return r.observe(processes, nil)
+}
+
+func (r RoundRobin) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{
+		quantum:    max(r.Quantum, 1),
+		cores:      r.Cores,
+		switchCost: r.SwitchCost,
+		tieBreak:   r.TieBreak,
+		observers:  obs,
+	})
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundRobin_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		rr        RoundRobin
+		processes []Process
+		wantGantt []TimeSlice
+		wantCores []CoreStats
+		// wantSwitchTime is the total context switch overhead.
+		wantSwitchTime int64
+	}{
+		{
+			name: "processes take turns each quantum",
+			rr:   RoundRobin{Quantum: 2},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 5},
+				{PID: 2, Start: 5, Stop: 6},
+			},
+		},
+		{
+			name: "context switches cost time",
+			rr:   RoundRobin{Quantum: 2, SwitchCost: 1},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 3, Switch: true},
+				{PID: 2, Start: 3, Stop: 5},
+				{PID: 1, Start: 5, Stop: 6, Switch: true},
+				{PID: 1, Start: 6, Stop: 7},
+			},
+			wantSwitchTime: 2,
+		},
+		{
+			name: "two cores keep a process on the CPU it last ran on",
+			rr:   RoundRobin{Quantum: 2, Cores: 2},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4, CPU: 0},
+				{PID: 2, Start: 0, Stop: 2, CPU: 1},
+				{PID: 3, Start: 2, Stop: 4, CPU: 1},
+			},
+			wantCores: []CoreStats{
+				{CPU: 0, Busy: 4, Utilization: 1},
+				{CPU: 1, Busy: 4, Utilization: 1},
+			},
+		},
+		{
+			name: "CPUs aren't busy during context switches",
+			rr:   RoundRobin{Quantum: 2, Cores: 2, SwitchCost: 1},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4, CPU: 0},
+				{PID: 2, Start: 0, Stop: 2, CPU: 1},
+				{PID: 3, Start: 2, Stop: 3, CPU: 1, Switch: true},
+				{PID: 3, Start: 3, Stop: 5, CPU: 1},
+			},
+			wantCores: []CoreStats{
+				{CPU: 0, Busy: 4, Utilization: 0.8},
+				{CPU: 1, Busy: 4, Utilization: 0.8},
+			},
+			wantSwitchTime: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.rr.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %+v, want %+v", got.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(got.Cores, tt.wantCores) {
+				t.Errorf("Cores = %+v, want %+v", got.Cores, tt.wantCores)
+			}
+			if got.Stats.SwitchTime != tt.wantSwitchTime {
+				t.Errorf("SwitchTime = %d, want %d", got.Stats.SwitchTime, tt.wantSwitchTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Package scheduler implements CPU scheduling algorithms over a set of processes.
+//
+// Every algorithm satisfies the Scheduler interface, so callers can run them programmatically and
+// render or inspect the Result however they like.
+package scheduler
+
+type (
+	Process struct {
+		ProcessID int64
+		// Name is an optional label for the process, e.g. "editor".
+		Name          string
+		ArrivalTime   int64
+		BurstDuration int64
+		Priority      int64
+		Group         string
+		// Deadline is the time by which the process should complete; zero means it has none.
+		Deadline int64
+		// Period makes the process a periodic task, released every Period from its ArrivalTime with a
+		// worst-case execution time of BurstDuration. Zero means the process runs once.
+		Period int64
+		// Bursts alternates CPU and I/O burst durations, starting and ending with a CPU burst, for a process
+		// that blocks on I/O. BurstDuration is then the total of the CPU bursts. Empty means a single CPU burst.
+		Bursts []int64
+		// DependsOn lists the PIDs that must complete before the process can run.
+		DependsOn []int64
+		// Affinity lists the CPUs the process may run on; empty means any. Only multi-core schedules honor it.
+		Affinity []int
+		// Gang is the ID of the thread group the process is co-scheduled with under gang scheduling; zero means
+		// it runs alone.
+		Gang int64
+		// Suspensions lists the times the process is suspended, beyond blocking on I/O.
+		Suspensions []Suspension
+	}
+	TimeSlice struct {
+		PID   int64
+		Start int64
+		Stop  int64
+		// CPU is the core the slice ran on.
+		CPU int
+		// Switch marks context switch overhead spent loading PID rather than running it.
+		Switch bool
+	}
+)
+
+// Scheduler schedules a set of processes and returns the resulting schedule.
+// Implementations must not modify the given processes.
+type Scheduler interface {
+	Schedule(processes []Process) ScheduleResult
+}
+
+type (
+	// ScheduleResult is the outcome of scheduling a set of processes.
+	ScheduleResult struct {
+		Gantt []TimeSlice
+		// Processes holds the timing of each process, in the order the scheduler finished with them.
+		Processes []ProcessResult
+		Stats     Stats
+		// Cgroups holds per-group accounting for schedulers that enforce cgroup limits.
+		Cgroups []CgroupStats
+		// Shares holds the CPU share of each process for proportional-share schedulers.
+		Shares []Share
+		// Schedulability holds the utilization analysis of schedulers for periodic tasks.
+		Schedulability *Schedulability
+		// Dispatches logs each dispatch for schedulers whose decisions change over time.
+		Dispatches []Dispatch
+		// Predictions holds each CPU burst's prediction for schedulers that predict bursts.
+		Predictions []Prediction
+		// Cores holds the busy time of each CPU of a multi-core schedule.
+		Cores []CoreStats
+	}
+	// CoreStats is a CPU's busy time over a schedule.
+	CoreStats struct {
+		CPU  int
+		Busy int64
+		// Pinned is the part of Busy spent running processes with an affinity.
+		Pinned int64
+		// Utilization is the fraction of the schedule's length the CPU was busy.
+		Utilization float64
+	}
+	// Dispatch records a process being put on the CPU.
+	Dispatch struct {
+		Time int64
+		PID  int64
+		// Priority is the base priority of the process.
+		Priority int64
+		// EffectivePriority is the priority the dispatch decision was made with.
+		EffectivePriority int64
+	}
+	// ProcessResult is the timing of a single scheduled process.
+	ProcessResult struct {
+		Process
+		Wait int64
+		// Response is the time from arrival until the process first ran.
+		Response   int64
+		Turnaround int64
+		Completion int64
+		// Suspended is the time the process spent suspended, which isn't counted as waiting.
+		Suspended int64
+	}
+	// Stats are the aggregate statistics of a schedule.
+	Stats struct {
+		AveWait       float64
+		AveResponse   float64
+		AveTurnaround float64
+		// AveThroughput is processes completed per unit of time.
+		AveThroughput float64
+		// Fairness is Jain's fairness index over the processes' slowdowns, from 1/n, when one process
+		// took all the delay, to 1, when every process was slowed down equally.
+		Fairness float64
+		// DeadlineMisses counts the processes that completed after their deadline.
+		DeadlineMisses int
+		// Switches counts the context switches, and SwitchTime the total time they cost.
+		Switches   int
+		SwitchTime int64
+		// Makespan is the time from the start of the schedule until the last process completed.
+		Makespan int64
+		// IdleTime is the CPU time over the makespan spent with nothing to run, and Utilization the fraction
+		// spent running processes, both summed over every CPU.
+		IdleTime    int64
+		Utilization float64
+		// Fragmentation is the CPU time a gang schedule left idle while a gang waited for its members or for enough
+		// free CPUs.
+		Fragmentation int64
+		// LoadImbalance is how much busier the busiest CPU of a multi-core schedule was than the average, as a
+		// fraction of the average: zero when every CPU was equally busy.
+		LoadImbalance float64
+		// PredictionError is the mean absolute error of the burst predictions, for schedulers that make them.
+		PredictionError float64
+		// Energy is the energy used over the makespan under an energy model, and EnergyDelay its product with the
+		// makespan; both are zero without one.
+		Energy      float64
+		EnergyDelay float64
+	}
+)
+
+// RunsOn reports whether the process's affinity lets it run on cpu.
+func (p Process) RunsOn(cpu int) bool {
+	if len(p.Affinity) == 0 {
+		return true
+	}
+	for _, c := range p.Affinity {
+		if c == cpu {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IOTime returns the total time the process spends blocked on I/O.
+func (p Process) IOTime() int64 {
+	var total int64
+	for i := 1; i < len(p.Bursts); i += 2 {
+		total += p.Bursts[i]
+	}
+
+	return total
+}
+
+// Slowdown returns the process's normalized turnaround: its turnaround over the time it needed to run, including
+// I/O and the time it was suspended. A process that never waited has a slowdown of 1.
+func (r ProcessResult) Slowdown() float64 {
+	return float64(r.Turnaround) / float64(r.BurstDuration+r.IOTime()+r.Suspended)
+}
+
+// Fairness returns Jain's fairness index over the slowdowns of rows, (Σx)² / (n·Σx²).
+func Fairness(rows []ProcessResult) float64 {
+	var sum, squares float64
+	for i := range rows {
+		x := rows[i].Slowdown()
+		sum += x
+		squares += x * x
+	}
+	if squares == 0 {
+		return 0
+	}
+
+	return sum * sum / (float64(len(rows)) * squares)
+}
+
+// account fills in the context switch, makespan and utilization statistics of a schedule on cpus CPUs.
+func (s *Stats) account(gantt []TimeSlice, rows []ProcessResult, cpus int) {
+	var busy int64
+	for _, slice := range gantt {
+		if slice.Switch {
+			s.Switches++
+			s.SwitchTime += slice.Stop - slice.Start
+		} else {
+			busy += slice.Stop - slice.Start
+		}
+	}
+	for i := range rows {
+		s.Makespan = max(s.Makespan, rows[i].Completion)
+	}
+	if capacity := s.Makespan * int64(cpus); capacity > 0 {
+		s.IdleTime = capacity - busy - s.SwitchTime
+		s.Utilization = float64(busy) / float64(capacity)
+	}
+}
+
+// MissedDeadline reports whether the process has a deadline and completed after it.
+func (r ProcessResult) MissedDeadline() bool {
+	return r.Deadline > 0 && r.Completion > r.Deadline
+}
+
+func deadlineMisses(rows []ProcessResult) int {
+	var misses int
+	for i := range rows {
+		if rows[i].MissedDeadline() {
+			misses++
+		}
+	}
+
+	return misses
+}
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// appendSlice records CPU time for pid, extending the last slice when it is contiguous.
+func appendSlice(gantt []TimeSlice, pid, start, stop int64) []TimeSlice {
+	if n := len(gantt); n > 0 && gantt[n-1].PID == pid && gantt[n-1].Stop == start && !gantt[n-1].Switch {
+		gantt[n-1].Stop = stop
+		return gantt
+	}
+
+	return append(gantt, TimeSlice{
+		PID:   pid,
+		Start: start,
+		Stop:  stop,
+	})
+}
+
+func max(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
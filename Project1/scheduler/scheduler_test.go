@@ -0,0 +1,397 @@
+package scheduler
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestScheduler_Schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		wantGantt []TimeSlice
+	}{
+		{
+			name:      "FCFS",
+			scheduler: FCFS{},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 2, Start: 5, Stop: 14},
+				{PID: 3, Start: 14, Stop: 20},
+			},
+		},
+		{
+			name:      "SJF",
+			scheduler: SJF{},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 2, Start: 5, Stop: 14},
+				{PID: 3, Start: 14, Stop: 20},
+			},
+		},
+		{
+			name:      "SJF Priority",
+			scheduler: SJFPriority{},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 12},
+				{PID: 1, Start: 12, Stop: 14},
+				{PID: 3, Start: 14, Stop: 20},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			input := make([]Process, len(processes))
+			copy(input, processes)
+
+			got := tt.scheduler.Schedule(input)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(input, processes) {
+				t.Errorf("Schedule() modified its input: %v", input)
+			}
+		})
+	}
+}
+
+func TestScheduler_Response(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+	}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		// want maps PIDs to their response time.
+		want map[int64]int64
+	}{
+		{name: "FCFS", scheduler: FCFS{}, want: map[int64]int64{1: 0, 2: 3, 3: 5}},
+		{name: "Round-Robin", scheduler: RoundRobin{Quantum: 1}, want: map[int64]int64{1: 0, 2: 0, 3: 1}},
+		{name: "Round-Robin with switch cost", scheduler: RoundRobin{Quantum: 2, SwitchCost: 1}, want: map[int64]int64{1: 0, 2: 2, 3: 4}},
+		{
+			name:      "MLQ",
+			scheduler: MLQ{Queues: []Queue{{MinPriority: 1, MaxPriority: 1, Discipline: RRQueue, Quantum: 1}}},
+			want:      map[int64]int64{1: 0, 2: 0, 3: 1},
+		},
+		{name: "cgroups", scheduler: CgroupRoundRobin{}, want: map[int64]int64{1: 0, 2: 0, 3: 1}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.scheduler.Schedule(processes)
+			var total int64
+			for _, row := range got.Processes {
+				if row.Response != tt.want[row.ProcessID] {
+					t.Errorf("process %d response = %d, want %d", row.ProcessID, row.Response, tt.want[row.ProcessID])
+				}
+				total += row.Response
+			}
+			if want := float64(total) / float64(len(processes)); got.Stats.AveResponse != want {
+				t.Errorf("AveResponse = %v, want %v", got.Stats.AveResponse, want)
+			}
+		})
+	}
+}
+
+func TestScheduler_arrivals(t *testing.T) {
+	t.Parallel()
+	queues := []Queue{
+		{MinPriority: 1, MaxPriority: 1, Discipline: RRQueue, Quantum: 2},
+		{MinPriority: 2, MaxPriority: 10, Discipline: FCFSQueue},
+	}
+	schedulers := map[string]Scheduler{
+		"FCFS": FCFS{}, "SJF": SJF{}, "SRTF": SRTF{}, "SJF Priority": SJFPriority{}, "Aging": Aging{Interval: 2},
+		"HRRN": HRRN{}, "Round-Robin": RoundRobin{Quantum: 2}, "Lottery": Lottery{Seed: 1}, "Stride": Stride{},
+		"EDF": EDF{}, "RM": RateMonotonic{}, "MLQ": MLQ{Queues: queues}, "cgroups": CgroupRoundRobin{},
+		"Predictive SJF": PredictiveSJF{Alpha: 0.5},
+		"MLFQ":           MLFQ{Levels: []Level{{Discipline: RRQueue, Quantum: 2}, {Discipline: FCFSQueue}}, Boost: 10},
+	}
+	workloads := []struct {
+		name      string
+		processes []Process
+		wantIdle  int64
+	}{
+		{
+			name: "unsorted",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 4, BurstDuration: 3, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 5, Priority: 3},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+			},
+		},
+		{
+			name: "gaps between arrivals",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 3, BurstDuration: 2, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 10, BurstDuration: 4, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 11, BurstDuration: 1, Priority: 3},
+			},
+			wantIdle: 3 + 5,
+		},
+		{
+			name: "unsorted with gaps",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 20, BurstDuration: 3, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 10, BurstDuration: 4, Priority: 3},
+				{ProcessID: 4, ArrivalTime: 11, BurstDuration: 1, Priority: 1},
+			},
+			wantIdle: 8 + 5,
+		},
+	}
+	for name, scheduler := range schedulers {
+		for _, w := range workloads {
+			name, scheduler, w := name, scheduler, w
+			t.Run(name+" "+w.name, func(t *testing.T) {
+				t.Parallel()
+				got := scheduler.Schedule(w.processes)
+				if err := got.Check(); err != nil {
+					t.Error(err)
+				}
+				arrivals := make(map[int64]int64)
+				for _, p := range w.processes {
+					arrivals[p.ProcessID] = p.ArrivalTime
+				}
+				for _, row := range got.Processes {
+					if row.Wait < 0 || row.Response < 0 || row.Response > row.Wait ||
+						row.Turnaround != row.Completion-row.ArrivalTime || row.Wait != row.Turnaround-row.BurstDuration {
+						t.Errorf("process %d timing is inconsistent: %+v", row.ProcessID, row)
+					}
+				}
+				var last int64
+				for _, slice := range got.Gantt {
+					if slice.Start < arrivals[slice.PID] {
+						t.Errorf("process %d ran at %d, before it arrived at %d", slice.PID, slice.Start, arrivals[slice.PID])
+					}
+					if slice.Start < last {
+						t.Errorf("slice %+v overlaps the one before it", slice)
+					}
+					last = slice.Stop
+				}
+				if got.Stats.IdleTime != w.wantIdle {
+					t.Errorf("IdleTime = %d, want %d", got.Stats.IdleTime, w.wantIdle)
+				}
+
+				// Listing the processes in order of arrival changes nothing.
+				sorted := append([]Process(nil), w.processes...)
+				sort.Slice(sorted, func(i, j int) bool {
+					return sorted[i].ArrivalTime < sorted[j].ArrivalTime
+				})
+				if want := scheduler.Schedule(sorted); !reflect.DeepEqual(got, want) {
+					t.Errorf("Schedule() = %+v, want the schedule of the sorted processes %+v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestScheduler_cores(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1, Deadline: 10},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 1, Deadline: 10},
+	}
+	schedulers := map[string]Scheduler{
+		"Aging": Aging{Cores: 2, SwitchCost: 1}, "HRRN": HRRN{Cores: 2, SwitchCost: 1},
+		"Lottery": Lottery{Seed: 1, Cores: 2, SwitchCost: 1}, "Stride": Stride{Cores: 2, SwitchCost: 1},
+		"EDF": EDF{Cores: 2, SwitchCost: 1}, "RM": RateMonotonic{Cores: 2, SwitchCost: 1},
+	}
+	for name, scheduler := range schedulers {
+		name, scheduler := name, scheduler
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := scheduler.Schedule(processes)
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+			if len(got.Cores) != 2 || got.Stats.Makespan != 4 {
+				t.Errorf("Schedule() ran on %d CPUs until %d, want both processes at once on 2 CPUs until 4",
+					len(got.Cores), got.Stats.Makespan)
+			}
+		})
+	}
+}
+
+func TestScheduler_affinity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		processes     []Process
+		wantGantt     []TimeSlice
+		wantCores     []CoreStats
+		wantImbalance float64
+	}{
+		{
+			name: "pinned processes wait for their CPU while another idles",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Affinity: []int{0}},
+				{ProcessID: 2, BurstDuration: 4, Affinity: []int{0}},
+				{ProcessID: 3, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 3, Start: 0, Stop: 2, CPU: 1},
+				{PID: 2, Start: 4, Stop: 8},
+			},
+			wantCores: []CoreStats{
+				{CPU: 0, Busy: 8, Pinned: 8, Utilization: 1},
+				{CPU: 1, Busy: 2, Utilization: 0.25},
+			},
+			wantImbalance: 0.6,
+		},
+		{
+			name: "processes ahead move aside for pinned ones",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2},
+				{ProcessID: 2, BurstDuration: 2, Affinity: []int{0}},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 2},
+				{PID: 1, Start: 0, Stop: 2, CPU: 1},
+			},
+			wantCores: []CoreStats{
+				{CPU: 0, Busy: 2, Pinned: 2, Utilization: 1},
+				{CPU: 1, Busy: 2, Utilization: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := FCFS{Cores: 2}.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(got.Cores, tt.wantCores) {
+				t.Errorf("Schedule() cores = %+v, want %+v", got.Cores, tt.wantCores)
+			}
+			if got.Stats.LoadImbalance != tt.wantImbalance {
+				t.Errorf("Schedule() load imbalance = %v, want %v", got.Stats.LoadImbalance, tt.wantImbalance)
+			}
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestStats_account(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		rows  []ProcessResult
+		cpus  int
+		want  Stats
+	}{
+		{
+			name:  "idle gap before an arrival",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 5, Stop: 7}},
+			rows:  []ProcessResult{{Completion: 2}, {Completion: 7}},
+			cpus:  1,
+			want:  Stats{Makespan: 7, IdleTime: 3, Utilization: 4.0 / 7},
+		},
+		{
+			name: "context switches are neither idle nor useful",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 3, Switch: true},
+				{PID: 2, Start: 3, Stop: 4},
+			},
+			rows: []ProcessResult{{Completion: 2}, {Completion: 4}},
+			cpus: 1,
+			want: Stats{Switches: 1, SwitchTime: 1, Makespan: 4, Utilization: 3.0 / 4},
+		},
+		{
+			name: "two CPUs",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 0, Stop: 1, CPU: 1},
+			},
+			rows: []ProcessResult{{Completion: 4}, {Completion: 1}},
+			cpus: 2,
+			want: Stats{Makespan: 4, IdleTime: 3, Utilization: 5.0 / 8},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got Stats
+			got.account(tt.gantt, tt.rows, tt.cpus)
+			if got != tt.want {
+				t.Errorf("account() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFairness(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		rows []ProcessResult
+		want float64
+	}{
+		{
+			name: "equal slowdowns are fair",
+			rows: []ProcessResult{
+				{Process: Process{BurstDuration: 2}, Turnaround: 4},
+				{Process: Process{BurstDuration: 3}, Turnaround: 6},
+			},
+			want: 1,
+		},
+		{
+			name: "one starved process",
+			rows: []ProcessResult{
+				{Process: Process{BurstDuration: 1}, Turnaround: 1},
+				{Process: Process{BurstDuration: 1}, Turnaround: 1},
+				{Process: Process{BurstDuration: 1}, Turnaround: 7},
+			},
+			want: 81.0 / 153,
+		},
+		{
+			name: "I/O counts as service",
+			rows: []ProcessResult{
+				{Process: Process{BurstDuration: 2, Bursts: []int64{1, 3, 1}}, Turnaround: 5},
+				{Process: Process{BurstDuration: 1}, Turnaround: 1},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Fairness(tt.rows); got != tt.want {
+				t.Errorf("Fairness() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// jain returns Jain's fairness index over xs.
+func jain(xs ...float64) float64 {
+	var sum, squares float64
+	for _, x := range xs {
+		sum += x
+		squares += x * x
+	}
+
+	return sum * sum / (float64(len(xs)) * squares)
+}
@@ -0,0 +1,589 @@
+package scheduler
+
+import "sort"
+
+// task is a process being simulated.
+type task struct {
+	Process
+	// remaining is what is left of the current CPU burst.
+	remaining int64
+	// readySince is when the task last joined the ready queue.
+	readySince int64
+	// burst indexes the current CPU burst in Bursts.
+	burst int
+	// wake is when the task's I/O completes while it is blocked.
+	wake int64
+	// started is when the task first ran, or -1 before then.
+	started int64
+	// seq is the task's place in the ready queue.
+	seq int64
+	// used is the time the task has run of its current quantum.
+	used int64
+	// resume is when the task resumes while it is suspended, since when, and suspended the time it has spent
+	// suspended.
+	resume, suspendedSince, suspended int64
+}
+
+// cpuBurst returns the length of the task's current CPU burst.
+func (t *task) cpuBurst() int64 {
+	if len(t.Bursts) == 0 {
+		return t.BurstDuration
+	}
+	return t.Bursts[t.burst]
+}
+
+// policy describes how simulate dispatches ready tasks onto the CPUs.
+type policy struct {
+	// less reports whether a should be dispatched before b at time now. Ties (and a nil less) fall back to
+	// ready queue order.
+	less func(a, b *task, now int64) bool
+	// choose, when set, replaces less and returns the index of the ready task to dispatch, drawing on the clock's
+	// random source if it chooses at random.
+	choose func(ready []*task, clock *Clock) int
+	// tieBreak settles ties under less, and orders simultaneous arrivals and I/O completions.
+	tieBreak TieBreak
+	// fixedKeys promises that less ignores now and that the order of two tasks doesn't change while they're
+	// ready, so the ready queue can be kept as a heap.
+	fixedKeys bool
+	// preemptive re-evaluates the ready queue whenever a process arrives or returns from I/O, preempting the
+	// running task when the newcomer should be dispatched before it.
+	preemptive bool
+	// tick, when set, is called each time the clock stops, once the ready queue holds the tasks that have arrived
+	// or returned to it. It tells the clock when it next has to stop, and reports whether the policy now ranks the
+	// tasks differently, so that a preemptive policy re-evaluates the ready queue.
+	tick func(ready []*task, clock *Clock) bool
+	// runnable, when set, reports whether the ready task t may be dispatched. Tasks that may not are passed over,
+	// keeping their places in the queue.
+	runnable func(t *task) bool
+	// limit, when set, returns how long the running task t may run before ran ends its turn or reranks it, or -1
+	// when there's no limit.
+	limit func(t *task) int64
+	// ran, when set, is called each time t has run for step. It reports whether t's turn is over, so that it goes
+	// to the back of the ready queue as when its quantum expires, and whether the policy now ranks the tasks
+	// differently, so that a preemptive policy re-evaluates the ready queue.
+	ran func(t *task, step int64) (expired, reranked bool)
+	// quantum bounds how long a task runs before it goes to the back of the ready queue; zero means unbounded. A
+	// task preempted by another keeps what is left of its quantum.
+	quantum int64
+	// quantumOf, when set, replaces quantum with each task's own.
+	quantumOf func(t *task) int64
+	// dispatched, when set, is called each time a task is put on a CPU.
+	dispatched func(t *task, now int64)
+	// assigned, when set, is called each time a task is given a CPU, including when it keeps the one it has.
+	assigned func(t *task)
+	// cores is the number of CPUs; zero means one.
+	cores int
+	// switchCost is the time a CPU spends switching to a different process before running it.
+	switchCost int64
+	// seed seeds the clock's random source.
+	seed int64
+	// observers are notified of the events of the schedule as they happen.
+	observers observers
+}
+
+// core is a CPU during the simulation.
+type core struct {
+	id    int
+	t     *task // the running task, or nil when idle
+	gantt []TimeSlice
+	last  *task // the task that most recently left the CPU
+	// overhead is what is left of the context switch to t.
+	overhead int64
+	// shown is the task observers were last told was dispatched onto the CPU, until they're told it left.
+	shown *task
+	// expired is whether the policy ended t's turn when it last ran.
+	expired bool
+}
+
+// simulate runs processes on pol.cores CPUs under the given policy. The clock jumps straight to the next
+// arrival, I/O completion, suspension, resumption, CPU burst completion, quantum expiry or time the policy asks
+// for.
+// A process blocks for the I/O between its CPU bursts and rejoins the back of the ready queue afterwards,
+// and is held back from the ready queue until the processes it depends on have completed. A suspended process is
+// taken off its CPU, or out of the ready queue, and rejoins the back of the ready queue when it resumes.
+// Processes are reported in the order they complete, and observers are notified of each event as it happens.
+func simulate(processes []Process, pol policy) ScheduleResult {
+	var (
+		clock    = NewClock(pol.seed)
+		tasks    = arrivalOrder(processes, pol.tieBreak)
+		ready    = newReadyQueue(pol, len(processes))
+		io       = newBlocked(pol.tieBreak, clock)
+		held     = make([]*task, 0) // arrived, but waiting on dependencies
+		finished = make(completions)
+		rows     = make([]ProcessResult, 0, len(processes))
+		cores    = make([]*core, max(int64(pol.cores), 1))
+		paused   = newSuspended(tasks, pol.tieBreak, clock)
+		next     int     // index of the next task to arrive
+		arrivals bool    // whether processes arrived or returned from I/O since the last dispatch decision
+		arrived  []*task // processes that arrived, whose observers are yet to be notified
+	)
+	for _, t := range tasks {
+		t.remaining = t.cpuBurst()
+		clock.At(t.ArrivalTime)
+	}
+	for i := range cores {
+		cores[i] = &core{id: i}
+	}
+	// enqueue queues t, unless it is suspended.
+	enqueue := func(t *task) {
+		if !paused.hold(t, clock.Now()) {
+			ready.pushBack(t)
+			arrivals = true
+		}
+	}
+	admit := func() {
+		now := clock.Now()
+		for next < len(tasks) && tasks[next].ArrivalTime <= now {
+			tasks[next].readySince = tasks[next].ArrivalTime
+			arrived = append(arrived, tasks[next])
+			if finished.met(tasks[next].Process) {
+				enqueue(tasks[next])
+			} else {
+				held = append(held, tasks[next])
+			}
+			next++
+		}
+		for i := 0; i < len(held); i++ {
+			if finished.met(held[i].Process) {
+				held[i].readySince = now
+				enqueue(held[i])
+				held = append(held[:i], held[i+1:]...)
+				i--
+			}
+		}
+		for _, t := range io.wake(now) {
+			enqueue(t)
+		}
+		if paused.starting(now) {
+			ready.remove(func(t *task) bool {
+				return paused.hold(t, now)
+			})
+		}
+		for _, t := range paused.resume(now) {
+			ready.pushBack(t)
+			arrivals = true
+		}
+	}
+
+	for len(rows) < len(tasks) {
+		admit()
+		now := clock.Now()
+		// Observers hear of arrivals after the processes that left their CPUs at the same time.
+		for _, t := range arrived {
+			pol.observers.arrive(t)
+		}
+		arrived = arrived[:0]
+		if pol.tick != nil && pol.tick(ready.tasks, clock) {
+			arrivals = true
+		}
+		if pol.preemptive && arrivals {
+			// Running tasks go back to the head of the queue, so they only lose their CPU to a task that
+			// should strictly run before them.
+			running := make([]*task, 0, len(cores))
+			for _, c := range cores {
+				if c.t != nil {
+					c.t.readySince = now
+					running = append(running, c.t)
+					c.t = nil
+				}
+			}
+			ready.pushFront(running)
+		}
+		arrivals = false
+		dispatch(cores, ready, pol, clock)
+		for _, c := range cores {
+			if c.shown != c.t {
+				c.preempt(pol.observers, now)
+			}
+		}
+
+		run := int64(-1)
+		for _, c := range cores {
+			if c.t == nil {
+				continue
+			}
+			r := c.t.remaining
+			if c.overhead > 0 {
+				r = c.overhead
+			} else {
+				if q := pol.quantumFor(c.t); q > 0 {
+					r = min(r, q-c.t.used)
+				}
+				if pol.limit != nil {
+					if l := pol.limit(c.t); l >= 0 {
+						r = min(r, l)
+					}
+				}
+			}
+			if run < 0 || r < run {
+				run = r
+			}
+		}
+		// Stop at the next event, so an arrival can be dispatched onto an idle CPU or preempt, and a suspension
+		// can take its task off the CPU.
+		step := clock.Step(run)
+		if step < 0 {
+			// Only processes waiting on dependencies that can never complete are left.
+			break
+		}
+		for _, c := range cores {
+			if c.t != nil && c.overhead > 0 {
+				if n := len(c.gantt); n > 0 && c.gantt[n-1].Switch && c.gantt[n-1].PID == c.t.ProcessID && c.gantt[n-1].Stop == now {
+					c.gantt[n-1].Stop += step
+				} else {
+					c.gantt = append(c.gantt, TimeSlice{PID: c.t.ProcessID, Start: now, Stop: now + step, Switch: true})
+				}
+				c.overhead -= step
+			} else if c.t != nil {
+				c.start(pol.observers, now)
+				if c.t.started < 0 {
+					c.t.started = now
+				}
+				c.gantt = appendSlice(c.gantt, c.t.ProcessID, now, now+step)
+				c.t.remaining -= step
+				c.t.used += step
+				if pol.ran != nil {
+					var reranked bool
+					c.expired, reranked = pol.ran(c.t, step)
+					arrivals = arrivals || reranked
+				}
+			}
+		}
+		clock.Advance(step)
+		now = clock.Now()
+		// Arrivals queue ahead of tasks coming off a CPU.
+		admit()
+
+		for _, c := range cores {
+			expired := c.expired
+			c.expired = false
+			switch {
+			case c.t == nil:
+			case c.t.remaining == 0 && c.t.blocksOnIO():
+				// Block for the I/O burst that follows.
+				c.leave(pol.observers, EventBlock, now)
+				c.t.used = 0
+				io.block(c.t)
+				c.t = nil
+			case c.t.remaining == 0:
+				c.leave(pol.observers, EventComplete, now)
+				turnaround := now - c.t.ArrivalTime
+				finished[c.t.ProcessID] = true
+				rows = append(rows, ProcessResult{
+					Process:    c.t.Process,
+					Wait:       turnaround - c.t.BurstDuration - c.t.IOTime() - c.t.suspended,
+					Response:   c.t.started - c.t.ArrivalTime,
+					Turnaround: turnaround,
+					Completion: now,
+					Suspended:  c.t.suspended,
+				})
+				c.t = nil
+			case paused.hold(c.t, now):
+				c.preempt(pol.observers, now)
+				c.t, c.overhead = nil, 0
+			case expired || pol.quantumFor(c.t) > 0 && c.t.used >= pol.quantumFor(c.t):
+				c.preempt(pol.observers, now)
+				c.t.readySince, c.t.used = now, 0
+				ready.pushBack(c.t)
+				c.t = nil
+			}
+		}
+	}
+
+	result := ScheduleResult{
+		Gantt:     mergeGantts(cores),
+		Processes: rows,
+		Stats:     summarize(rows),
+	}
+	result.Stats.account(result.Gantt, rows, len(cores))
+	if len(cores) > 1 {
+		result.Cores = coreStats(cores, rows)
+		result.Stats.LoadImbalance = loadImbalance(result.Cores)
+	}
+
+	return result
+}
+
+// dispatch fills the idle CPUs from the ready queue. A task that is picked again goes back to the CPU it last
+// ran on when that CPU is free. Tasks only go to CPUs in their affinity, and a task that can't, because tasks
+// ahead of it took every idle CPU it may run on, or that the policy doesn't let run, is passed over and keeps its
+// place in the queue.
+func dispatch(cores []*core, ready *readyQueue, pol policy, clock *Clock) {
+	var (
+		now     = clock.Now()
+		idle    = make([]*core, 0, len(cores))
+		picked  = make([]*task, 0, len(cores))
+		passed  = make([]*task, 0)
+		matched = make(map[*core]*task, len(cores))
+	)
+	for _, c := range cores {
+		if c.t == nil {
+			idle = append(idle, c)
+		}
+	}
+	for len(picked) < len(idle) && ready.Len() > 0 {
+		t := ready.pop(clock)
+		if (pol.runnable == nil || pol.runnable(t)) && match(t, idle, matched, make(map[*core]bool)) {
+			picked = append(picked, t)
+		} else {
+			passed = append(passed, t)
+		}
+	}
+	ready.requeue(passed)
+
+	assign := func(c *core, t *task) {
+		if pol.assigned != nil {
+			pol.assigned(t)
+		}
+		if c.last == t && len(c.gantt) > 0 && c.gantt[len(c.gantt)-1].Stop == now {
+			// t kept the CPU, including what is left of its context switch.
+			c.t = t
+			return
+		}
+		if pol.dispatched != nil {
+			pol.dispatched(t, now)
+		}
+		c.overhead = 0
+		if c.last != nil && c.last != t {
+			c.overhead = pol.switchCost
+		}
+		c.t, c.last = t, t
+	}
+	placed := place(idle, picked)
+	if placed == nil {
+		placed = matched
+	}
+	for _, c := range idle {
+		if t, ok := placed[c]; ok && c.last == t {
+			assign(c, t)
+		}
+	}
+	for _, t := range picked {
+		for _, c := range idle {
+			if placed[c] == t && c.t == nil {
+				assign(c, t)
+			}
+		}
+	}
+}
+
+// place puts each picked task back on the idle CPU it last ran on, if it can, and the others on the first idle
+// CPU they may run on, in order. It returns nil when that leaves a task without a CPU.
+func place(idle []*core, picked []*task) map[*core]*task {
+	var (
+		placed = make(map[*core]*task, len(picked))
+		done   = make(map[*task]bool, len(picked))
+	)
+	for _, c := range idle {
+		for _, t := range picked {
+			if c.last == t {
+				placed[c], done[t] = t, true
+			}
+		}
+	}
+	for _, t := range picked {
+		for _, c := range idle {
+			if done[t] {
+				break
+			}
+			if _, ok := placed[c]; !ok && t.RunsOn(c.id) {
+				placed[c], done[t] = t, true
+			}
+		}
+		if !done[t] {
+			return nil
+		}
+	}
+
+	return placed
+}
+
+// match finds t an idle CPU in its affinity, the first free one if any is, or else by moving the tasks already
+// matched to other CPUs, and reports whether it could.
+func match(t *task, idle []*core, matched map[*core]*task, visited map[*core]bool) bool {
+	for _, c := range idle {
+		if _, ok := matched[c]; !ok && t.RunsOn(c.id) {
+			matched[c] = t
+			return true
+		}
+	}
+	for _, c := range idle {
+		if visited[c] || !t.RunsOn(c.id) {
+			continue
+		}
+		visited[c] = true
+		if match(matched[c], idle, matched, visited) {
+			matched[c] = t
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeGantts combines the per-CPU GANTT charts, ordered by start time and then CPU.
+func mergeGantts(cores []*core) []TimeSlice {
+	gantt := make([]TimeSlice, 0)
+	for _, c := range cores {
+		for _, s := range c.gantt {
+			s.CPU = c.id
+			gantt = append(gantt, s)
+		}
+	}
+	sort.SliceStable(gantt, func(i, j int) bool {
+		if gantt[i].Start != gantt[j].Start {
+			return gantt[i].Start < gantt[j].Start
+		}
+		return gantt[i].CPU < gantt[j].CPU
+	})
+
+	return gantt
+}
+
+// coreStats computes the busy time and utilization of each CPU over the schedule. Like Stats.account, it leaves
+// out context switches, which aren't useful work.
+func coreStats(cores []*core, rows []ProcessResult) []CoreStats {
+	var makespan int64
+	for i := range rows {
+		makespan = max(makespan, rows[i].Completion)
+	}
+	pinned := make(map[int64]bool)
+	for i := range rows {
+		if len(rows[i].Affinity) > 0 {
+			pinned[rows[i].ProcessID] = true
+		}
+	}
+	stats := make([]CoreStats, len(cores))
+	for i, c := range cores {
+		stats[i].CPU = c.id
+		for _, s := range c.gantt {
+			if s.Switch {
+				continue
+			}
+			stats[i].Busy += s.Stop - s.Start
+			if pinned[s.PID] {
+				stats[i].Pinned += s.Stop - s.Start
+			}
+		}
+		if makespan > 0 {
+			stats[i].Utilization = float64(stats[i].Busy) / float64(makespan)
+		}
+	}
+
+	return stats
+}
+
+// loadImbalance returns how much busier the busiest CPU was than the average, as a fraction of the average.
+func loadImbalance(cores []CoreStats) float64 {
+	var busiest, total int64
+	for _, c := range cores {
+		busiest = max(busiest, c.Busy)
+		total += c.Busy
+	}
+	if total == 0 {
+		return 0
+	}
+
+	return float64(busiest*int64(len(cores))-total) / float64(total)
+}
+
+// arrivalOrder returns the processes as tasks, sorted by arrival time and then by tb.
+func arrivalOrder(processes []Process, tb TieBreak) []*task {
+	tasks := make([]*task, len(processes))
+	for i := range processes {
+		tasks[i] = &task{Process: processes[i], remaining: processes[i].BurstDuration, started: -1}
+	}
+	sortByArrival(tasks, tb)
+
+	return tasks
+}
+
+// sortByArrival sorts tasks by arrival time, ordering simultaneous arrivals by tb and otherwise keeping their
+// input order.
+func sortByArrival(tasks []*task, tb TieBreak) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].ArrivalTime != tasks[j].ArrivalTime {
+			return tasks[i].ArrivalTime < tasks[j].ArrivalTime
+		}
+		return tb.before(&tasks[i].Process, &tasks[j].Process)
+	})
+}
+
+// quantumFor returns t's quantum, or zero when it's unbounded.
+func (pol policy) quantumFor(t *task) int64 {
+	if pol.quantumOf != nil {
+		return pol.quantumOf(t)
+	}
+
+	return pol.quantum
+}
+
+// pick returns the index of the ready task to dispatch next.
+func (pol policy) pick(ready []*task, clock *Clock) int {
+	if pol.choose != nil {
+		return pol.choose(ready, clock)
+	}
+	now := clock.Now()
+	best := 0
+	if pol.less == nil {
+		return best
+	}
+	for i := 1; i < len(ready); i++ {
+		if pol.less(ready[i], ready[best], now) || !pol.less(ready[best], ready[i], now) && pol.tied(ready[i], ready[best]) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// tied reports whether a goes before b when less ranks them equally. Tasks put back at the head of the queue
+// by a preemption check keep their place, so a tie never preempts; the others are ordered by the tie-break
+// policy and then by queue order.
+func (pol policy) tied(a, b *task) bool {
+	if a.seq > 0 && b.seq > 0 {
+		switch {
+		case pol.tieBreak.before(&a.Process, &b.Process):
+			return true
+		case pol.tieBreak.before(&b.Process, &a.Process):
+			return false
+		}
+	}
+	return a.seq < b.seq
+}
+
+// summarize computes the aggregate statistics of per-process results, all zero when there are none.
+func summarize(rows []ProcessResult) Stats {
+	var (
+		totalWait       float64
+		totalResponse   float64
+		totalTurnaround float64
+		lastCompletion  float64
+	)
+	for i := range rows {
+		totalWait += float64(rows[i].Wait)
+		totalResponse += float64(rows[i].Response)
+		totalTurnaround += float64(rows[i].Turnaround)
+		if c := float64(rows[i].Completion); c > lastCompletion {
+			lastCompletion = c
+		}
+	}
+
+	if len(rows) == 0 {
+		return Stats{}
+	}
+
+	count := float64(len(rows))
+	stats := Stats{
+		AveWait:        totalWait / count,
+		AveResponse:    totalResponse / count,
+		AveTurnaround:  totalTurnaround / count,
+		Fairness:       Fairness(rows),
+		DeadlineMisses: deadlineMisses(rows),
+	}
+	if lastCompletion > 0 {
+		stats.AveThroughput = count / lastCompletion
+	}
+
+	return stats
+}
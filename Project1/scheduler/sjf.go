@@ -0,0 +1,33 @@
+package scheduler
+
+// SJF is Shortest Job First scheduling: the ready process with the shortest next CPU burst runs next, to the
+// end of that burst.
+type SJF struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the SJF schedule of processes.
+func (s SJF) Schedule(processes []Process) ScheduleResult {
+	return s.observe(processes, nil)
+}
+
+func (s SJF) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{
+		less:       shortestBurst,
+		fixedKeys:  true,
+		cores:      s.Cores,
+		switchCost: s.SwitchCost,
+		tieBreak:   s.TieBreak,
+		observers:  obs,
+	})
+}
+
+// shortestBurst orders tasks by the length of their next CPU burst.
+func shortestBurst(a, b *task, _ int64) bool {
+	return a.cpuBurst() < b.cpuBurst()
+}
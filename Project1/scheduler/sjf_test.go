@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSJF_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      ScheduleResult
+	}{
+		{
+			name: "I/O-bound process runs its short CPU bursts first",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Bursts: []int64{1, 4, 1}},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 6},
+			},
+			want: ScheduleResult{
+				Gantt: []TimeSlice{
+					{PID: 1, Start: 0, Stop: 1},
+					{PID: 2, Start: 1, Stop: 7},
+					{PID: 1, Start: 7, Stop: 8},
+				},
+				Processes: []ProcessResult{
+					{Process: Process{ProcessID: 2, ArrivalTime: 0, BurstDuration: 6}, Wait: 1, Response: 1, Turnaround: 7, Completion: 7},
+					{Process: Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Bursts: []int64{1, 4, 1}}, Wait: 2, Turnaround: 8, Completion: 8},
+				},
+				Stats: Stats{
+					AveWait:       3.0 / 2,
+					AveResponse:   1.0 / 2,
+					AveTurnaround: 15.0 / 2,
+					AveThroughput: 2.0 / 8,
+					Fairness:      jain(7.0/6, 8.0/6),
+					Makespan:      8,
+					Utilization:   1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (SJF{}).Schedule(tt.processes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Schedule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+// SRTF is Shortest Remaining Time First scheduling, the preemptive form of SJF: whenever a process
+// arrives, the ready process with the least remaining burst takes the CPU.
+type SRTF struct {
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the SRTF schedule of processes.
+func (s SRTF) Schedule(processes []Process) ScheduleResult {
+	return s.observe(processes, nil)
+}
+
+func (s SRTF) observe(processes []Process, obs observers) ScheduleResult {
+	return simulate(processes, policy{
+		less:       shortestRemaining,
+		fixedKeys:  true,
+		preemptive: true,
+		cores:      s.Cores,
+		switchCost: s.SwitchCost,
+		tieBreak:   s.TieBreak,
+		observers:  obs,
+	})
+}
+
+// shortestRemaining orders tasks by what is left of their CPU burst.
+func shortestRemaining(a, b *task, _ int64) bool {
+	return a.remaining < b.remaining
+}
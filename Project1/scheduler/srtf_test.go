@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSRTF_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantStats Stats
+	}{
+		{
+			name: "textbook example",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 9},
+				{ProcessID: 4, ArrivalTime: 3, BurstDuration: 5},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 5},
+				{PID: 4, Start: 5, Stop: 10},
+				{PID: 1, Start: 10, Stop: 17},
+				{PID: 3, Start: 17, Stop: 26},
+			},
+			wantStats: Stats{
+				AveWait:       26.0 / 4,
+				AveResponse:   17.0 / 4,
+				AveTurnaround: 52.0 / 4,
+				AveThroughput: 4.0 / 26,
+				Fairness:      jain(1, 7.0/5, 17.0/8, 24.0/9),
+				Makespan:      26,
+				Utilization:   1,
+			},
+		},
+		{
+			name: "equal remaining time does not preempt",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 7},
+			},
+			wantStats: Stats{
+				AveWait:       3.0 / 2,
+				AveResponse:   3.0 / 2,
+				AveTurnaround: 10.0 / 2,
+				AveThroughput: 2.0 / 7,
+				Fairness:      jain(1, 2),
+				Makespan:      7,
+				Utilization:   1,
+			},
+		},
+		{
+			name: "idle CPU waits for the next arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 10, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 2, Stop: 4},
+				{PID: 2, Start: 10, Stop: 11},
+			},
+			wantStats: Stats{
+				AveWait:       0,
+				AveTurnaround: 3.0 / 2,
+				AveThroughput: 2.0 / 11,
+				Fairness:      1,
+				Makespan:      11,
+				IdleTime:      8,
+				Utilization:   3.0 / 11,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SRTF{}.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if got.Stats != tt.wantStats {
+				t.Errorf("Schedule() stats = %+v, want %+v", got.Stats, tt.wantStats)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+// strideOne is the stride numerator; a process's stride is strideOne divided by its tickets.
+const strideOne = 1 << 20
+
+// Stride is stride scheduling, the deterministic counterpart to Lottery: each process holds its Priority
+// as a number of tickets (at least one) and advances its pass by strideOne/tickets every time it is
+// given a CPU. Every quantum the ready process with the lowest pass runs, ties going by TieBreak and then
+// to the one that has waited longest.
+type Stride struct {
+	// Quantum is the time between dispatch decisions; zero means one time unit.
+	Quantum int64
+	// Cores is the number of CPUs; zero means one.
+	Cores int
+	// SwitchCost is the context switch overhead each time a CPU changes process.
+	SwitchCost int64
+	// TieBreak orders simultaneous arrivals and the processes the scheduler ranks equally.
+	TieBreak TieBreak
+}
+
+// Schedule returns the stride schedule of processes.
+func (s Stride) Schedule(processes []Process) ScheduleResult {
+	return s.observe(processes, nil)
+}
+
+func (s Stride) observe(processes []Process, obs observers) ScheduleResult {
+	var (
+		pass    = make(map[int64]int64, len(processes))
+		virtual int64 // pass of the most recent dispatch, where arrivals join
+	)
+	quantum := s.Quantum
+	if quantum <= 0 {
+		quantum = 1
+	}
+
+	result := simulate(processes, policy{
+		choose: func(ready []*task, _ *Clock) int {
+			// New arrivals start level with the lowest pass already in the system.
+			lowest, known := virtual, false
+			for _, t := range ready {
+				if p, ok := pass[t.ProcessID]; ok && (!known || p < lowest) {
+					lowest, known = p, true
+				}
+			}
+			for _, t := range ready {
+				if _, ok := pass[t.ProcessID]; !ok {
+					pass[t.ProcessID] = lowest
+				}
+			}
+
+			best := 0
+			for i := 1; i < len(ready); i++ {
+				p, q := pass[ready[i].ProcessID], pass[ready[best].ProcessID]
+				if p < q || p == q && s.TieBreak.before(&ready[i].Process, &ready[best].Process) {
+					best = i
+				}
+			}
+			return best
+		},
+		// A task is only charged its stride once it gets a CPU, not when it's chosen and then passed over
+		// because its affinity excludes the idle CPUs.
+		assigned: func(t *task) {
+			virtual = pass[t.ProcessID]
+			pass[t.ProcessID] += strideOne / tickets(t.Process)
+		},
+		quantum:    quantum,
+		tieBreak:   s.TieBreak,
+		cores:      s.Cores,
+		switchCost: s.SwitchCost,
+		observers:  obs,
+	})
+	result.Shares = shares(result, tickets)
+
+	return result
+}
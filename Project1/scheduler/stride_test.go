@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStride_Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		quantum   int64
+		cores     int
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "runs in proportion to tickets",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 2, Start: 1, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+				{PID: 2, Start: 7, Stop: 9},
+			},
+		},
+		{
+			name: "late arrivals do not monopolize the CPU",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 4, BurstDuration: 2, Priority: 1},
+			},
+			quantum: 2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8},
+			},
+		},
+		{
+			name: "pinned processes are not charged for CPUs they could not run on",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 1, Affinity: []int{0}},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4, Priority: 1, Affinity: []int{0}},
+			},
+			cores: 2,
+			// Processes 2 and 3 take turns on CPU 0 rather than one of them running to completion.
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 1, CPU: 0},
+				{PID: 1, Start: 0, Stop: 4, CPU: 1},
+				{PID: 3, Start: 1, Stop: 2, CPU: 0},
+				{PID: 2, Start: 2, Stop: 3, CPU: 0},
+				{PID: 3, Start: 3, Stop: 4, CPU: 0},
+				{PID: 2, Start: 4, Stop: 5, CPU: 0},
+				{PID: 3, Start: 5, Stop: 6, CPU: 0},
+				{PID: 2, Start: 6, Stop: 7, CPU: 0},
+				{PID: 3, Start: 7, Stop: 8, CPU: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Stride{Quantum: tt.quantum, Cores: tt.cores}.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if len(got.Shares) != len(tt.processes) {
+				t.Errorf("Schedule() shares = %+v, want one per process", got.Shares)
+			}
+		})
+	}
+}
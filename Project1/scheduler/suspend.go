@@ -0,0 +1,135 @@
+package scheduler
+
+import "sort"
+
+// Suspension is an interval a process is suspended for, e.g. by an operator: from At until At+For it is taken off
+// its CPU, or out of the ready queue, and can't run. A process suspended while blocked on I/O stays suspended
+// once the I/O completes, and one suspended before it arrives is held from its arrival.
+type Suspension struct {
+	At  int64
+	For int64
+}
+
+// suspendedUntil returns when the process resumes if it is suspended at now, or now if it isn't. Suspensions that
+// overlap or follow on from each other run together.
+func (p *Process) suspendedUntil(now int64) int64 {
+	until := now
+	for changed := true; changed; {
+		changed = false
+		for _, s := range p.Suspensions {
+			if s.At <= until && until < s.At+s.For {
+				until, changed = s.At+s.For, true
+			}
+		}
+	}
+
+	return until
+}
+
+// suspendedBetween returns how much of the time from start to stop the process is suspended for.
+func (p *Process) suspendedBetween(start, stop int64) int64 {
+	var total int64
+	for _, s := range p.suspensions() {
+		total += max(min(s.Stop, stop)-max(s.Start, start), 0)
+	}
+
+	return total
+}
+
+// suspensions returns the intervals the process is suspended for, in order, with overlapping ones merged.
+func (p *Process) suspensions() []TimeSlice {
+	intervals := make([]TimeSlice, 0, len(p.Suspensions))
+	for _, s := range p.Suspensions {
+		if s.For > 0 {
+			intervals = append(intervals, TimeSlice{PID: p.ProcessID, Start: s.At, Stop: s.At + s.For})
+		}
+	}
+
+	return mergeIntervals(intervals)
+}
+
+// mergeIntervals sorts intervals by start, merging the ones that overlap or touch.
+func mergeIntervals(intervals []TimeSlice) []TimeSlice {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start < intervals[j].Start
+	})
+	merged := intervals[:0]
+	for _, s := range intervals {
+		if n := len(merged); n > 0 && s.Start <= merged[n-1].Stop {
+			merged[n-1].Stop = max(merged[n-1].Stop, s.Stop)
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+// suspended holds the tasks of a simulation that are suspended, until they resume. The clock is told when
+// suspensions begin and when held tasks resume.
+type suspended struct {
+	tasks []*task
+	// starts holds the times suspensions begin, in order.
+	starts   []int64
+	tieBreak TieBreak
+	clock    *Clock
+}
+
+func newSuspended(tasks []*task, tb TieBreak, clock *Clock) *suspended {
+	s := &suspended{tieBreak: tb, clock: clock}
+	for _, t := range tasks {
+		for _, p := range t.Suspensions {
+			s.starts = append(s.starts, p.At)
+			clock.At(p.At)
+		}
+	}
+	sort.Slice(s.starts, func(i, j int) bool {
+		return s.starts[i] < s.starts[j]
+	})
+
+	return s
+}
+
+// hold takes t out of the simulation if it is suspended at now, and reports whether it is. It loses what is left
+// of its quantum.
+func (s *suspended) hold(t *task, now int64) bool {
+	until := t.suspendedUntil(now)
+	if until == now {
+		return false
+	}
+	t.resume, t.suspendedSince, t.used = until, now, 0
+	s.tasks = append(s.tasks, t)
+	s.clock.At(until)
+
+	return true
+}
+
+// starting reports whether a suspension begins at now, so running and ready tasks may have to be held.
+func (s *suspended) starting(now int64) bool {
+	i := sort.Search(len(s.starts), func(i int) bool {
+		return s.starts[i] >= now
+	})
+
+	return i < len(s.starts) && s.starts[i] == now
+}
+
+// resume returns the tasks that have resumed by now, in the order they resumed, with ties ordered by the
+// tie-break policy.
+func (s *suspended) resume(now int64) []*task {
+	sort.SliceStable(s.tasks, func(i, j int) bool {
+		if s.tasks[i].resume != s.tasks[j].resume {
+			return s.tasks[i].resume < s.tasks[j].resume
+		}
+		return s.tieBreak.before(&s.tasks[i].Process, &s.tasks[j].Process)
+	})
+	var resumed []*task
+	for len(s.tasks) > 0 && s.tasks[0].resume <= now {
+		t := s.tasks[0]
+		t.suspended += t.resume - t.suspendedSince
+		t.readySince = t.resume
+		resumed = append(resumed, t)
+		s.tasks = s.tasks[1:]
+	}
+
+	return resumed
+}
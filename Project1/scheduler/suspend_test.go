@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchedulers_suspend(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 6, Suspensions: []Suspension{{At: 2, For: 3}}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	runToCompletion := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 5, Stop: 9}}
+	// Process 1 is preempted by process 2's arrival, so is suspended while ready.
+	preempted := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 5, Stop: 10}}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		wantGantt []TimeSlice
+	}{
+		{name: "FCFS", scheduler: FCFS{}, wantGantt: runToCompletion},
+		{name: "SRTF", scheduler: SRTF{}, wantGantt: preempted},
+		{name: "Round-Robin", scheduler: RoundRobin{Quantum: 2}, wantGantt: runToCompletion},
+		{name: "MLQ", scheduler: MLQ{}, wantGantt: runToCompletion},
+		{name: "MLFQ", scheduler: MLFQ{Levels: []Level{{Discipline: RRQueue, Quantum: 2}, {Discipline: FCFSQueue}}}, wantGantt: runToCompletion},
+		{name: "Gang", scheduler: Gang{}, wantGantt: runToCompletion},
+		{name: "cgroups", scheduler: CgroupRoundRobin{}, wantGantt: preempted},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.scheduler.Schedule(processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			for _, row := range got.Processes {
+				if row.ProcessID == 1 && row.Suspended != 3 {
+					t.Errorf("Schedule() process 1 suspended for %d, want 3", row.Suspended)
+				}
+			}
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFCFS_Schedule_suspend(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		processes     []Process
+		wantGantt     []TimeSlice
+		wantWait      int64
+		wantSuspended int64
+	}{
+		{
+			name: "a process suspended while blocked on I/O stays suspended once the I/O completes",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Bursts: []int64{2, 3, 2}, Suspensions: []Suspension{{At: 3, For: 4}}},
+				{ProcessID: 2, BurstDuration: 3},
+			},
+			wantGantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 5}, {PID: 1, Start: 7, Stop: 9}},
+			wantSuspended: 2,
+		},
+		{
+			name: "a process suspended before it arrives is held from its arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 2, Suspensions: []Suspension{{At: 0, For: 4}}},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2},
+			},
+			wantGantt:     []TimeSlice{{PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 7}},
+			wantWait:      1,
+			wantSuspended: 2,
+		},
+		{
+			name: "overlapping suspensions run together",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, Suspensions: []Suspension{{At: 1, For: 2}, {At: 2, For: 3}, {At: 5, For: 1}}},
+			},
+			wantGantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 6, Stop: 8}},
+			wantSuspended: 5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := FCFS{}.Schedule(tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			for _, row := range got.Processes {
+				if row.ProcessID == 1 && (row.Wait != tt.wantWait || row.Suspended != tt.wantSuspended) {
+					t.Errorf("Schedule() process 1 waited %d and was suspended for %d, want %d and %d",
+						row.Wait, row.Suspended, tt.wantWait, tt.wantSuspended)
+				}
+			}
+			if err := got.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGang_Schedule_suspend(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Gang: 1, Suspensions: []Suspension{{At: 1, For: 2}}},
+		{ProcessID: 2, BurstDuration: 4, Gang: 1},
+	}
+	got := Gang{Cores: 2}.Schedule(processes)
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 0, Stop: 1, CPU: 1},
+		{PID: 1, Start: 3, Stop: 6},
+		{PID: 2, Start: 3, Stop: 6, CPU: 1},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("Schedule() gantt = %v, want %v", got.Gantt, want)
+	}
+	// Both CPUs idle while process 1 is suspended, holding up its sibling.
+	if got.Stats.Fragmentation != 4 {
+		t.Errorf("Schedule() fragmentation = %d, want 4", got.Stats.Fragmentation)
+	}
+	if err := got.Check(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TieBreak decides between processes that a scheduler ranks equally: ones arriving at the same time, equal
+// bursts under SJF, equal priorities, equal stride passes and so on.
+type TieBreak int
+
+const (
+	// TieFIFO keeps ready queue order, so simultaneous arrivals go in the order they were listed.
+	TieFIFO TieBreak = iota
+	// TieArrival prefers the process that arrived first, then the one with the lowest PID, as textbook
+	// worked examples do.
+	TieArrival
+)
+
+// Tie-break names, as accepted by ParseTieBreak.
+const (
+	TieFIFOName    = "fifo"
+	TieArrivalName = "arrival"
+)
+
+var ErrInvalidTieBreak = errors.New("invalid tie-break")
+
+// ParseTieBreak returns the named tie-break policy; empty means TieFIFO.
+func ParseTieBreak(name string) (TieBreak, error) {
+	switch name {
+	case "", TieFIFOName:
+		return TieFIFO, nil
+	case TieArrivalName:
+		return TieArrival, nil
+	default:
+		return TieFIFO, fmt.Errorf("%w: %q must be %s or %s", ErrInvalidTieBreak, name, TieFIFOName, TieArrivalName)
+	}
+}
+
+func (tb TieBreak) String() string {
+	if tb == TieArrival {
+		return TieArrivalName
+	}
+	return TieFIFOName
+}
+
+// before reports whether the tie between a and b goes to a. TieFIFO never decides, leaving the tie to queue
+// order.
+func (tb TieBreak) before(a, b *Process) bool {
+	if tb != TieArrival {
+		return false
+	}
+	if a.ArrivalTime != b.ArrivalTime {
+		return a.ArrivalTime < b.ArrivalTime
+	}
+	return a.ProcessID < b.ProcessID
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTieBreak(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		want    TieBreak
+		wantErr error
+	}{
+		{name: "", want: TieFIFO},
+		{name: "fifo", want: TieFIFO},
+		{name: "arrival", want: TieArrival},
+		{name: "pid", wantErr: ErrInvalidTieBreak},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTieBreak(tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTieBreak() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTieBreak() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// dispatchOrder returns the PIDs of a schedule in the order they first ran.
+func dispatchOrder(result ScheduleResult) []int64 {
+	var (
+		order []int64
+		seen  = make(map[int64]bool)
+	)
+	for _, s := range result.Gantt {
+		if !s.Switch && !seen[s.PID] {
+			seen[s.PID] = true
+			order = append(order, s.PID)
+		}
+	}
+
+	return order
+}
+
+func TestTieBreak_schedulers(t *testing.T) {
+	t.Parallel()
+	// Equal in every respect but their PIDs, and listed out of PID order.
+	processes := []Process{
+		{ProcessID: 3, BurstDuration: 2, Priority: 1},
+		{ProcessID: 1, BurstDuration: 2, Priority: 1},
+		{ProcessID: 2, BurstDuration: 2, Priority: 1},
+	}
+	tests := []struct {
+		name      string
+		scheduler func(TieBreak) Scheduler
+	}{
+		{name: "FCFS", scheduler: func(tb TieBreak) Scheduler { return FCFS{TieBreak: tb} }},
+		{name: "SJF", scheduler: func(tb TieBreak) Scheduler { return SJF{TieBreak: tb} }},
+		{name: "Predictive SJF", scheduler: func(tb TieBreak) Scheduler { return PredictiveSJF{TieBreak: tb} }},
+		{name: "SJF on 2 CPUs", scheduler: func(tb TieBreak) Scheduler { return SJF{Cores: 2, TieBreak: tb} }},
+		{name: "SRTF", scheduler: func(tb TieBreak) Scheduler { return SRTF{TieBreak: tb} }},
+		{name: "SJF Priority", scheduler: func(tb TieBreak) Scheduler { return SJFPriority{TieBreak: tb} }},
+		{name: "Aging", scheduler: func(tb TieBreak) Scheduler { return Aging{TieBreak: tb} }},
+		{name: "HRRN", scheduler: func(tb TieBreak) Scheduler { return HRRN{TieBreak: tb} }},
+		{name: "Round-Robin", scheduler: func(tb TieBreak) Scheduler { return RoundRobin{TieBreak: tb} }},
+		{name: "Stride", scheduler: func(tb TieBreak) Scheduler { return Stride{TieBreak: tb} }},
+		{name: "EDF", scheduler: func(tb TieBreak) Scheduler { return EDF{TieBreak: tb} }},
+		{name: "Rate-monotonic", scheduler: func(tb TieBreak) Scheduler { return RateMonotonic{TieBreak: tb} }},
+		{name: "MLQ", scheduler: func(tb TieBreak) Scheduler {
+			return MLQ{Queues: []Queue{{MinPriority: 1, MaxPriority: 1, Discipline: SJFQueue}}, TieBreak: tb}
+		}},
+		{name: "MLFQ", scheduler: func(tb TieBreak) Scheduler {
+			return MLFQ{Levels: []Level{{Discipline: SJFQueue, Allotment: 10}}, TieBreak: tb}
+		}},
+		{name: "Cgroup", scheduler: func(tb TieBreak) Scheduler { return CgroupRoundRobin{TieBreak: tb} }},
+	}
+	want := map[TieBreak][]int64{
+		TieFIFO:    {3, 1, 2},
+		TieArrival: {1, 2, 3},
+	}
+	for _, tt := range tests {
+		for _, tb := range []TieBreak{TieFIFO, TieArrival} {
+			tt, tb := tt, tb
+			t.Run(tt.name+" "+tb.String(), func(t *testing.T) {
+				t.Parallel()
+				input := append([]Process(nil), processes...)
+				if got := dispatchOrder(tt.scheduler(tb).Schedule(input)); !reflect.DeepEqual(got, want[tb]) {
+					t.Errorf("dispatch order = %v, want %v", got, want[tb])
+				}
+			})
+		}
+	}
+}
+
+func TestTieBreak_stream(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 3, BurstDuration: 1},
+		{ProcessID: 1, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 5, BurstDuration: 1},
+		{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+	}
+	for tb, want := range map[TieBreak][]int64{TieFIFO: {3, 1, 4, 2}, TieArrival: {1, 3, 2, 4}} {
+		var got []int64
+		_, err := FCFS{TieBreak: tb}.Stream(processStream(processes), func(row ProcessResult) error {
+			got = append(got, row.ProcessID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: Stream() order = %v, want %v", tb, got, want)
+		}
+		if schedule := dispatchOrder(FCFS{TieBreak: tb}.Schedule(processes)); !reflect.DeepEqual(schedule, want) {
+			t.Errorf("%v: Schedule() order = %v, want %v", tb, schedule, want)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+const (
+	// maxRequestBytes bounds the size of a request to the HTTP API.
+	maxRequestBytes = 10 << 20
+	// maxServeProcesses bounds the number of processes in a workload the HTTP API schedules.
+	maxServeProcesses = 1000
+	// maxServeBurst bounds the total CPU time of a workload the HTTP API schedules, as the time and memory a
+	// simulation takes grow with it.
+	maxServeBurst = 1_000_000
+	// maxServeHorizon bounds the simulated time a workload the HTTP API schedules can span: its last arrival or
+	// suspension, plus all its CPU, I/O and suspended time.
+	maxServeHorizon = 10_000_000
+	// maxServeCores bounds the number of CPUs the HTTP API simulates, as every step of a simulation visits each one.
+	maxServeCores = 64
+)
+
+// indexHTML is the web page served at /, a form for scheduling a workload that charts the schedules.
+//
+//go:embed web/index.html
+var indexHTML []byte
+
+// scheduleRequest is the body of a POST /schedule request: a workload, as processes or the text of a CSV process
+// file, and the settings to schedule it with. Settings left out take the command line's defaults, except the
+// seed, which defaults to 1 so that responses are reproducible.
+type scheduleRequest struct {
+	Processes  []workloadProcess `json:"processes,omitempty"`
+	CSV        string            `json:"csv,omitempty"`
+	Events     []workloadEvent   `json:"events,omitempty"`
+	Algorithms []string          `json:"algorithms,omitempty"`
+	Quantum    int64             `json:"quantum"`
+	Cores      int               `json:"cores"`
+	SwitchCost int64             `json:"switch_cost"`
+	Aging      int64             `json:"aging"`
+	Seed       int64             `json:"seed"`
+	MLQ        string            `json:"mlq,omitempty"`
+	MLQSlices  string            `json:"mlq_slices,omitempty"`
+	MLFQ       string            `json:"mlfq,omitempty"`
+	MLFQBoost  int64             `json:"mlfq_boost,omitempty"`
+	TieBreak   string            `json:"tie_break,omitempty"`
+	// PredictInitial defaults to 10, like -predict-initial.
+	PredictAlpha   float64 `json:"predict_alpha,omitempty"`
+	PredictInitial float64 `json:"predict_initial,omitempty"`
+	Energy         string  `json:"energy,omitempty"`
+	Frequency      float64 `json:"frequency,omitempty"`
+	SleepPower     float64 `json:"sleep_power,omitempty"`
+	Check          bool    `json:"check,omitempty"`
+}
+
+// scheduleResponse is the body of a successful POST /schedule response, with the JSON report of each
+// algorithm's schedule in order.
+type scheduleResponse struct {
+	Schedules []jsonReport `json:"schedules"`
+}
+
+// errorResponse is the body of a failed request.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+//region Serving the HTTP API
+
+// runServe runs the serve subcommand, serving the HTTP API on -addr until it fails.
+func runServe(args []string, w io.Writer) error {
+	var (
+		fs         = flag.NewFlagSet("serve", flag.ContinueOnError)
+		addr       string
+		concurrent int
+	)
+	fs.StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+	fs.IntVar(&concurrent, "concurrent", runtime.GOMAXPROCS(0), "most schedule requests to work on at once")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if concurrent < 1 {
+		return fmt.Errorf("%w: -concurrent must be at least 1", ErrInvalidArgs)
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           apiHandler(concurrent),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	_, _ = fmt.Fprintf(w, "Serving the scheduler on http://%s\n", addr)
+
+	return server.ListenAndServe()
+}
+
+// apiHandler routes the HTTP API:
+//
+//	GET  /            the web page
+//	POST /schedule    schedules the workload of a scheduleRequest, responding with a scheduleResponse
+//	GET  /algorithms  lists the algorithms that can be requested, built-in and custom
+//	GET  /metrics     reports the handler's metrics in the Prometheus text format
+//
+// Errors are responded to with an errorResponse. At most concurrent schedule requests are worked on at once, and
+// the ones beyond that are turned away with 503 Service Unavailable rather than queued, so a burst of them can't
+// pile up.
+func apiHandler(concurrent int) http.Handler {
+	var (
+		mux   = http.NewServeMux()
+		m     = newMetrics()
+		slots = make(chan struct{}, concurrent)
+	)
+	mux.HandleFunc("/", m.instrument("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(indexHTML)
+	}))
+	mux.HandleFunc("/schedule", m.instrument("/schedule", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "use POST"})
+			return
+		}
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		default:
+			w.Header().Set("Retry-After", "1")
+			writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "too many schedules running, try again"})
+			return
+		}
+		resp, err := serveSchedule(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		switch {
+		case errors.Is(err, scheduler.ErrInvariant):
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		case err != nil:
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		default:
+			m.observeSchedules(resp.Schedules)
+			writeJSON(w, http.StatusOK, resp)
+		}
+	}))
+	mux.HandleFunc("/algorithms", m.instrument("/algorithms", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "use GET"})
+			return
+		}
+		writeJSON(w, http.StatusOK, append(append([]string(nil), builtinAlgorithms...), scheduler.Registered.Names()...))
+	}))
+	mux.HandleFunc("/metrics", m.instrument("/metrics", m.ServeHTTP))
+
+	return mux
+}
+
+// serveSchedule decodes a scheduleRequest from r and schedules its workload.
+func serveSchedule(r io.Reader) (scheduleResponse, error) {
+	req := scheduleRequest{Quantum: 1, Cores: 1, Aging: 5, Seed: 1, PredictInitial: 10}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return scheduleResponse{}, fmt.Errorf("%w: reading JSON", err)
+	}
+	var (
+		processes []scheduler.Process
+		err       error
+	)
+	switch {
+	case req.CSV != "" && len(req.Processes) > 0:
+		return scheduleResponse{}, fmt.Errorf("%w: give either processes or csv, not both", ErrInvalidArgs)
+	case req.CSV != "":
+		processes, err = loadProcesses(strings.NewReader(req.CSV))
+	default:
+		processes, err = workloadProcesses(req.Processes)
+	}
+	if err == nil {
+		err = applyEvents(processes, req.Events)
+	}
+	if err != nil {
+		return scheduleResponse{}, err
+	}
+	if err := checkServeLimits(processes, req.Cores); err != nil {
+		return scheduleResponse{}, err
+	}
+	s := settings{
+		Quantum:        req.Quantum,
+		Cores:          req.Cores,
+		SwitchCost:     req.SwitchCost,
+		Aging:          req.Aging,
+		Seed:           req.Seed,
+		MLQ:            req.MLQ,
+		MLQSlices:      req.MLQSlices,
+		MLFQ:           req.MLFQ,
+		MLFQBoost:      req.MLFQBoost,
+		TieBreak:       req.TieBreak,
+		PredictAlpha:   req.PredictAlpha,
+		PredictInitial: req.PredictInitial,
+		Energy:         req.Energy,
+		Frequency:      req.Frequency,
+		SleepPower:     req.SleepPower,
+		Check:          req.Check,
+		Algorithms:     req.Algorithms,
+	}
+	if err := s.validate(); err != nil {
+		return scheduleResponse{}, err
+	}
+	algorithms := s.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms(processes, s)
+	}
+	schedules, err := scheduleAll(processes, algorithms, s)
+	if err != nil {
+		return scheduleResponse{}, err
+	}
+	resp := scheduleResponse{Schedules: make([]jsonReport, len(schedules))}
+	for i, sc := range schedules {
+		resp.Schedules[i] = newJSONReport(sc.name, sc.title, sc.result)
+	}
+
+	return resp, nil
+}
+
+// checkServeLimits checks that a workload isn't empty, and is small enough, in processes, CPU time and the time it
+// spans, for the HTTP API to schedule on cores CPUs, which mustn't be too many to simulate. The hyperperiod of a
+// Rate-Monotonic workload is bounded when it's scheduled.
+func checkServeLimits(processes []scheduler.Process, cores int) error {
+	switch {
+	case cores > maxServeCores:
+		return fmt.Errorf("%w: %d cores, more than the %d the server simulates", ErrInvalidArgs, cores,
+			maxServeCores)
+	case len(processes) == 0:
+		return fmt.Errorf("%w: no processes to schedule", ErrInvalidArgs)
+	case len(processes) > maxServeProcesses:
+		return fmt.Errorf("%w: %d processes, more than the %d the server schedules", ErrInvalidArgs,
+			len(processes), maxServeProcesses)
+	}
+	var total int64
+	for i := range processes {
+		// Subtracting keeps the sum from overflowing.
+		if processes[i].BurstDuration > maxServeBurst-total {
+			return fmt.Errorf("%w: the processes need more than the %d units of CPU time the server schedules",
+				ErrInvalidArgs, maxServeBurst)
+		}
+		total += processes[i].BurstDuration
+	}
+	var latest, span int64
+	// extend adds d to span, reporting false once it is over the horizon.
+	extend := func(d int64) bool {
+		if d > maxServeHorizon-span {
+			return false
+		}
+		span += d
+		return true
+	}
+	ok := true
+	for i := range processes {
+		p := &processes[i]
+		if p.ArrivalTime > latest {
+			latest = p.ArrivalTime
+		}
+		ok = ok && extend(p.BurstDuration)
+		for j := 1; j < len(p.Bursts); j += 2 {
+			ok = ok && extend(p.Bursts[j])
+		}
+		for _, sus := range p.Suspensions {
+			if sus.At > latest {
+				latest = sus.At
+			}
+			ok = ok && extend(sus.For)
+		}
+	}
+	if !ok || !extend(latest) {
+		return fmt.Errorf("%w: the processes span more than the %d units of time the server simulates",
+			ErrInvalidArgs, maxServeHorizon)
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+//endregion
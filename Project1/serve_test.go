@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_apiHandler_schedule(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(apiHandler(1))
+	t.Cleanup(server.Close)
+
+	body := `{"processes": [{"pid": 1, "burst": 5}, {"pid": 2, "burst": 2, "arrival": 1}],
+		"algorithms": ["fcfs", "rr"], "quantum": 2, "check": true}`
+	resp, err := http.Post(server.URL+"/schedule", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /schedule status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("POST /schedule Content-Type = %q, want application/json", got)
+	}
+	var got scheduleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Schedules) != 2 {
+		t.Fatalf("POST /schedule returned %d schedules, want 2", len(got.Schedules))
+	}
+	for i, want := range []struct {
+		algorithm string
+		aveWait   float64
+	}{
+		{algorithm: "fcfs", aveWait: 2},
+		{algorithm: "rr", aveWait: 1.5},
+	} {
+		if sc := got.Schedules[i]; sc.Algorithm != want.algorithm || sc.Stats.AveWait != want.aveWait {
+			t.Errorf("schedule %d = %s with average wait %v, want %s with %v",
+				i, sc.Algorithm, sc.Stats.AveWait, want.algorithm, want.aveWait)
+		}
+	}
+}
+
+func Test_apiHandler_errors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(apiHandler(1))
+	t.Cleanup(server.Close)
+	var many strings.Builder
+	for pid := 1; pid <= maxServeProcesses+1; pid++ {
+		fmt.Fprintf(&many, "%d,1,0,0\n", pid)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantMsg    string // part of the error message
+	}{
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			path:       "/schedule",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantMsg:    "use POST",
+		},
+		{
+			name:       "bad JSON",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "reading JSON",
+		},
+		{
+			name:       "unknown field",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}], "quantom": 2}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "quantom",
+		},
+		{
+			name:       "processes and CSV",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}], "csv": "1,5,0,2"}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "not both",
+		},
+		{
+			name:       "invalid CSV",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"csv": "1,5,0,2\n1,9,3,1"}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "PID 1 is already used on line 1",
+		},
+		{
+			name:       "no processes",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "no processes to schedule",
+		},
+		{
+			name:       "too many processes",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       fmt.Sprintf(`{"csv": %q}`, many.String()),
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "1001 processes",
+		},
+		{
+			name:       "too much CPU time",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 9000000000000000000}, {"pid": 2, "burst": 9000000000000000000}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "units of CPU time",
+		},
+		{
+			name:       "arrives too late",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 1, "arrival": 3000000000}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "units of time the server simulates",
+		},
+		{
+			name:       "suspended too long",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}], "events": [{"pid": 1, "at": 1, "for": 9000000000000000000}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "units of time the server simulates",
+		},
+		{
+			name:       "too many cores",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}, {"pid": 2, "burst": 3}], "cores": 20000000}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "20000000 cores",
+		},
+		{
+			name:       "hyperperiod too long",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 1, "period": 50021}, {"pid": 2, "burst": 1, "period": 50023}], "algorithms": ["rm"]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    scheduler.ErrTooManyJobs.Error(),
+		},
+		{
+			name:       "invalid process",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}, {"pid": 1, "burst": 2}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    ErrInvalidProcess.Error(),
+		},
+		{
+			name:       "invalid event",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}], "events": [{"pid": 3, "at": 1, "for": 2}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    ErrInvalidEvent.Error(),
+		},
+		{
+			name:       "invalid settings",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}], "quantum": 0}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    ErrInvalidArgs.Error(),
+		},
+		{
+			name:       "unknown algorithm",
+			method:     http.MethodPost,
+			path:       "/schedule",
+			body:       `{"processes": [{"pid": 1, "burst": 5}], "algorithms": ["ljf"]}`,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "ljf",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var got errorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus || !strings.Contains(got.Error, tt.wantMsg) {
+				t.Errorf("%s %s = %d %q, want %d ...%s", tt.method, tt.path, resp.StatusCode, got.Error, tt.wantStatus, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func Test_apiHandler_busy(t *testing.T) {
+	t.Parallel()
+	// With no slots, every schedule request is turned away as if others were running.
+	rec := httptest.NewRecorder()
+	body := `{"processes": [{"pid": 1, "burst": 5}]}`
+	apiHandler(0).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/schedule", strings.NewReader(body)))
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("POST /schedule when busy = %d with Retry-After %q, want %d with one",
+			rec.Code, rec.Header().Get("Retry-After"), http.StatusServiceUnavailable)
+	}
+}
+
+func Test_apiHandler_csv(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	body := `{"csv": "1,5,0,2\n2,9,3,1\n3,6,6,3", "algorithms": ["fcfs"]}`
+	apiHandler(1).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/schedule", strings.NewReader(body)))
+	var got scheduleResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || len(got.Schedules) != 1 || len(got.Schedules[0].Processes) != 3 {
+		t.Errorf("POST /schedule = %d %+v, want the FCFS schedule of 3 processes", rec.Code, got)
+	}
+}
+
+func Test_apiHandler_events(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	body := `{"csv": "1,5,0,2\n2,9,3,1", "events": [{"pid": 1, "at": 2, "for": 4}], "algorithms": ["fcfs"]}`
+	apiHandler(1).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/schedule", strings.NewReader(body)))
+	var got scheduleResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || len(got.Schedules) != 1 || got.Schedules[0].Processes[1].Suspended != 4 {
+		t.Errorf("POST /schedule = %d %+v, want process 1 suspended for 4", rec.Code, got)
+	}
+}
+
+func Test_apiHandler_page(t *testing.T) {
+	t.Parallel()
+	handler := apiHandler(1)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") ||
+		!strings.Contains(rec.Body.String(), "<title>Process Scheduler</title>") {
+		t.Errorf("GET / = %d %s, want the web page", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /missing = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func Test_apiHandler_algorithms(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(apiHandler(1))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/algorithms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var got []string
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) < len(builtinAlgorithms) || got[0] != builtinAlgorithms[0] {
+		t.Errorf("GET /algorithms = %v, want the built-in algorithms %v first", got, builtinAlgorithms)
+	}
+}
+
+func Test_apiHandler_metrics(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(apiHandler(1))
+	t.Cleanup(server.Close)
+
+	for _, body := range []string{
+		`{"processes": [{"pid": 1, "burst": 5}, {"pid": 2, "burst": 2, "arrival": 1}], "algorithms": ["fcfs", "rr"], "quantum": 2}`,
+		`{"algorithms": ["fcfs"]}`,
+	} {
+		resp, err := http.Post(server.URL+"/schedule", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("GET /metrics Content-Type = %q, want the Prometheus text format", got)
+	}
+	var b strings.Builder
+	if _, err := io.Copy(&b, resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"# TYPE scheduler_http_requests_total counter\n",
+		"scheduler_http_requests_total{code=\"200\",path=\"/schedule\"} 1\n",
+		"scheduler_http_requests_total{code=\"400\",path=\"/schedule\"} 1\n",
+		"# TYPE scheduler_http_request_duration_seconds histogram\n",
+		"scheduler_http_request_duration_seconds_bucket{path=\"/schedule\",le=\"+Inf\"} 2\n",
+		"scheduler_http_request_duration_seconds_count{path=\"/schedule\"} 2\n",
+		"scheduler_simulations_total{algorithm=\"fcfs\"} 1\n",
+		"scheduler_simulations_total{algorithm=\"rr\"} 1\n",
+		"scheduler_simulation_processes_bucket{le=\"1\"} 0\n",
+		"scheduler_simulation_processes_bucket{le=\"10\"} 2\n",
+		"scheduler_simulation_processes_sum 4\n",
+		"scheduler_recent_average_wait{algorithm=\"rr\"} 1.5\n",
+		"scheduler_recent_average_turnaround{algorithm=\"fcfs\"} 5.5\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GET /metrics is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func Test_metrics_recent(t *testing.T) {
+	t.Parallel()
+	m := newMetrics()
+	for i := 0; i <= recentRuns; i++ {
+		m.observeSchedules([]jsonReport{{Algorithm: "rr", Stats: jsonStats{AveWait: float64(i)}}})
+	}
+	var b strings.Builder
+	m.write(&b)
+	// The first run, with a wait of 0, has dropped out of the recent ones, 1 to 100.
+	if want := "scheduler_recent_average_wait{algorithm=\"rr\"} 50.5\n"; !strings.Contains(b.String(), want) {
+		t.Errorf("write() is missing %q:\n%s", want, b.String())
+	}
+	if want := "scheduler_simulations_total{algorithm=\"rr\"} 101\n"; !strings.Contains(b.String(), want) {
+		t.Errorf("write() is missing %q:\n%s", want, b.String())
+	}
+}
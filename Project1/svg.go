@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// Layout of SVG GANTT charts, in pixels.
+const (
+	svgWidth     = 960
+	svgMargin    = 50
+	svgRowHeight = 32
+	svgRowGap    = 8
+	svgTitleSize = 30
+	svgAxisSize  = 24
+)
+
+// svgColors are the fills of processes' bars, chosen by PID.
+var svgColors = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+//region SVG charts
+
+// outputGanttSVG writes a schedule's GANTT chart as an SVG image: a row of bars per CPU, with widths proportional
+// to their durations, and a tooltip on each bar giving its start and stop.
+func outputGanttSVG(w io.Writer, _, title string, result scheduler.ScheduleResult) error {
+	var (
+		names  = processNames(result.Processes)
+		cpus   = 1
+		length int64
+	)
+	for _, s := range result.Gantt {
+		if s.CPU >= cpus {
+			cpus = s.CPU + 1
+		}
+		if s.Stop > length {
+			length = s.Stop
+		}
+	}
+	if length == 0 {
+		length = 1
+	}
+	var (
+		scale  = float64(svgWidth-2*svgMargin) / float64(length)
+		x      = func(t int64) float64 { return svgMargin + float64(t)*scale }
+		axis   = svgTitleSize + cpus*(svgRowHeight+svgRowGap)
+		height = axis + svgAxisSize
+	)
+
+	_, _ = fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"sans-serif\" font-size=\"12\">\n",
+		svgWidth, height)
+	_, _ = fmt.Fprintf(w, "<title>%s</title>\n", html.EscapeString(title))
+	_, _ = fmt.Fprintf(w, "<text x=\"%d\" y=\"20\" font-size=\"16\">%s</text>\n", svgMargin, html.EscapeString(title))
+	for cpu := 0; cpu < cpus; cpu++ {
+		y := svgTitleSize + cpu*(svgRowHeight+svgRowGap)
+		_, _ = fmt.Fprintf(w, "<text x=\"%d\" y=\"%d\" text-anchor=\"end\">CPU %d</text>\n",
+			svgMargin-6, y+svgRowHeight/2+4, cpu)
+		// Idle time shows through as the row's background.
+		_, _ = fmt.Fprintf(w, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"#f4f4f4\"/>\n",
+			svgMargin, y, svgWidth-2*svgMargin, svgRowHeight)
+		for _, s := range result.Gantt {
+			if s.CPU != cpu {
+				continue
+			}
+			var (
+				left, width = x(s.Start), float64(s.Stop-s.Start) * scale
+				text        = label(s.PID, names)
+				fill        = pidColor(svgColors, s.PID)
+				tooltip     = fmt.Sprintf("%s: %d-%d", text, s.Start, s.Stop)
+			)
+			if s.Switch {
+				text, fill = "cs", "#777777"
+				tooltip = fmt.Sprintf("context switch to %s: %d-%d", label(s.PID, names), s.Start, s.Stop)
+			}
+			_, _ = fmt.Fprintf(w, "<g><title>%s</title>", html.EscapeString(tooltip))
+			_, _ = fmt.Fprintf(w, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" fill=\"%s\" stroke=\"#ffffff\"/>",
+				left, y, width, svgRowHeight, fill)
+			// Only label bars wide enough to hold it.
+			if width >= float64(7*len(text)+4) {
+				_, _ = fmt.Fprintf(w, "<text x=\"%.1f\" y=\"%d\" text-anchor=\"middle\" fill=\"#ffffff\">%s</text>",
+					left+width/2, y+svgRowHeight/2+4, html.EscapeString(text))
+			}
+			_, _ = fmt.Fprintln(w, "</g>")
+		}
+	}
+
+	// Time axis
+	step := svgTickStep(length)
+	for t := int64(0); t <= length; t += step {
+		_, _ = fmt.Fprintf(w, "<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\" stroke=\"#999999\"/>",
+			x(t), axis-svgRowGap, x(t), axis-2)
+		_, _ = fmt.Fprintf(w, "<text x=\"%.1f\" y=\"%d\" text-anchor=\"middle\">%d</text>\n", x(t), axis+12, t)
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+
+	return err
+}
+
+// svgTickStep returns the time between ticks of an axis of the given length: a 1, 2 or 5 times power of ten
+// giving at most 20 ticks.
+func svgTickStep(length int64) int64 {
+	for step := int64(1); ; step *= 10 {
+		for _, m := range []int64{1, 2, 5} {
+			if length/(step*m) <= 20 {
+				return step * m
+			}
+		}
+	}
+}
+
+// htmlRenderer returns a renderer writing schedules' SVG GANTT charts into an HTML page. The page's head is
+// only written once per writer, so the charts of several algorithms written to the same output make one page.
+func htmlRenderer() renderer {
+	headed := make(map[io.Writer]bool)
+	return func(w io.Writer, name, title string, result scheduler.ScheduleResult) error {
+		if !headed[w] {
+			headed[w] = true
+			_, _ = fmt.Fprint(w, "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>Schedules</title></head>\n<body>\n")
+		}
+		_, _ = fmt.Fprintf(w, "<section id=\"%s\">\n", html.EscapeString(name))
+		if err := outputGanttSVG(w, name, title, result); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(w, "</section>")
+
+		return err
+	}
+}
+
+//endregion
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_svgTickStep(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		length int64
+		want   int64
+	}{
+		{length: 1, want: 1},
+		{length: 20, want: 1},
+		{length: 21, want: 2},
+		{length: 90, want: 5},
+		{length: 150, want: 10},
+		{length: 4000, want: 200},
+	}
+	for _, tt := range tests {
+		if got := svgTickStep(tt.length); got != tt.want {
+			t.Errorf("svgTickStep(%d) = %d, want %d", tt.length, got, tt.want)
+		}
+	}
+}
+
+func Test_outputGanttSVG(t *testing.T) {
+	t.Parallel()
+	result := scheduler.RoundRobin{Quantum: 2, Cores: 2, SwitchCost: 1}.Schedule([]scheduler.Process{
+		{ProcessID: 1, Name: "a<b", BurstDuration: 5},
+		{ProcessID: 2, BurstDuration: 3},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 1},
+	})
+	var out bytes.Buffer
+	if err := outputGanttSVG(&out, algoRoundRobin, "Round-Robin & co", result); err != nil {
+		t.Fatal(err)
+	}
+
+	// The chart is well-formed XML with a tooltip per slice.
+	var svg struct {
+		XMLName xml.Name `xml:"svg"`
+		Title   string   `xml:"title"`
+		Bars    []struct {
+			Title string `xml:"title"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal(out.Bytes(), &svg); err != nil {
+		t.Fatalf("%v:\n%s", err, out.String())
+	}
+	if svg.Title != "Round-Robin & co" {
+		t.Errorf("title = %q", svg.Title)
+	}
+	if len(svg.Bars) != len(result.Gantt) {
+		t.Fatalf("got %d bars, want one per slice: %v", len(svg.Bars), result.Gantt)
+	}
+	for i, s := range result.Gantt {
+		if s.PID == 1 && !s.Switch && !strings.HasPrefix(svg.Bars[i].Title, "a<b: ") {
+			t.Errorf("bar %d has tooltip %q", i, svg.Bars[i].Title)
+		}
+	}
+	if !strings.Contains(out.String(), ">CPU 1</text>") {
+		t.Errorf("chart has no row for CPU 1:\n%s", out.String())
+	}
+}
+
+func Test_outputGanttSVG_negativePID(t *testing.T) {
+	t.Parallel()
+	result := scheduler.FCFS{}.Schedule([]scheduler.Process{
+		{ProcessID: -1, BurstDuration: 5},
+	})
+	var out bytes.Buffer
+	if err := outputGanttSVG(&out, algoFCFS, "FCFS", result); err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("fill=%q", svgColors[len(svgColors)-1]); !strings.Contains(out.String(), want) {
+		t.Errorf("chart has no bar with %s:\n%s", want, out.String())
+	}
+}
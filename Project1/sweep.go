@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// sweepPlotWidth is the width, in characters, of the longest bar of a quantum sweep's plot.
+const sweepPlotWidth = 50
+
+// maxSweepQuanta is the most quanta a sweep runs, each a schedule of its own.
+const maxSweepQuanta = 10_000
+
+// sweepPoint is the outcome of Round-Robin with one quantum.
+type sweepPoint struct {
+	Quantum       int64
+	AveWait       float64
+	AveTurnaround float64
+	Switches      int
+}
+
+//region Quantum sweeps
+
+// parseSweep parses a quantum sweep of the form min:max, of at most maxSweepQuanta quanta.
+func parseSweep(s string) (int64, int64, error) {
+	lo, hi, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: quantum sweep %q must be min:max", ErrInvalidArgs, s)
+	}
+	min, err := strconv.ParseInt(lo, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: quantum sweep %q: %v", ErrInvalidArgs, s, err)
+	}
+	max, err := strconv.ParseInt(hi, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: quantum sweep %q: %v", ErrInvalidArgs, s, err)
+	}
+	if min < 1 || max < min {
+		return 0, 0, fmt.Errorf("%w: quantum sweep %q needs 1 <= min <= max", ErrInvalidArgs, s)
+	}
+	if max-min >= maxSweepQuanta {
+		return 0, 0, fmt.Errorf("%w: quantum sweep %q runs more than %d quanta", ErrInvalidArgs, s, maxSweepQuanta)
+	}
+
+	return min, max, nil
+}
+
+// quantumSweep schedules processes under Round-Robin with each quantum from lo to hi in parallel, with the
+// cores and switch cost of s.
+func quantumSweep(processes []scheduler.Process, s settings, lo, hi int64) []sweepPoint {
+	var (
+		points = make([]sweepPoint, hi-lo+1)
+		wg     sync.WaitGroup
+		// slots bounds the schedules running at once, and so the copies of processes, for wide sweeps.
+		slots = make(chan struct{}, runtime.GOMAXPROCS(0))
+	)
+	for i := range points {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, processes []scheduler.Process) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			q := lo + int64(i)
+			rr := scheduler.RoundRobin{Quantum: q, Cores: s.Cores, SwitchCost: s.SwitchCost, TieBreak: s.tieBreak()}
+			result := rr.Schedule(processes)
+			points[i] = sweepPoint{
+				Quantum:       q,
+				AveWait:       result.Stats.AveWait,
+				AveTurnaround: result.Stats.AveTurnaround,
+				Switches:      processChanges(result.Gantt),
+			}
+		}(i, append([]scheduler.Process(nil), processes...))
+	}
+	wg.Wait()
+
+	return points
+}
+
+// processChanges counts the times a CPU changes process in gantt: the context switches, whether or not they cost
+// anything.
+func processChanges(gantt []scheduler.TimeSlice) int {
+	var (
+		changes int
+		last    = make(map[int]int64)
+	)
+	for _, slice := range gantt {
+		if slice.Switch {
+			continue
+		}
+		if pid, ok := last[slice.CPU]; ok && pid != slice.PID {
+			changes++
+		}
+		last[slice.CPU] = slice.PID
+	}
+
+	return changes
+}
+
+// runSweep writes the quantum sweep of s for processes to w, as CSV for CSV output and otherwise as a table and
+// a plot.
+func runSweep(w io.Writer, processes []scheduler.Process, s settings) error {
+	lo, hi, err := parseSweep(s.QuantumSweep)
+	if err != nil {
+		return err
+	}
+	points := quantumSweep(processes, s, lo, hi)
+	if s.Output == outputCSV {
+		return outputSweepCSV(w, points)
+	}
+	outputSweep(w, points)
+
+	return nil
+}
+
+func outputSweep(w io.Writer, points []sweepPoint) {
+	outputTitle(w, "Round-Robin quantum sweep")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Quantum", "Ave Wait", "Ave Turnaround", "Switches"})
+	best := 0
+	for i, p := range points {
+		if p.AveWait < points[best].AveWait {
+			best = i
+		}
+		table.Append([]string{
+			strconv.FormatInt(p.Quantum, 10),
+			fmt.Sprintf("%.2f", p.AveWait),
+			fmt.Sprintf("%.2f", p.AveTurnaround),
+			strconv.Itoa(p.Switches),
+		})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Lowest average wait: %.2f at quantum %d\n\n", points[best].AveWait, points[best].Quantum)
+	outputSweepPlot(w, points)
+}
+
+// outputSweepPlot plots each quantum's average turnaround as a bar, with the part of it spent waiting drawn as
+// # and the rest as =.
+func outputSweepPlot(w io.Writer, points []sweepPoint) {
+	var longest float64
+	for _, p := range points {
+		if p.AveTurnaround > longest {
+			longest = p.AveTurnaround
+		}
+	}
+	if longest == 0 {
+		longest = 1
+	}
+	_, _ = fmt.Fprintln(w, "Average wait (#) and turnaround (#=) by quantum")
+	for _, p := range points {
+		wait := int(p.AveWait / longest * sweepPlotWidth)
+		turnaround := int(p.AveTurnaround / longest * sweepPlotWidth)
+		_, _ = fmt.Fprintf(w, "%4d | %s%s %.2f\n", p.Quantum,
+			strings.Repeat("#", wait), strings.Repeat("=", turnaround-wait), p.AveTurnaround)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func outputSweepCSV(w io.Writer, points []sweepPoint) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"quantum", "average_wait", "average_turnaround", "switches"})
+	for _, p := range points {
+		_ = cw.Write([]string{
+			strconv.FormatInt(p.Quantum, 10),
+			strconv.FormatFloat(p.AveWait, 'f', 2, 64),
+			strconv.FormatFloat(p.AveTurnaround, 'f', 2, 64),
+			strconv.Itoa(p.Switches),
+		})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+//endregion
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_parseSweep(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		sweep          string
+		wantLo, wantHi int64
+		wantErr        error
+	}{
+		{name: "range", sweep: "1:20", wantLo: 1, wantHi: 20},
+		{name: "one quantum", sweep: "4:4", wantLo: 4, wantHi: 4},
+		{name: "no separator", sweep: "1-20", wantErr: ErrInvalidArgs},
+		{name: "not a number", sweep: "1:x", wantErr: ErrInvalidArgs},
+		{name: "backwards", sweep: "5:2", wantErr: ErrInvalidArgs},
+		{name: "zero quantum", sweep: "0:2", wantErr: ErrInvalidArgs},
+		{name: "too many quanta", sweep: "1:100000000000", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lo, hi, err := parseSweep(tt.sweep)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if lo != tt.wantLo || hi != tt.wantHi {
+				t.Errorf("parseSweep() = %d, %d, want %d, %d", lo, hi, tt.wantLo, tt.wantHi)
+			}
+		})
+	}
+}
+
+func Test_quantumSweep(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 1},
+	}
+	got := quantumSweep(processes, settings{Cores: 1}, 1, 4)
+	// The short process waits out the long one's first slice, so the wait grows with the quantum until the
+	// long process runs to completion in one slice.
+	want := []sweepPoint{
+		{Quantum: 1, AveWait: 1, AveTurnaround: 3.5, Switches: 2},
+		{Quantum: 2, AveWait: 1.5, AveTurnaround: 4, Switches: 2},
+		{Quantum: 3, AveWait: 2, AveTurnaround: 4.5, Switches: 2},
+		{Quantum: 4, AveWait: 2, AveTurnaround: 4.5, Switches: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("quantumSweep() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_runSweep(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 1},
+	}
+	var out bytes.Buffer
+	if err := runSweep(&out, processes, settings{Cores: 1, QuantumSweep: "1:2", Output: outputCSV}); err != nil {
+		t.Fatal(err)
+	}
+	want := "quantum,average_wait,average_turnaround,switches\n1,1.00,3.50,2\n2,1.50,4.00,2\n"
+	if got := out.String(); got != want {
+		t.Errorf("runSweep() =\n%s\nwant\n%s", got, want)
+	}
+
+	out.Reset()
+	if err := runSweep(&out, processes, settings{Cores: 1, QuantumSweep: "1:2"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Lowest average wait: 1.00 at quantum 1", "   1 | ############=============================== 3.50"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runSweep() =\n%s\nwant it to contain %q", out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/concurrency"
+)
+
+// syncTitles holds the report title of each synchronization scenario.
+var syncTitles = map[string]string{
+	concurrency.BoundedBuffer:      "Bounded buffer",
+	concurrency.ReadersWriters:     "Readers-writers",
+	concurrency.DiningPhilosophers: "Dining philosophers",
+}
+
+//region Simulating synchronization
+
+// runSyncsim runs the syncsim subcommand: it simulates a synchronization scenario under each of its strategies,
+// and writes the operations and waits of each thread and role, and the deadlock it ended in if it did, to w,
+// followed by a comparison of the strategies when there are several.
+func runSyncsim(args []string, w io.Writer) error {
+	var (
+		fs         = flag.NewFlagSet("syncsim", flag.ContinueOnError)
+		scenario   string
+		strategies string
+		work       string
+		hold       string
+		c          concurrency.Config
+	)
+	fs.StringVar(&scenario, "scenario", concurrency.BoundedBuffer, "scenario to simulate, bounded-buffer, readers-writers or philosophers")
+	fs.StringVar(&strategies, "strategy", "", "comma separated strategies to compare; all of the scenario's if empty")
+	fs.IntVar(&c.Producers, "producers", 2, "number of bounded-buffer producers")
+	fs.IntVar(&c.Consumers, "consumers", 2, "number of bounded-buffer consumers")
+	fs.Int64Var(&c.Capacity, "capacity", 5, "number of slots in the bounded buffer")
+	fs.IntVar(&c.Readers, "readers", 3, "number of readers-writers readers")
+	fs.IntVar(&c.Writers, "writers", 2, "number of readers-writers writers")
+	fs.IntVar(&c.Philosophers, "philosophers", 5, "number of dining philosophers")
+	fs.StringVar(&work, "work", "1:5", "time a thread spends outside its critical section each time, as `MIN[:MAX]`")
+	fs.StringVar(&hold, "hold", "1:3", "time a thread spends inside its critical section each time, as `MIN[:MAX]`")
+	fs.Int64Var(&c.Duration, "duration", 1000, "time to simulate")
+	fs.Int64Var(&c.Seed, "seed", time.Now().UnixNano(), "random seed for the times drawn from ranges, to reproduce a run")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %q", ErrInvalidArgs, fs.Args())
+	}
+	c.Scenario = scenario
+	var err error
+	if c.Work, err = parseTiming(work); err != nil {
+		return err
+	}
+	if c.Hold, err = parseTiming(hold); err != nil {
+		return err
+	}
+	if err := c.Check(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	names := parseAlgorithms(strategies)
+	if len(names) == 0 {
+		names = concurrency.Strategies[scenario]
+	}
+	for _, strategy := range names {
+		c.Strategy = strategy
+		if err := c.Check(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+	}
+
+	results := make([]concurrency.Result, len(names))
+	for i, strategy := range names {
+		c.Strategy = strategy
+		if results[i], err = concurrency.Simulate(c); err != nil {
+			return err
+		}
+		outputSync(w, c, results[i])
+	}
+	if len(names) > 1 {
+		outputSyncComparison(w, names, results)
+	}
+
+	return nil
+}
+
+// parseTiming parses a time range given as MIN:MAX, or a fixed time as one number.
+func parseTiming(text string) (concurrency.Timing, error) {
+	low, high, ranged := strings.Cut(text, ":")
+	var (
+		t   concurrency.Timing
+		err error
+	)
+	if t.Min, err = strconv.ParseInt(strings.TrimSpace(low), 10, 64); err != nil {
+		return t, fmt.Errorf("%w: time %q: want MIN[:MAX]", ErrInvalidArgs, text)
+	}
+	t.Max = t.Min
+	if ranged {
+		if t.Max, err = strconv.ParseInt(strings.TrimSpace(high), 10, 64); err != nil {
+			return t, fmt.Errorf("%w: time %q: want MIN[:MAX]", ErrInvalidArgs, text)
+		}
+	}
+
+	return t, nil
+}
+
+//endregion
+
+//region Synchronization output
+
+// outputSync outputs the outcome of a synchronization simulation: a table of each thread's operations and waits,
+// one of each role's with their throughput, and the deadlock it ended in, with what each thread held and waited
+// for, if it did.
+func outputSync(w io.Writer, c concurrency.Config, result concurrency.Result) {
+	outputTitle(w, fmt.Sprintf("%s (%s)", syncTitles[c.Scenario], c.Strategy))
+	_, _ = fmt.Fprintf(w, "%s, work %s, hold %s, %d time units\n", syncThreads(c), formatTiming(c.Work), formatTiming(c.Hold), c.Duration)
+
+	_, _ = fmt.Fprintln(w, "Threads")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Thread", "Role", "Operations", "Total Wait", "Ave Wait", "Max Wait"})
+	for _, t := range result.Threads {
+		var ave float64
+		if t.Operations > 0 {
+			ave = float64(t.TotalWait) / float64(t.Operations)
+		}
+		table.Append([]string{
+			t.Name,
+			t.Role,
+			strconv.Itoa(t.Operations),
+			strconv.FormatInt(t.TotalWait, 10),
+			fmt.Sprintf("%.2f", ave),
+			strconv.FormatInt(t.MaxWait, 10),
+		})
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintln(w, "Roles")
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Role", "Threads", "Operations", "Throughput", "Ave Wait", "Max Wait"})
+	for _, r := range result.Roles {
+		table.Append([]string{
+			r.Role,
+			strconv.Itoa(r.Threads),
+			strconv.Itoa(r.Operations),
+			fmt.Sprintf("%.3f", r.Throughput),
+			fmt.Sprintf("%.2f", r.AveWait),
+			strconv.FormatInt(r.MaxWait, 10),
+		})
+	}
+	table.Render()
+
+	if result.Deadlock == nil {
+		_, _ = fmt.Fprintf(w, "No deadlock in %d time units\n\n", result.End)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Deadlock at time %d: every thread is waiting\n", result.Deadlock.Time)
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Thread", "Holds", "Waits For"})
+	for _, b := range result.Deadlock.Threads {
+		table.Append([]string{b.Thread, strings.Join(b.Holds, ", "), b.WaitsFor})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputSyncComparison outputs a table comparing the strategies of a synchronization scenario.
+func outputSyncComparison(w io.Writer, strategies []string, results []concurrency.Result) {
+	outputTitle(w, "Synchronization strategies")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Strategy", "Operations", "Throughput", "Ave Wait", "Max Wait", "Deadlock"})
+	for i, r := range results {
+		var (
+			operations int
+			wait       float64
+			maxWait    int64
+		)
+		for _, role := range r.Roles {
+			operations += role.Operations
+			wait += role.AveWait * float64(role.Operations)
+			if role.MaxWait > maxWait {
+				maxWait = role.MaxWait
+			}
+		}
+		var throughput float64
+		if r.End > 0 {
+			throughput = float64(operations) / float64(r.End)
+		}
+		if operations > 0 {
+			wait /= float64(operations)
+		}
+		deadlock := "none"
+		if r.Deadlock != nil {
+			deadlock = fmt.Sprintf("at %d", r.Deadlock.Time)
+		}
+		table.Append([]string{
+			strategies[i],
+			strconv.Itoa(operations),
+			fmt.Sprintf("%.3f", throughput),
+			fmt.Sprintf("%.2f", wait),
+			strconv.FormatInt(maxWait, 10),
+			deadlock,
+		})
+	}
+	table.Render()
+}
+
+// syncThreads describes the threads of a synchronization scenario, e.g. "2 producers, 2 consumers, capacity 5".
+func syncThreads(c concurrency.Config) string {
+	switch c.Scenario {
+	case concurrency.BoundedBuffer:
+		return fmt.Sprintf("%d producers, %d consumers, capacity %d", c.Producers, c.Consumers, c.Capacity)
+	case concurrency.ReadersWriters:
+		return fmt.Sprintf("%d readers, %d writers", c.Readers, c.Writers)
+	default:
+		return fmt.Sprintf("%d philosophers", c.Philosophers)
+	}
+}
+
+// formatTiming formats a time range as MIN-MAX, or a fixed time as one number.
+func formatTiming(t concurrency.Timing) string {
+	if t.Min == t.Max {
+		return strconv.FormatInt(t.Min, 10)
+	}
+
+	return fmt.Sprintf("%d-%d", t.Min, t.Max)
+}
+
+//endregion
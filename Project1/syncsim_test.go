@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_runSyncsim(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	args := []string{"-scenario", "philosophers", "-philosophers", "3", "-work", "3", "-hold", "2", "-duration", "60", "-seed", "1"}
+	if err := runSyncsim(args, &b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"Dining philosophers (ordered)",
+		"3 philosophers, work 3, hold 2, 60 time units",
+		"No deadlock in 60 time units",
+		"Dining philosophers (naive)",
+		"| Ph1    | philosopher |          0 |          0 |     0.00 |        0 |",
+		"Deadlock at time 3: every thread is waiting",
+		"| Ph3    | fork3 | fork1     |",
+		"| naive    |          0 |      0.000 |     0.00 |        0 | at 3     |",
+		"| waiter   |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runSyncsim() output is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func Test_runSyncsim_strategy(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	args := []string{"-producers", "1", "-consumers", "1", "-capacity", "1", "-work", "1", "-hold", "1:1", "-duration", "10", "-strategy", "semaphores"}
+	if err := runSyncsim(args, &b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"Bounded buffer (semaphores)",
+		"1 producers, 1 consumers, capacity 1, work 1, hold 1, 10 time units",
+		"| producer |       1 |          5 |      0.500 |     0.00 |        0 |",
+		"| consumer |       1 |          4 |      0.400 |     0.50 |        2 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runSyncsim() output is missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Synchronization strategies") {
+		t.Errorf("runSyncsim() compared a single strategy:\n%s", got)
+	}
+}
+
+func Test_runSyncsim_invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown scenario", args: []string{"-scenario", "barber"}},
+		{name: "unknown strategy", args: []string{"-strategy", "semaphores,naive"}},
+		{name: "bad timing", args: []string{"-work", "1-5"}},
+		{name: "bad range", args: []string{"-hold", "2:x"}},
+		{name: "no hold", args: []string{"-hold", "0"}},
+		{name: "no philosophers", args: []string{"-scenario", "philosophers", "-philosophers", "1"}},
+		{name: "arguments", args: []string{"philosophers"}},
+		{name: "unknown flag", args: []string{"-threads", "4"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := runSyncsim(tt.args, &bytes.Buffer{}); !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("runSyncsim() error = %v, wantErr %v", err, ErrInvalidArgs)
+			}
+		})
+	}
+}
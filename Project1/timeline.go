@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// States of a process in its timeline.
+const (
+	stateRun    = "run"
+	stateWait   = "wait"
+	stateIO     = "io"
+	stateSwitch = "cs"
+)
+
+// interval is a stretch of a process's life spent in one state.
+type interval struct {
+	State       string
+	Start, Stop int64
+}
+
+//region Timelines
+
+// processTimeline splits the life of a process, from arrival to completion, into the intervals it ran, was
+// being switched to, was blocked on I/O, or waited, from the slices of the GANTT chart.
+func processTimeline(row scheduler.ProcessResult, gantt []scheduler.TimeSlice) []interval {
+	var (
+		timeline = make([]interval, 0)
+		at       = row.ArrivalTime
+		ioUntil  int64
+		// cpuDone is the CPU time the process has had, and burstEnd the CPU time at which its current CPU
+		// burst ends, with burst indexing it in Bursts.
+		cpuDone, burstEnd int64
+		burst             int
+	)
+	burstEnd = row.BurstDuration
+	if len(row.Bursts) > 0 {
+		burstEnd = row.Bursts[0]
+	}
+	add := func(state string, start, stop int64) {
+		if stop <= start {
+			return
+		}
+		if n := len(timeline); n > 0 && timeline[n-1].State == state && timeline[n-1].Stop == start {
+			timeline[n-1].Stop = stop
+			return
+		}
+		timeline = append(timeline, interval{State: state, Start: start, Stop: stop})
+	}
+	// idle covers the time until stop that the process was off the CPU.
+	idle := func(stop int64) {
+		if ioUntil > at {
+			add(stateIO, at, min(ioUntil, stop))
+			at = min(ioUntil, stop)
+		}
+		add(stateWait, at, stop)
+		at = stop
+	}
+
+	for _, s := range gantt {
+		if s.PID != row.ProcessID || s.Stop <= row.ArrivalTime || s.Start >= row.Completion {
+			continue
+		}
+		idle(s.Start)
+		if s.Switch {
+			add(stateSwitch, s.Start, s.Stop)
+			at = s.Stop
+			continue
+		}
+		add(stateRun, s.Start, s.Stop)
+		at = s.Stop
+		cpuDone += s.Stop - s.Start
+		if cpuDone >= burstEnd && burst+2 < len(row.Bursts) {
+			ioUntil = s.Stop + row.Bursts[burst+1]
+			burst += 2
+			burstEnd += row.Bursts[burst]
+		}
+	}
+	idle(row.Completion)
+
+	return timeline
+}
+
+func outputTimelines(w io.Writer, result scheduler.ScheduleResult) {
+	_, _ = fmt.Fprintln(w, "Process timelines")
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"ID", "Slowdown", "Timeline"})
+	names := processNames(result.Processes)
+	for _, row := range result.Processes {
+		var intervals []string
+		for _, i := range processTimeline(row, result.Gantt) {
+			intervals = append(intervals, fmt.Sprintf("%s %d-%d", i.State, i.Start, i.Stop))
+		}
+		table.Append([]string{
+			label(row.ProcessID, names),
+			fmt.Sprintf("%.2f", row.Slowdown()),
+			strings.Join(intervals, ", "),
+		})
+	}
+	table.Render()
+}
+
+// outputWorst outputs the k processes with the worst slowdowns, worst first, with the intervals they waited in
+// the ready queue, to show which processes the algorithm treated worst and when.
+func outputWorst(w io.Writer, result scheduler.ScheduleResult, k int) {
+	rows := append([]scheduler.ProcessResult(nil), result.Processes...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Slowdown() > rows[j].Slowdown()
+	})
+	if k < len(rows) {
+		rows = rows[:k]
+	}
+
+	_, _ = fmt.Fprintln(w, "Worst slowdowns")
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"ID", "Slowdown", "Turnaround", "Wait", "Waiting"})
+	names := processNames(result.Processes)
+	for _, row := range rows {
+		var waits []string
+		for _, i := range processTimeline(row, result.Gantt) {
+			if i.State == stateWait {
+				waits = append(waits, fmt.Sprintf("%d-%d", i.Start, i.Stop))
+			}
+		}
+		table.Append([]string{
+			label(row.ProcessID, names),
+			fmt.Sprintf("%.2f", row.Slowdown()),
+			strconv.FormatInt(row.Turnaround, 10),
+			strconv.FormatInt(row.Wait, 10),
+			strings.Join(waits, ", "),
+		})
+	}
+	table.Render()
+}
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+//endregion
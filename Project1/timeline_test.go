@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_processTimeline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		row   scheduler.ProcessResult
+		gantt []scheduler.TimeSlice
+		want  []interval
+	}{
+		{
+			name: "preempted process",
+			row:  scheduler.ProcessResult{Process: scheduler.Process{ProcessID: 1, ArrivalTime: 1, BurstDuration: 3}, Completion: 8},
+			gantt: []scheduler.TimeSlice{
+				{PID: 2, Start: 0, Stop: 2},
+				{PID: 1, Start: 2, Stop: 3},
+				{PID: 2, Start: 3, Stop: 5},
+				{PID: 1, Start: 5, Stop: 6, Switch: true},
+				{PID: 1, Start: 6, Stop: 8},
+			},
+			want: []interval{
+				{State: stateWait, Start: 1, Stop: 2},
+				{State: stateRun, Start: 2, Stop: 3},
+				{State: stateWait, Start: 3, Stop: 5},
+				{State: stateSwitch, Start: 5, Stop: 6},
+				{State: stateRun, Start: 6, Stop: 8},
+			},
+		},
+		{
+			name: "blocked on I/O, then waits for the CPU",
+			row: scheduler.ProcessResult{
+				Process:    scheduler.Process{ProcessID: 1, BurstDuration: 3, Bursts: []int64{2, 2, 1}},
+				Completion: 7,
+			},
+			gantt: []scheduler.TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{PID: 1, Start: 1, Stop: 2, CPU: 1},
+				{PID: 2, Start: 2, Stop: 6},
+				{PID: 1, Start: 6, Stop: 7},
+			},
+			want: []interval{
+				{State: stateRun, Start: 0, Stop: 2},
+				{State: stateIO, Start: 2, Stop: 4},
+				{State: stateWait, Start: 4, Stop: 6},
+				{State: stateRun, Start: 6, Stop: 7},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := processTimeline(tt.row, tt.gantt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("processTimeline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputWorst(t *testing.T) {
+	t.Parallel()
+	result := scheduler.FCFS{}.Schedule([]scheduler.Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, Name: "shell", BurstDuration: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 1},
+	})
+	var b bytes.Buffer
+	outputWorst(&b, result, 2)
+	want := "Worst slowdowns\n" +
+		"+-------+----------+------------+------+---------+\n" +
+		"|  ID   | SLOWDOWN | TURNAROUND | WAIT | WAITING |\n" +
+		"+-------+----------+------------+------+---------+\n" +
+		"| shell |     5.00 |          5 |    4 | 0-4     |\n" +
+		"|     3 |     3.00 |          6 |    4 | 1-5     |\n" +
+		"+-------+----------+------------+------+---------+\n"
+	if got := b.String(); got != want {
+		t.Errorf("outputWorst() =\n%s\nwant\n%s", got, want)
+	}
+}
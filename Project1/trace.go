@@ -0,0 +1,446 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// schedTrace is a kernel scheduling trace for a single CPU converted to the scheduler's model.
+// Times are in microseconds since the first event in the trace.
+type schedTrace struct {
+	Gantt      []scheduler.TimeSlice
+	Processes  []scheduler.Process
+	Completion map[int64]int64
+}
+
+// schedEvent is a sched_switch or sched_wakeup event parsed from `perf sched script` or ftrace output.
+type schedEvent struct {
+	CPU     int64
+	Time    int64 // microseconds
+	Name    string
+	PrevPID int64
+	// PrevState is the state the previous task left the CPU in: R(+) when preempted, otherwise blocked.
+	PrevState string
+	NextPID   int64
+	NextPrio  int64
+	NextComm  string
+	PID       int64 // woken PID for wakeup events
+	Prio      int64
+}
+
+var (
+	ErrInvalidTrace = errors.New("invalid sched trace")
+
+	// Matches the common prefix of ftrace and `perf sched script` lines, e.g.
+	//   bash-1234  [000] d..3  1234.567890: sched_switch: ...
+	//   bash  1234 [000]  1234.567890: sched:sched_switch: ...
+	traceLineRe = regexp.MustCompile(`\[(\d+)\].*?\s(\d+\.\d+):\s+(?:sched:)?(sched_switch|sched_wakeup_new|sched_wakeup):\s*(.*)$`)
+	// Matches the compact perf switch payload: prev:1234 [120] S ==> next:0 [120]
+	perfSwitchRe = regexp.MustCompile(`^\S*:(\d+) \[(\d+)\] (\S+) ==> (\S*):(\d+) \[(\d+)\]`)
+	// Matches the compact perf wakeup payload: comm:1234 [120] ...
+	perfWakeupRe = regexp.MustCompile(`^\S*:(\d+) \[(\d+)\]`)
+)
+
+//region Schedulers
+
+// traceResult converts a schedule recorded by the kernel into the same result as the simulated schedulers,
+// so real Linux scheduling can be compared against the algorithms.
+// Arrival is the first wakeup of a task (or its first run), burst is its total run time on the CPU and
+// wait covers everything else, including time spent sleeping. Response is the time until the task's first run.
+func traceResult(trace *schedTrace) scheduler.ScheduleResult {
+	var (
+		totalWait       float64
+		totalResponse   float64
+		totalTurnaround float64
+		lastCompletion  float64
+		schedule        = make([]scheduler.ProcessResult, len(trace.Processes))
+		firstRun        = make(map[int64]int64)
+	)
+	for i := len(trace.Gantt) - 1; i >= 0; i-- {
+		firstRun[trace.Gantt[i].PID] = trace.Gantt[i].Start
+	}
+	for i, p := range trace.Processes {
+		completion := trace.Completion[p.ProcessID]
+		turnaround := completion - p.ArrivalTime
+		waitingTime := turnaround - p.BurstDuration
+		response := firstRun[p.ProcessID] - p.ArrivalTime
+		totalWait += float64(waitingTime)
+		totalResponse += float64(response)
+		totalTurnaround += float64(turnaround)
+		if float64(completion) > lastCompletion {
+			lastCompletion = float64(completion)
+		}
+		schedule[i] = scheduler.ProcessResult{
+			Process:    p,
+			Wait:       waitingTime,
+			Response:   response,
+			Turnaround: turnaround,
+			Completion: completion,
+		}
+	}
+
+	var busy int64
+	for _, s := range trace.Gantt {
+		busy += s.Stop - s.Start
+	}
+	makespan := int64(lastCompletion)
+
+	result := scheduler.ScheduleResult{
+		Gantt:     trace.Gantt,
+		Processes: schedule,
+		Stats: scheduler.Stats{
+			Fairness: scheduler.Fairness(schedule),
+			Makespan: makespan,
+			IdleTime: makespan - busy,
+		},
+	}
+	if count := float64(len(trace.Processes)); count > 0 {
+		result.Stats.AveWait = totalWait / count
+		result.Stats.AveResponse = totalResponse / count
+		result.Stats.AveTurnaround = totalTurnaround / count
+	}
+	if makespan > 0 {
+		result.Stats.AveThroughput = float64(len(trace.Processes)) / lastCompletion
+		result.Stats.Utilization = float64(busy) / float64(makespan)
+	}
+
+	return result
+}
+
+//endregion
+
+//region Loading traces.
+
+// importSchedTrace converts the sched_switch/sched_wakeup events for one CPU into GANTT slices and
+// per-task processes. The idle task (PID 0) is left out of both.
+func importSchedTrace(r io.Reader, cpu int64) (*schedTrace, error) {
+	events, err := parseSchedEvents(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: no sched_switch or sched_wakeup events found", ErrInvalidTrace)
+	}
+
+	var (
+		origin   = events[0].Time
+		last     int64
+		running  int64 // PID currently on the CPU
+		runStart int64
+		trace    = &schedTrace{Completion: make(map[int64]int64)}
+		arrival  = make(map[int64]int64)
+		burst    = make(map[int64]int64)
+		prio     = make(map[int64]int64)
+		comm     = make(map[int64]string)
+		order    = make([]int64, 0)
+	)
+	stop := func(at int64) {
+		if running == 0 || at <= runStart {
+			return
+		}
+		trace.Gantt = append(trace.Gantt, scheduler.TimeSlice{PID: running, Start: runStart, Stop: at})
+		burst[running] += at - runStart
+		trace.Completion[running] = at
+	}
+	for _, e := range events {
+		at := e.Time - origin
+		last = at
+		switch e.Name {
+		case "sched_wakeup", "sched_wakeup_new":
+			if _, ok := arrival[e.PID]; !ok && e.PID != 0 {
+				arrival[e.PID] = at
+				prio[e.PID] = e.Prio
+			}
+		case "sched_switch":
+			if e.CPU != cpu {
+				continue
+			}
+			stop(at)
+			running, runStart = e.NextPID, at
+			if running == 0 {
+				continue
+			}
+			if _, ok := arrival[running]; !ok {
+				arrival[running] = at
+			}
+			if e.NextComm != "" {
+				comm[running] = e.NextComm
+			}
+			if _, ok := prio[running]; !ok || prio[running] == 0 {
+				prio[running] = e.NextPrio
+			}
+			if _, ok := burst[running]; !ok {
+				burst[running] = 0
+				order = append(order, running)
+			}
+		}
+	}
+	// Close the slice of whatever was still running when the trace ended.
+	stop(last)
+
+	for _, pid := range order {
+		if burst[pid] == 0 {
+			continue
+		}
+		trace.Processes = append(trace.Processes, scheduler.Process{
+			ProcessID:     pid,
+			Name:          comm[pid],
+			ArrivalTime:   arrival[pid],
+			BurstDuration: burst[pid],
+			Priority:      prio[pid],
+		})
+	}
+	if len(trace.Processes) == 0 {
+		return nil, fmt.Errorf("%w: no tasks ran on CPU %d", ErrInvalidTrace, cpu)
+	}
+
+	return trace, nil
+}
+
+// importSchedWorkload converts the sched_switch/sched_wakeup events of every CPU into a workload to simulate,
+// with a process per task that ran. A task arrives at its first wakeup (or its first run), and its CPU bursts are
+// its run time between blocking, so preemptions don't split them. The I/O bursts between them last from the task
+// blocking until its next wakeup, or its next run when the trace has no wakeup for it. The priority is the
+// kernel's (lower is higher, 0-99 for real-time tasks and 100-139 for the rest), and times are in microseconds
+// since the first event in the trace.
+func importSchedWorkload(r io.Reader) ([]scheduler.Process, error) {
+	events, err := parseSchedEvents(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: no sched_switch or sched_wakeup events found", ErrInvalidTrace)
+	}
+
+	type task struct {
+		arrival, prio int64
+		comm          string
+		bursts        []int64 // alternating CPU and I/O, ending with the CPU burst in progress
+		running       bool
+		runStart      int64
+		blocked       bool
+		blockedAt     int64
+		wokeAt        int64 // -1 until woken after blocking
+	}
+	var (
+		origin = events[0].Time
+		last   int64
+		tasks  = make(map[int64]*task)
+		order  = make([]int64, 0)
+	)
+	get := func(pid, at int64) *task {
+		t, ok := tasks[pid]
+		if !ok {
+			t = &task{arrival: at, bursts: []int64{0}, wokeAt: -1}
+			tasks[pid] = t
+			order = append(order, pid)
+		}
+		return t
+	}
+	stop := func(t *task, at int64) {
+		if t.running {
+			t.bursts[len(t.bursts)-1] += at - t.runStart
+			t.running = false
+		}
+	}
+	for _, e := range events {
+		at := e.Time - origin
+		last = at
+		switch e.Name {
+		case "sched_wakeup", "sched_wakeup_new":
+			if e.PID == 0 {
+				continue
+			}
+			t := get(e.PID, at)
+			if t.prio == 0 {
+				t.prio = e.Prio
+			}
+			if t.blocked && t.wokeAt < 0 {
+				t.wokeAt = at
+			}
+		case "sched_switch":
+			if e.PrevPID != 0 {
+				prev := get(e.PrevPID, at)
+				stop(prev, at)
+				if !strings.HasPrefix(e.PrevState, "R") && !prev.blocked {
+					prev.blocked, prev.blockedAt, prev.wokeAt = true, at, -1
+				}
+			}
+			if e.NextPID == 0 {
+				continue
+			}
+			next := get(e.NextPID, at)
+			if next.prio == 0 {
+				next.prio = e.NextPrio
+			}
+			if e.NextComm != "" {
+				next.comm = e.NextComm
+			}
+			if next.blocked {
+				woke := next.wokeAt
+				if woke < 0 {
+					woke = at
+				}
+				switch n := len(next.bursts); {
+				case next.bursts[n-1] > 0:
+					next.bursts = append(next.bursts, woke-next.blockedAt, 0)
+				case n > 1:
+					// It blocked again without running, so the I/O continues.
+					next.bursts[n-2] += woke - next.blockedAt
+				}
+				next.blocked = false
+			}
+			next.running, next.runStart = true, at
+		}
+	}
+
+	var processes []scheduler.Process
+	for _, pid := range order {
+		t := tasks[pid]
+		// Close the run of whatever was still running when the trace ended, and drop I/O it never came back from.
+		stop(t, last)
+		if n := len(t.bursts); n > 1 && t.bursts[n-1] == 0 {
+			t.bursts = t.bursts[:n-2]
+		}
+		burst := cpuTime(t.bursts)
+		if burst == 0 {
+			continue
+		}
+		p := scheduler.Process{
+			ProcessID:     pid,
+			Name:          t.comm,
+			ArrivalTime:   t.arrival,
+			BurstDuration: burst,
+			Priority:      t.prio,
+		}
+		if len(t.bursts) > 1 {
+			p.Bursts = t.bursts
+		}
+		processes = append(processes, p)
+	}
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("%w: no tasks ran", ErrInvalidTrace)
+	}
+
+	return processes, nil
+}
+
+// parseSchedEvents reads scheduler events in time order, skipping lines that are not sched events.
+func parseSchedEvents(r io.Reader) ([]schedEvent, error) {
+	var (
+		events  = make([]schedEvent, 0)
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	for scanner.Scan() {
+		line++
+		m := traceLineRe.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		e, err := parseSchedEvent(m[1], m[2], m[3], m[4])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidTrace, line, err)
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading trace", err)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+
+	return events, nil
+}
+
+func parseSchedEvent(cpu, ts, name, payload string) (schedEvent, error) {
+	var (
+		e   = schedEvent{Name: name}
+		err error
+	)
+	if e.CPU, err = strconv.ParseInt(cpu, 10, 64); err != nil {
+		return e, err
+	}
+	if e.Time, err = parseTraceTimestamp(ts); err != nil {
+		return e, err
+	}
+
+	fields := traceFields(payload)
+	switch name {
+	case "sched_switch":
+		if _, ok := fields["next_pid"]; ok {
+			e.PrevPID, err = strconv.ParseInt(fields["prev_pid"], 10, 64)
+			if err == nil {
+				e.NextPID, err = strconv.ParseInt(fields["next_pid"], 10, 64)
+			}
+			if err == nil {
+				e.NextPrio, err = strconv.ParseInt(fields["next_prio"], 10, 64)
+			}
+			e.PrevState, e.NextComm = fields["prev_state"], fields["next_comm"]
+			return e, err
+		}
+		m := perfSwitchRe.FindStringSubmatch(payload)
+		if m == nil {
+			return e, fmt.Errorf("unrecognized sched_switch: %q", payload)
+		}
+		e.PrevPID, _ = strconv.ParseInt(m[1], 10, 64)
+		e.PrevState, e.NextComm = m[3], m[4]
+		e.NextPID, _ = strconv.ParseInt(m[5], 10, 64)
+		e.NextPrio, _ = strconv.ParseInt(m[6], 10, 64)
+	default:
+		if _, ok := fields["pid"]; ok {
+			e.PID, err = strconv.ParseInt(fields["pid"], 10, 64)
+			if err == nil {
+				e.Prio, err = strconv.ParseInt(fields["prio"], 10, 64)
+			}
+			return e, err
+		}
+		m := perfWakeupRe.FindStringSubmatch(payload)
+		if m == nil {
+			return e, fmt.Errorf("unrecognized %s: %q", name, payload)
+		}
+		e.PID, _ = strconv.ParseInt(m[1], 10, 64)
+		e.Prio, _ = strconv.ParseInt(m[2], 10, 64)
+	}
+
+	return e, nil
+}
+
+// traceFields splits a key=value payload; the compact perf format has no pid fields.
+func traceFields(payload string) map[string]string {
+	fields := make(map[string]string)
+	for _, f := range strings.Fields(payload) {
+		if k, v, ok := strings.Cut(f, "="); ok && k != "" {
+			fields[k] = v
+		}
+	}
+
+	return fields
+}
+
+// parseTraceTimestamp converts a "seconds.fraction" timestamp to microseconds.
+func parseTraceTimestamp(ts string) (int64, error) {
+	sec, frac, _ := strings.Cut(ts, ".")
+	frac = (frac + "000000")[:6]
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	us, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return s*1_000_000 + us, nil
+}
+
+//endregion
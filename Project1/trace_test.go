@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+const ftraceFixture = `# tracer: nop
+          <idle>-0     [000] d..3  100.000000: sched_wakeup: comm=a pid=10 prio=120 target_cpu=000
+          <idle>-0     [000] d..3  100.000010: sched_switch: prev_comm=swapper/0 prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=a next_pid=10 next_prio=120
+               a-10    [000] d..3  100.000050: sched_switch: prev_comm=a prev_pid=10 prev_prio=120 prev_state=S ==> next_comm=b next_pid=11 next_prio=110
+               c-12    [001] d..3  100.000060: sched_switch: prev_comm=c prev_pid=12 prev_prio=120 prev_state=S ==> next_comm=d next_pid=13 next_prio=110
+               b-11    [000] d..3  100.000070: sched_switch: prev_comm=b prev_pid=11 prev_prio=110 prev_state=S ==> next_comm=a next_pid=10 next_prio=120
+`
+
+const perfFixture = `            perf    10 [000]   100.000000:       sched:sched_wakeup: a:10 [120] success=1 CPU:000
+         swapper     0 [000]   100.000010:       sched:sched_switch: swapper/0:0 [120] R ==> a:10 [120]
+               a    10 [000]   100.000050:       sched:sched_switch: a:10 [120] S ==> b:11 [110]
+               b    11 [000]   100.000070:       sched:sched_switch: b:11 [110] S ==> a:10 [120]
+               a    10 [000]   100.000100:       sched:sched_switch: a:10 [120] S ==> swapper/0:0 [120]
+`
+
+func Test_importSchedTrace(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r   io.Reader
+		cpu int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *schedTrace
+		wantErr error
+	}{
+		{
+			name: "bad reader",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "no events",
+			args: args{
+				r: strings.NewReader("# tracer: nop\n"),
+			},
+			wantErr: ErrInvalidTrace,
+		},
+		{
+			name: "nothing ran on the CPU",
+			args: args{
+				r:   strings.NewReader(ftraceFixture),
+				cpu: 7,
+			},
+			wantErr: ErrInvalidTrace,
+		},
+		{
+			name: "ftrace",
+			args: args{
+				r: strings.NewReader(ftraceFixture),
+			},
+			want: &schedTrace{
+				Gantt: []scheduler.TimeSlice{
+					{PID: 10, Start: 10, Stop: 50},
+					{PID: 11, Start: 50, Stop: 70},
+				},
+				Processes: []scheduler.Process{
+					{ProcessID: 10, Name: "a", ArrivalTime: 0, BurstDuration: 40, Priority: 120},
+					{ProcessID: 11, Name: "b", ArrivalTime: 50, BurstDuration: 20, Priority: 110},
+				},
+				Completion: map[int64]int64{10: 50, 11: 70},
+			},
+		},
+		{
+			name: "perf sched script",
+			args: args{
+				r: strings.NewReader(perfFixture),
+			},
+			want: &schedTrace{
+				Gantt: []scheduler.TimeSlice{
+					{PID: 10, Start: 10, Stop: 50},
+					{PID: 11, Start: 50, Stop: 70},
+					{PID: 10, Start: 70, Stop: 100},
+				},
+				Processes: []scheduler.Process{
+					{ProcessID: 10, Name: "a", ArrivalTime: 0, BurstDuration: 70, Priority: 120},
+					{ProcessID: 11, Name: "b", ArrivalTime: 50, BurstDuration: 20, Priority: 110},
+				},
+				Completion: map[int64]int64{10: 100, 11: 70},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := importSchedTrace(tt.args.r, tt.args.cpu)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importSchedTrace() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_traceResult(t *testing.T) {
+	t.Parallel()
+	trace, err := importSchedTrace(strings.NewReader(perfFixture), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, "trace", traceResult(trace), false)
+	got := w.String()
+	for _, want := range []string{
+		"| idle  |               a               |       b       |           a           |\n" +
+			"0       10                              50              70                      100",
+		"| 10 | a    |      120 |    70 |        0 |      30 |       10 |        100 |        100 |",
+		"| 11 | b    |      110 |    20 |       50 |       0 |        0 |         20 |         70 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("traceResult() = %v, want %v", got, want)
+		}
+	}
+}
+
+func Test_traceResult_empty(t *testing.T) {
+	t.Parallel()
+	if got := traceResult(&schedTrace{}); got.Stats != (scheduler.Stats{}) {
+		t.Errorf("traceResult() stats = %+v, want all zero", got.Stats)
+	}
+}
+
+// smpFixture has tasks on two CPUs that are preempted (a at 20), block on I/O (b and c) and exit (a).
+const smpFixture = `# tracer: nop
+          <idle>-0     [000] d..3  100.000000: sched_wakeup: comm=a pid=10 prio=120 target_cpu=000
+          <idle>-0     [000] d..3  100.000000: sched_switch: prev_comm=swapper/0 prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=a next_pid=10 next_prio=120
+          <idle>-0     [001] d..3  100.000005: sched_wakeup: comm=b pid=11 prio=100 target_cpu=001
+          <idle>-0     [001] d..3  100.000005: sched_switch: prev_comm=swapper/1 prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=b next_pid=11 next_prio=100
+               a-10    [000] d..3  100.000020: sched_switch: prev_comm=a prev_pid=10 prev_prio=120 prev_state=R+ ==> next_comm=c next_pid=12 next_prio=120
+               c-12    [000] d..3  100.000030: sched_switch: prev_comm=c prev_pid=12 prev_prio=120 prev_state=S ==> next_comm=a next_pid=10 next_prio=120
+               b-11    [001] d..3  100.000040: sched_switch: prev_comm=b prev_pid=11 prev_prio=100 prev_state=D ==> next_comm=swapper/1 next_pid=0 next_prio=120
+          <idle>-0     [001] d..3  100.000045: sched_wakeup: comm=c pid=12 prio=120 target_cpu=001
+          <idle>-0     [001] d..3  100.000050: sched_wakeup: comm=b pid=11 prio=100 target_cpu=000
+          <idle>-0     [001] d..3  100.000050: sched_switch: prev_comm=swapper/1 prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=c next_pid=12 next_prio=120
+               a-10    [000] d..3  100.000060: sched_switch: prev_comm=a prev_pid=10 prev_prio=120 prev_state=X ==> next_comm=b next_pid=11 next_prio=100
+               c-12    [001] d..3  100.000070: sched_switch: prev_comm=c prev_pid=12 prev_prio=120 prev_state=S ==> next_comm=swapper/1 next_pid=0 next_prio=120
+               b-11    [000] d..3  100.000080: sched_switch: prev_comm=b prev_pid=11 prev_prio=100 prev_state=S ==> next_comm=swapper/0 next_pid=0 next_prio=120
+`
+
+func Test_importSchedWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []scheduler.Process
+		wantErr error
+	}{
+		{
+			name:    "bad reader",
+			r:       iotest.ErrReader(io.ErrUnexpectedEOF),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "no events",
+			r:       strings.NewReader("# tracer: nop\n"),
+			wantErr: ErrInvalidTrace,
+		},
+		{
+			name: "perf sched script",
+			r:    strings.NewReader(perfFixture),
+			want: []scheduler.Process{
+				{ProcessID: 10, Name: "a", ArrivalTime: 0, BurstDuration: 70, Priority: 120, Bursts: []int64{40, 20, 30}},
+				{ProcessID: 11, Name: "b", ArrivalTime: 50, BurstDuration: 20, Priority: 110},
+			},
+		},
+		{
+			name: "ftrace on two CPUs",
+			r:    strings.NewReader(smpFixture),
+			want: []scheduler.Process{
+				{ProcessID: 10, Name: "a", ArrivalTime: 0, BurstDuration: 50, Priority: 120},
+				{ProcessID: 11, Name: "b", ArrivalTime: 5, BurstDuration: 55, Priority: 100, Bursts: []int64{35, 10, 20}},
+				{ProcessID: 12, Name: "c", ArrivalTime: 20, BurstDuration: 30, Priority: 120, Bursts: []int64{10, 15, 20}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := importSchedWorkload(tt.r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importSchedWorkload() = %+v, want %+v", got, tt.want)
+			}
+			for _, p := range got {
+				if err := checkProcess(p); err != nil {
+					t.Error(err)
+				}
+				if p.Bursts != nil {
+					if err := checkBursts(p.Bursts, p.BurstDuration); err != nil {
+						t.Errorf("process %d: %v", p.ProcessID, err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// goldenExt is the extension of a workload's golden file, which holds its expected results: basic.csv is
+// checked against basic.csv.golden.
+const goldenExt = ".golden"
+
+var ErrVerifyFailed = errors.New("verification failed")
+
+//region Verifying schedules
+
+// runVerify runs the verify subcommand: it schedules every workload file in a directory, checks each schedule's
+// invariants and compares each algorithm's results with the workload's golden file, writing a PASS or FAIL line
+// per workload and a summary to w. With -update it writes the golden files from the current results instead.
+func runVerify(args []string, w io.Writer) error {
+	var (
+		fs     = flag.NewFlagSet("verify", flag.ContinueOnError)
+		s      = settings{Check: true}
+		update bool
+	)
+	fs.BoolVar(&update, "update", false, "write each workload's golden file from its current results instead of checking it")
+	fs.Int64Var(&s.Quantum, "quantum", 1, "Round-Robin time quantum, also the time between Lottery draws and Stride dispatches")
+	fs.IntVar(&s.Cores, "cores", 1, "number of CPUs")
+	fs.Int64Var(&s.SwitchCost, "switch-cost", 0, "context switch overhead")
+	fs.Int64Var(&s.Aging, "aging", 5, "time a process waits before its priority improves by one under priority aging")
+	fs.Int64Var(&s.Seed, "seed", 1, "seed for randomized schedulers, fixed so results are reproducible")
+	fs.StringVar(&s.TieBreak, "tie-break", scheduler.TieFIFOName, "tie-break policy, fifo or arrival")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: verify takes a directory of workload files", ErrInvalidArgs)
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+	dir := fs.Arg(0)
+	workloads, err := verifyWorkloads(dir)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, name := range workloads {
+		diffs, err := verifyWorkload(filepath.Join(dir, name), s, update)
+		switch {
+		case err != nil:
+			diffs = []string{err.Error()}
+		case update:
+			_, _ = fmt.Fprintf(w, "UPDATED  %s\n", name)
+			continue
+		case len(diffs) == 0:
+			_, _ = fmt.Fprintf(w, "PASS     %s\n", name)
+			continue
+		}
+		failed++
+		_, _ = fmt.Fprintf(w, "FAIL     %s\n", name)
+		for _, d := range diffs {
+			_, _ = fmt.Fprintf(w, "         %s\n", d)
+		}
+	}
+	if update {
+		_, _ = fmt.Fprintf(w, "%d workloads: %d updated, %d failed\n", len(workloads), len(workloads)-failed, failed)
+	} else {
+		_, _ = fmt.Fprintf(w, "%d workloads: %d passed, %d failed\n", len(workloads), len(workloads)-failed, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d workloads", ErrVerifyFailed, failed, len(workloads))
+	}
+
+	return nil
+}
+
+// verifyWorkloads returns the names of the workload files in dir: its CSV, JSON and YAML files.
+func verifyWorkloads(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	var workloads []string
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".csv", ".json", ".yaml", ".yml":
+			if !e.IsDir() {
+				workloads = append(workloads, e.Name())
+			}
+		}
+	}
+	if len(workloads) == 0 {
+		return nil, fmt.Errorf("%w: no workload files in %s", ErrInvalidArgs, dir)
+	}
+
+	return workloads, nil
+}
+
+// verifyWorkload schedules the workload in file and describes how the results differ from its golden file, or,
+// when update is set, writes them to the golden file.
+func verifyWorkload(file string, s settings, update bool) ([]string, error) {
+	reports, err := verifyReports(file, s)
+	if err != nil {
+		return nil, err
+	}
+	if update {
+		return nil, writeGolden(file+goldenExt, reports)
+	}
+
+	return checkGolden(file+goldenExt, reports)
+}
+
+// verifyReports schedules the workload in file under the default algorithms with the settings s, or, for a
+// scenario, under the algorithms and settings it gives, and returns the JSON report of each schedule.
+func verifyReports(file string, s settings) ([]jsonReport, error) {
+	format, err := workloadFormat("", file)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var processes []scheduler.Process
+	if format == formatYAML {
+		if processes, err = loadScenario(f, &s); err == nil {
+			err = s.validate()
+		}
+	} else {
+		processes, err = loadWorkload(f, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	algorithms := s.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms(processes, s)
+	}
+	schedules, err := scheduleAll(processes, algorithms, s)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]jsonReport, len(schedules))
+	for i, sc := range schedules {
+		reports[i] = newJSONReport(sc.name, sc.title, sc.result)
+	}
+
+	return reports, nil
+}
+
+// writeGolden writes reports to the golden file, indented so that changes to it read well in a diff.
+func writeGolden(file string, reports []jsonReport) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%v: error writing golden file", err)
+	}
+
+	return nil
+}
+
+// checkGolden compares reports with the golden file, describing each difference.
+func checkGolden(file string, reports []jsonReport) ([]string, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{fmt.Sprintf("no golden file %s; run verify -update to create it", filepath.Base(file))}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var want []jsonReport
+	if err := json.Unmarshal(data, &want); err != nil {
+		return nil, fmt.Errorf("%w: reading golden file %s", err, filepath.Base(file))
+	}
+
+	return goldenDiffs(want, reports), nil
+}
+
+// goldenDiffs describes how each algorithm's report in got differs from its golden report in want.
+func goldenDiffs(want, got []jsonReport) []string {
+	var (
+		diffs  []string
+		golden = make(map[string]jsonReport, len(want))
+	)
+	for _, r := range want {
+		golden[r.Algorithm] = r
+	}
+	for _, r := range got {
+		g, ok := golden[r.Algorithm]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: not in the golden file", r.Algorithm))
+			continue
+		}
+		delete(golden, r.Algorithm)
+		for _, d := range reportDiffs(g, r) {
+			diffs = append(diffs, fmt.Sprintf("%s: %s", r.Algorithm, d))
+		}
+	}
+	for _, r := range want {
+		if _, ok := golden[r.Algorithm]; ok {
+			diffs = append(diffs, fmt.Sprintf("%s: in the golden file but not scheduled", r.Algorithm))
+		}
+	}
+
+	return diffs
+}
+
+// reportDiffs describes the first difference in a report's processes and GANTT chart, and each statistic that
+// differs, from the golden report.
+func reportDiffs(want, got jsonReport) []string {
+	var diffs []string
+	if got.Title != want.Title {
+		diffs = append(diffs, fmt.Sprintf("title %q, want %q", got.Title, want.Title))
+	}
+	if len(got.Processes) != len(want.Processes) {
+		diffs = append(diffs, fmt.Sprintf("%d processes, want %d", len(got.Processes), len(want.Processes)))
+	} else {
+		for i := range got.Processes {
+			if got.Processes[i] != want.Processes[i] {
+				diffs = append(diffs, fmt.Sprintf("process row %d is %+v, want %+v", i+1, got.Processes[i], want.Processes[i]))
+				break
+			}
+		}
+	}
+	if len(got.Gantt) != len(want.Gantt) {
+		diffs = append(diffs, fmt.Sprintf("%d GANTT slices, want %d", len(got.Gantt), len(want.Gantt)))
+	} else {
+		for i := range got.Gantt {
+			if got.Gantt[i] != want.Gantt[i] {
+				diffs = append(diffs, fmt.Sprintf("GANTT slice %d is %+v, want %+v", i+1, got.Gantt[i], want.Gantt[i]))
+				break
+			}
+		}
+	}
+	// Statistics are named after their JSON fields, as they appear in the golden file.
+	gv, wv := reflect.ValueOf(got.Stats), reflect.ValueOf(want.Stats)
+	for i := 0; i < gv.NumField(); i++ {
+		if g, w := gv.Field(i).Interface(), wv.Field(i).Interface(); g != w {
+			name, _, _ := strings.Cut(gv.Type().Field(i).Tag.Get("json"), ",")
+			diffs = append(diffs, fmt.Sprintf("%s %v, want %v", name, g, w))
+		}
+	}
+
+	return diffs
+}
+
+//endregion
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_runVerify(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := runVerify([]string{"testdata/verify"}, &out); err != nil {
+		t.Fatalf("runVerify() error = %v\n%s", err, out.String())
+	}
+	want := "PASS     basic.csv\nPASS     io.json\nPASS     multicore.yaml\n3 workloads: 3 passed, 0 failed\n"
+	if got := out.String(); got != want {
+		t.Errorf("runVerify() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_runVerify_failures(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, name := range []string{"a.csv", "b.csv"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("1,5,0,2\n2,9,3,1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := runVerify([]string{"-update", dir}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tamper with a.csv's golden FCFS results and lose b.csv's golden file.
+	golden := filepath.Join(dir, "a.csv"+goldenExt)
+	data, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reports []jsonReport
+	if err := json.Unmarshal(data, &reports); err != nil {
+		t.Fatal(err)
+	}
+	reports[0].Processes[1].Wait++
+	reports[0].Stats.AveWait++
+	if err := writeGolden(golden, reports[:len(reports)-1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "b.csv"+goldenExt)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runVerify([]string{dir}, &out); !errors.Is(err, ErrVerifyFailed) {
+		t.Errorf("runVerify() error = %v, want %v", err, ErrVerifyFailed)
+	}
+	for _, want := range []string{
+		"FAIL     a.csv\n",
+		"fcfs: process row 2 is {PID:2 Name: Priority:1 Burst:9 Arrival:3 Wait:2 Response:2 Turnaround:11 Completion:14 Deadline:0 Suspended:0}, want {PID:2 Name: Priority:1 Burst:9 Arrival:3 Wait:3 Response:2 Turnaround:11 Completion:14 Deadline:0 Suspended:0}\n",
+		"fcfs: average_wait 1, want 2\n",
+		"stride: not in the golden file\n",
+		"FAIL     b.csv\n         no golden file b.csv.golden; run verify -update to create it\n",
+		"2 workloads: 0 passed, 2 failed\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runVerify() =\n%s\nwant it to contain %q", out.String(), want)
+		}
+	}
+}
+
+func Test_runVerify_args(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{nil, {"testdata/verify", "extra"}, {t.TempDir()}, {"-tie-break", "pid", "testdata/verify"}} {
+		if err := runVerify(args, &bytes.Buffer{}); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("runVerify(%q) error = %v, want %v", args, err, ErrInvalidArgs)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+// Process file formats.
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+	formatYAML = "yaml"
+	// formatSched is a `perf sched script` or ftrace sched_switch/sched_wakeup trace, converted to a workload.
+	formatSched = "sched"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown process file format")
+	ErrInvalidEvent  = errors.New("invalid event")
+)
+
+// workloadProcess is a process in a JSON process file or YAML scenario. Only pid and burst are required, and
+// burst may be left out when bursts are given.
+type workloadProcess struct {
+	PID       int64   `json:"pid" yaml:"pid"`
+	Name      string  `json:"name,omitempty" yaml:"name"`
+	Burst     int64   `json:"burst" yaml:"burst"`
+	Arrival   int64   `json:"arrival,omitempty" yaml:"arrival"`
+	Priority  int64   `json:"priority,omitempty" yaml:"priority"`
+	Group     string  `json:"group,omitempty" yaml:"group"`
+	Deadline  int64   `json:"deadline,omitempty" yaml:"deadline"`
+	Period    int64   `json:"period,omitempty" yaml:"period"`
+	Bursts    []int64 `json:"bursts,omitempty" yaml:"bursts"`
+	DependsOn []int64 `json:"depends_on,omitempty" yaml:"depends_on"`
+	Affinity  []int   `json:"affinity,omitempty" yaml:"affinity"`
+	Gang      int64   `json:"gang,omitempty" yaml:"gang"`
+}
+
+// workloadEvent is an entry in the events section of a JSON process file or YAML scenario: the process with the
+// given PID is suspended at time at for the given number of time units.
+type workloadEvent struct {
+	PID int64 `json:"pid" yaml:"pid"`
+	At  int64 `json:"at" yaml:"at"`
+	For int64 `json:"for" yaml:"for"`
+}
+
+// workloadFile is a JSON process file with events, as an object rather than an array of processes.
+type workloadFile struct {
+	Processes []workloadProcess `json:"processes"`
+	Events    []workloadEvent   `json:"events,omitempty"`
+}
+
+//region Loading processes.
+
+// workloadFormat returns the format of the named process file: format when it is set, otherwise one detected
+// from the file's extension, defaulting to CSV.
+func workloadFormat(format, name string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".json":
+			format = formatJSON
+		case ".yaml", ".yml":
+			format = formatYAML
+		default:
+			format = formatCSV
+		}
+	}
+	switch format {
+	case formatCSV, formatJSON, formatYAML, formatSched:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// loadWorkload reads processes from a process file in the given format.
+func loadWorkload(r io.Reader, format string) ([]scheduler.Process, error) {
+	switch format {
+	case formatCSV:
+		return loadProcesses(r)
+	case formatJSON:
+		return loadProcessesJSON(r)
+	case formatSched:
+		return importSchedWorkload(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// loadProcessesJSON reads processes from a JSON array of objects, e.g.
+//
+//	[{"pid": 1, "burst": 5, "arrival": 0, "bursts": [3, 4, 2]}, {"pid": 2, "burst": 9, "deadline": 20}]
+//
+// or, to suspend processes, from an object holding the array and the events, e.g.
+//
+//	{"processes": [{"pid": 1, "burst": 5}], "events": [{"pid": 1, "at": 2, "for": 3}]}
+func loadProcessesJSON(r io.Reader) ([]scheduler.Process, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	}
+	var file workloadFile
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := dec.Decode(&file); err != nil {
+			return nil, fmt.Errorf("%w: reading JSON", err)
+		}
+	} else if err := dec.Decode(&file.Processes); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	}
+	processes, err := workloadProcesses(file.Processes)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyEvents(processes, file.Events); err != nil {
+		return nil, err
+	}
+
+	return processes, nil
+}
+
+// workloadProcesses converts and validates the processes of a JSON process file or YAML scenario.
+func workloadProcesses(workload []workloadProcess) ([]scheduler.Process, error) {
+	var (
+		processes = make([]scheduler.Process, len(workload))
+		seen      = make(map[int64]bool)
+	)
+	for i, w := range workload {
+		if w.Burst == 0 {
+			w.Burst = cpuTime(w.Bursts)
+		}
+		if seen[w.PID] {
+			return nil, fmt.Errorf("%w: process %d: PID is used more than once", ErrInvalidProcess, w.PID)
+		}
+		seen[w.PID] = true
+		if len(w.Bursts) > 0 {
+			if err := checkBursts(w.Bursts, w.Burst); err != nil {
+				return nil, fmt.Errorf("%w: process %d: %v", ErrInvalidProcess, w.PID, err)
+			}
+		}
+		processes[i] = scheduler.Process{
+			ProcessID:     w.PID,
+			Name:          w.Name,
+			ArrivalTime:   w.Arrival,
+			BurstDuration: w.Burst,
+			Priority:      w.Priority,
+			Deadline:      w.Deadline,
+			Period:        w.Period,
+			Bursts:        w.Bursts,
+			DependsOn:     w.DependsOn,
+			Affinity:      w.Affinity,
+			Gang:          w.Gang,
+		}
+		if w.Group != "" {
+			processes[i].Group = scheduler.CleanCgroupPath(w.Group)
+		}
+		if err := checkProcess(processes[i]); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProcess, err)
+		}
+	}
+	if err := scheduler.CheckDependencies(processes); err != nil {
+		return nil, err
+	}
+
+	return processes, nil
+}
+
+// applyEvents suspends the processes the events name.
+func applyEvents(processes []scheduler.Process, events []workloadEvent) error {
+	byPID := make(map[int64]*scheduler.Process, len(processes))
+	for i := range processes {
+		byPID[processes[i].ProcessID] = &processes[i]
+	}
+	for i, e := range events {
+		p, ok := byPID[e.PID]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: event %d: no process has PID %d", ErrInvalidEvent, i+1, e.PID)
+		case e.At < 0:
+			return fmt.Errorf("%w: event %d: time %d is negative", ErrInvalidEvent, i+1, e.At)
+		case e.For <= 0:
+			return fmt.Errorf("%w: event %d: suspension must last a positive time, not %d", ErrInvalidEvent, i+1, e.For)
+		}
+		p.Suspensions = append(p.Suspensions, scheduler.Suspension{At: e.At, For: e.For})
+	}
+
+	return nil
+}
+
+//endregion
+
+//region Loading number lists
+
+// loadNumbers reads a list of whole numbers, such as pages or cylinders, in the given format: in CSV every field
+// of every row is a number, and fields can also be separated by spaces, e.g. "7,0,1,2" or "7 0 1 2"; JSON is an
+// array of numbers. noun names the numbers in errors, which wrap errInvalid, as does the error for an empty list.
+func loadNumbers(r io.Reader, format, noun string, errInvalid error) ([]int64, error) {
+	var numbers []int64
+	switch format {
+	case formatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		for {
+			row, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: reading CSV", err)
+			}
+			line, _ := reader.FieldPos(0)
+			for _, field := range row {
+				for _, v := range strings.Fields(strings.TrimPrefix(field, "\uFEFF")) {
+					n, err := strconv.ParseInt(v, 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("%w: line %d: %s %q is not a whole number", errInvalid, line, noun, v)
+					}
+					numbers = append(numbers, n)
+				}
+			}
+		}
+	case formatJSON:
+		if err := json.NewDecoder(r).Decode(&numbers); err != nil {
+			return nil, fmt.Errorf("%w: reading JSON", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q: %s lists are read from CSV or JSON", ErrUnknownFormat, format, noun)
+	}
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf("%w: no %ss", errInvalid, noun)
+	}
+
+	return numbers, nil
+}
+
+//endregion
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project1/scheduler"
+)
+
+func Test_workloadFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		format  string
+		file    string
+		want    string
+		wantErr error
+	}{
+		{name: "CSV by default", file: "processes.txt", want: formatCSV},
+		{name: "JSON by extension", file: "processes.JSON", want: formatJSON},
+		{name: "flag wins", format: formatCSV, file: "processes.json", want: formatCSV},
+		{name: "sched trace", format: formatSched, file: "sched.txt", want: formatSched},
+		{name: "unknown", format: "xml", file: "processes.xml", wantErr: ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := workloadFormat(tt.format, tt.file)
+			if got != tt.want {
+				t.Errorf("workloadFormat() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_loadProcessesJSON(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []scheduler.Process
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader(`[
+					{"pid": 1, "name": "editor", "arrival": 0, "priority": 2, "bursts": [3, 4, 2], "group": "web"},
+					{"pid": 2, "burst": 9, "arrival": 3, "deadline": 20, "depends_on": [1], "affinity": [1]}
+				]`),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "editor", BurstDuration: 5, Priority: 2, Group: "/web", Bursts: []int64{3, 4, 2}},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Deadline: 20, DependsOn: []int64{1}, Affinity: []int{1}},
+			},
+		},
+		{
+			name: "events suspend processes",
+			args: args{
+				r: strings.NewReader(`{
+					"processes": [{"pid": 1, "burst": 5}, {"pid": 2, "burst": 3}],
+					"events": [{"pid": 2, "at": 1, "for": 4}, {"pid": 2, "at": 8, "for": 1}]
+				}`),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5},
+				{ProcessID: 2, BurstDuration: 3, Suspensions: []scheduler.Suspension{{At: 1, For: 4}, {At: 8, For: 1}}},
+			},
+		},
+		{
+			name: "event for an unknown process",
+			args: args{
+				r: strings.NewReader(`{"processes": [{"pid": 1, "burst": 5}], "events": [{"pid": 2, "at": 1, "for": 4}]}`),
+			},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name: "suspension without a length",
+			args: args{
+				r: strings.NewReader(`{"processes": [{"pid": 1, "burst": 5}], "events": [{"pid": 1, "at": 1}]}`),
+			},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name: "bad JSON",
+			args: args{
+				r: strings.NewReader(`[{"pid": 1,`),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "no burst",
+			args: args{
+				r: strings.NewReader(`[{"pid": 1}]`),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "CPU bursts do not add up to the burst",
+			args: args{
+				r: strings.NewReader(`[{"pid": 1, "burst": 1}, {"pid": 7, "burst": 4, "bursts": [3, 4, 2]}]`),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "process 7: ",
+		},
+		{
+			name: "negative arrival",
+			args: args{
+				r: strings.NewReader(`[{"pid": 1, "burst": 4, "arrival": -2}]`),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "duplicate PID",
+			args: args{
+				r: strings.NewReader(`[{"pid": 1, "burst": 1}, {"pid": 3, "burst": 4}, {"pid": 3, "burst": 2}]`),
+			},
+			wantErr: ErrInvalidProcess,
+			wantMsg: "process 3: PID is used more than once",
+		},
+		{
+			name: "dependency cycle",
+			args: args{
+				r: strings.NewReader(`[{"pid": 1, "burst": 1, "depends_on": [1]}]`),
+			},
+			wantErr: scheduler.ErrDependencyCycle,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcessesJSON(tt.args.r)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcessesJSON() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.wantMsg)) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
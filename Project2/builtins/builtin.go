@@ -0,0 +1,118 @@
+package builtins
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+type (
+	// Builtin is a command the shell runs itself, rather than executing a program.
+	Builtin interface {
+		Run(ctx Context, args ...string) error
+	}
+	// Func adapts a function to a Builtin.
+	Func func(ctx Context, args ...string) error
+	// Context is what a builtin runs with: its standard streams and the shell running it.
+	Context struct {
+		Stdin  io.Reader
+		Stdout io.Writer
+		Stderr io.Writer
+		Shell  Shell
+	}
+	// Shell is the shell state builtins can see and change.
+	Shell interface {
+		// Exit asks the shell to exit once the builtin returns.
+		Exit()
+		// History returns the command lines entered so far, oldest first.
+		History() []string
+		// ClearHistory forgets the command lines entered so far.
+		ClearHistory() error
+		// Builtins returns the registry of the shell's builtins.
+		Builtins() *Registry
+		// Jobs returns the table of the shell's background jobs.
+		Jobs() Jobs
+	}
+)
+
+// Run calls f.
+func (f Func) Run(ctx Context, args ...string) error {
+	return f(ctx, args...)
+}
+
+// Registry maps names to builtins.
+type Registry struct {
+	builtins map[string]Builtin
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{builtins: make(map[string]Builtin)}
+}
+
+// Defaults returns a registry of the standard builtins: bg, cd, env, exit, fg, help, history, jobs, kill and pwd.
+func Defaults() *Registry {
+	r := NewRegistry()
+	r.Register("bg", Func(Background))
+	r.Register("cd", Func(func(_ Context, args ...string) error {
+		return ChangeDirectory(args...)
+	}))
+	r.Register("env", Func(func(ctx Context, args ...string) error {
+		return EnvironmentVariables(ctx.Stdout, args...)
+	}))
+	r.Register("exit", Func(Exit))
+	r.Register("fg", Func(Foreground))
+	r.Register("help", Func(Help))
+	r.Register("history", Func(History))
+	r.Register("jobs", Func(ListJobs))
+	r.Register("kill", Func(Kill))
+	r.Register("pwd", Func(PrintWorkingDirectory))
+
+	return r
+}
+
+// Register registers b as the builtin called name, replacing any builtin already called that.
+func (r *Registry) Register(name string, b Builtin) {
+	r.builtins[name] = b
+}
+
+// Lookup returns the builtin called name, and whether there is one.
+func (r *Registry) Lookup(name string) (Builtin, bool) {
+	b, ok := r.builtins[name]
+	return b, ok
+}
+
+// Names returns the names of the registered builtins, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.builtins))
+	for name := range r.builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Exit asks the shell to exit.
+func Exit(ctx Context, args ...string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: expected no arguments", ErrInvalidArgCount)
+	}
+	ctx.Shell.Exit()
+
+	return nil
+}
+
+// Help lists the shell's builtins.
+func Help(ctx Context, args ...string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: expected no arguments", ErrInvalidArgCount)
+	}
+	for _, name := range ctx.Shell.Builtins().Names() {
+		if _, err := fmt.Fprintln(ctx.Stdout, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package builtins
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeShell records what builtins ask of the shell.
+type fakeShell struct {
+	exited   bool
+	history  []string
+	registry *Registry
+	jobs     *fakeJobs
+}
+
+func (s *fakeShell) Exit()               { s.exited = true }
+func (s *fakeShell) History() []string   { return s.history }
+func (s *fakeShell) ClearHistory() error { s.history = nil; return nil }
+func (s *fakeShell) Builtins() *Registry { return s.registry }
+func (s *fakeShell) Jobs() Jobs          { return s.jobs }
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	if _, ok := r.Lookup("greet"); ok {
+		t.Fatal("Lookup() found a builtin in an empty registry")
+	}
+	var got []string
+	r.Register("greet", Func(func(_ Context, args ...string) error {
+		got = args
+		return nil
+	}))
+	b, ok := r.Lookup("greet")
+	if !ok {
+		t.Fatal("Lookup() didn't find a registered builtin")
+	}
+	if err := b.Run(Context{}, "a", "b"); err != nil || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Run() = %v with args %v, want nil with [a b]", err, got)
+	}
+	if names := Defaults().Names(); !reflect.DeepEqual(names, []string{
+		"bg", "cd", "env", "exit", "fg", "help", "history", "jobs", "kill", "pwd",
+	}) {
+		t.Errorf("Defaults().Names() = %v", names)
+	}
+}
+
+func TestShellBuiltins(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		builtin    Func
+		args       []string
+		wantOut    string
+		wantExited bool
+		wantErr    error
+	}{
+		{name: "exit", builtin: Exit, wantExited: true},
+		{name: "exit with args", builtin: Exit, args: []string{"1"}, wantErr: ErrInvalidArgCount},
+		{name: "help", builtin: Help, wantOut: "exit\nhistory\n"},
+		{name: "history", builtin: History, wantOut: "    1  ls\n    2  cd /tmp\n    3  history\n"},
+		{name: "history count", builtin: History, args: []string{"2"}, wantOut: "    2  cd /tmp\n    3  history\n"},
+		{name: "history count past the start", builtin: History, args: []string{"9"}, wantOut: "    1  ls\n    2  cd /tmp\n    3  history\n"},
+		{name: "history bad count", builtin: History, args: []string{"x"}, wantErr: ErrInvalidArgCount},
+		{name: "pwd with args", builtin: PrintWorkingDirectory, args: []string{"-P"}, wantErr: ErrInvalidArgCount},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			registry := NewRegistry()
+			registry.Register("history", Func(History))
+			registry.Register("exit", Func(Exit))
+			sh := &fakeShell{history: []string{"ls", "cd /tmp", "history"}, registry: registry}
+			var out bytes.Buffer
+			err := tt.builtin.Run(Context{Stdout: &out, Shell: sh}, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.wantOut || sh.exited != tt.wantExited {
+				t.Errorf("Run() wrote %q and exited %v, want %q and %v", out.String(), sh.exited, tt.wantOut, tt.wantExited)
+			}
+		})
+	}
+}
+
+func TestHistoryClear(t *testing.T) {
+	t.Parallel()
+	sh := &fakeShell{history: []string{"ls", "history -c"}}
+	var out bytes.Buffer
+	if err := History(Context{Stdout: &out, Shell: sh}, "-c"); err != nil || out.Len() > 0 || len(sh.history) > 0 {
+		t.Errorf("History(-c) = %v, wrote %q and left %v, want nil, nothing and no history", err, out.String(), sh.history)
+	}
+}
+
+func TestPrintWorkingDirectory(t *testing.T) {
+	// Not parallel: it changes the working directory.
+	tmp := t.TempDir()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := PrintWorkingDirectory(Context{Stdout: &out}); err != nil {
+		t.Fatalf("PrintWorkingDirectory() error = %v", err)
+	}
+	d1, err := os.Stat(strings.TrimSuffix(out.String(), "\n"))
+	if err != nil {
+		t.Fatalf("PrintWorkingDirectory() printed %q: %v", out.String(), err)
+	}
+	if d2, _ := os.Stat(tmp); !os.SameFile(d1, d2) {
+		t.Errorf("PrintWorkingDirectory() = %q, want %v", out.String(), tmp)
+	}
+}
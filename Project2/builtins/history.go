@@ -0,0 +1,41 @@
+package builtins
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// History lists the shell's command history, numbered from 1, or only the last n entries when given n. Given -c,
+// it clears the history instead.
+func History(ctx Context, args ...string) error {
+	history := ctx.Shell.History()
+	switch len(args) {
+	case 0:
+	case 1:
+		if args[0] == "-c" {
+			return ctx.Shell.ClearHistory()
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return fmt.Errorf("%w: %q is not a count", ErrInvalidArgCount, args[0])
+		}
+		if n < len(history) {
+			return printHistory(ctx, history[len(history)-n:], len(history)-n+1)
+		}
+	default:
+		return fmt.Errorf("%w: expected zero or one arguments (count or -c)", ErrInvalidArgCount)
+	}
+
+	return printHistory(ctx, history, 1)
+}
+
+// printHistory prints entries numbered from first.
+func printHistory(ctx Context, entries []string, first int) error {
+	for i, line := range entries {
+		if _, err := fmt.Fprintf(ctx.Stdout, "%5d  %s\n", first+i, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var (
+	// ErrNoSuchJob is returned for a job spec that doesn't name a job of the shell.
+	ErrNoSuchJob = errors.New("no such job")
+	// ErrNoJobControl is returned for signaling a job or process on a platform without job control, e.g. Windows.
+	ErrNoJobControl = errors.New("job control is not supported on this platform")
+)
+
+type (
+	// Job is a pipeline the shell runs in the background, as the job-control builtins see it.
+	Job struct {
+		// ID is the job's number, e.g. 1 for %1.
+		ID      int
+		Command string
+		// Status is Running or Stopped, and once it's finished, Done, Exit N for the status N of its last command,
+		// or the signal that killed it, e.g. Killed.
+		Status string
+	}
+	// Jobs is the shell's table of background jobs.
+	Jobs interface {
+		// List returns the jobs, oldest first, including those that have finished but haven't been reported yet.
+		List() []Job
+		// Foreground hands a job the terminal, if the shell has one, continues it if it's stopped, and waits for it
+		// to finish, returning its error, and forgets it; or to stop again, returning an error saying so.
+		Foreground(id int) error
+		// Background continues a stopped job in the background.
+		Background(id int) error
+		// Signal sends sig to the processes of a job.
+		Signal(id int, sig syscall.Signal) error
+	}
+)
+
+// ListJobs lists the shell's background jobs, e.g. "[1]  Running    sleep 10".
+func ListJobs(ctx Context, args ...string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: expected no arguments", ErrInvalidArgCount)
+	}
+	for _, job := range ctx.Shell.Jobs().List() {
+		if _, err := fmt.Fprintf(ctx.Stdout, "[%d]  %-10s %s\n", job.ID, job.Status, job.Command); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Foreground waits for a job, the most recent one unless given a job spec, continuing it if it's stopped.
+func Foreground(ctx Context, args ...string) error {
+	job, err := findJob(ctx.Shell.Jobs(), args...)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(ctx.Stdout, job.Command); err != nil {
+		return err
+	}
+
+	return ctx.Shell.Jobs().Foreground(job.ID)
+}
+
+// Background continues a stopped job, the most recent one unless given a job spec, in the background.
+func Background(ctx Context, args ...string) error {
+	job, err := findJob(ctx.Shell.Jobs(), args...)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Shell.Jobs().Background(job.ID); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(ctx.Stdout, "[%d]  %s &\n", job.ID, job.Command)
+
+	return err
+}
+
+// Kill sends a signal, TERM unless given one as -NAME or -NUMBER, to each job, given by a job spec like %1, or
+// process ID.
+func Kill(ctx Context, args ...string) error {
+	sig := syscall.SIGTERM
+	if len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		var err error
+		if sig, err = parseSignal(args[0][1:]); err != nil {
+			return err
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected at least one job or process ID", ErrInvalidArgCount)
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "%") {
+			job, err := findJob(ctx.Shell.Jobs(), arg)
+			if err != nil {
+				return err
+			}
+			if err := ctx.Shell.Jobs().Signal(job.ID, sig); err != nil {
+				return fmt.Errorf("%s: %w", arg, err)
+			}
+			continue
+		}
+		pid, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a job spec or process ID", ErrInvalidArgCount, arg)
+		}
+		if err := kill(pid, sig); err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+	}
+
+	return nil
+}
+
+// parseSignal parses a signal name, with or without SIG, or number.
+func parseSignal(s string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(s); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+	if sig, ok := signals[strings.TrimPrefix(strings.ToUpper(s), "SIG")]; ok {
+		return sig, nil
+	}
+
+	return 0, fmt.Errorf("%w: unknown signal %q", ErrInvalidArgCount, s)
+}
+
+// findJob returns the job given by a job spec, %N or N, or the most recent job when given none.
+func findJob(jobs Jobs, args ...string) (Job, error) {
+	list := jobs.List()
+	switch len(args) {
+	case 0:
+		if len(list) == 0 {
+			return Job{}, fmt.Errorf("%w: no current job", ErrNoSuchJob)
+		}
+		return list[len(list)-1], nil
+	case 1:
+		id, err := strconv.Atoi(strings.TrimPrefix(args[0], "%"))
+		if err != nil {
+			return Job{}, fmt.Errorf("%w: %q is not a job spec", ErrInvalidArgCount, args[0])
+		}
+		for _, job := range list {
+			if job.ID == id {
+				return job, nil
+			}
+		}
+		return Job{}, fmt.Errorf("%w: %s", ErrNoSuchJob, args[0])
+	default:
+		return Job{}, fmt.Errorf("%w: expected zero or one arguments (job)", ErrInvalidArgCount)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package builtins
+
+import "syscall"
+
+// signals are the signals kill knows by name. Without job control there are no signals to stop and continue jobs.
+var signals = map[string]syscall.Signal{
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"TERM": syscall.SIGTERM,
+}
+
+// kill can't signal processes without job control.
+func kill(int, syscall.Signal) error {
+	return ErrNoJobControl
+}
@@ -0,0 +1,93 @@
+package builtins
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+)
+
+// fakeJobs records what the job-control builtins ask of the job table.
+type fakeJobs struct {
+	jobs  []Job
+	calls []string
+}
+
+func (j *fakeJobs) List() []Job { return j.jobs }
+
+func (j *fakeJobs) Foreground(id int) error {
+	j.calls = append(j.calls, fmt.Sprintf("fg %d", id))
+	return nil
+}
+
+func (j *fakeJobs) Background(id int) error {
+	j.calls = append(j.calls, fmt.Sprintf("bg %d", id))
+	return nil
+}
+
+func (j *fakeJobs) Signal(id int, sig syscall.Signal) error {
+	j.calls = append(j.calls, fmt.Sprintf("signal %d %d", id, sig))
+	return nil
+}
+
+func TestJobBuiltins(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		builtin   Func
+		args      []string
+		wantOut   string
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:    "jobs",
+			builtin: ListJobs,
+			wantOut: "[1]  Stopped    vi notes\n[3]  Running    sleep 10 | cat\n",
+		},
+		{name: "jobs with args", builtin: ListJobs, args: []string{"-l"}, wantErr: ErrInvalidArgCount},
+		{name: "fg", builtin: Foreground, wantOut: "sleep 10 | cat\n", wantCalls: []string{"fg 3"}},
+		{name: "fg job", builtin: Foreground, args: []string{"%1"}, wantOut: "vi notes\n", wantCalls: []string{"fg 1"}},
+		{name: "fg job number", builtin: Foreground, args: []string{"1"}, wantOut: "vi notes\n", wantCalls: []string{"fg 1"}},
+		{name: "fg no such job", builtin: Foreground, args: []string{"%2"}, wantErr: ErrNoSuchJob},
+		{name: "fg bad job", builtin: Foreground, args: []string{"%x"}, wantErr: ErrInvalidArgCount},
+		{name: "fg two jobs", builtin: Foreground, args: []string{"%1", "%3"}, wantErr: ErrInvalidArgCount},
+		{name: "bg", builtin: Background, args: []string{"%1"}, wantOut: "[1]  vi notes &\n", wantCalls: []string{"bg 1"}},
+		{
+			name:      "kill",
+			builtin:   Kill,
+			args:      []string{"%1", "%3"},
+			wantCalls: []string{fmt.Sprintf("signal 1 %d", syscall.SIGTERM), fmt.Sprintf("signal 3 %d", syscall.SIGTERM)},
+		},
+		{
+			name:      "kill signal name",
+			builtin:   Kill,
+			args:      []string{"-sigquit", "%3"},
+			wantCalls: []string{fmt.Sprintf("signal 3 %d", syscall.SIGQUIT)},
+		},
+		{name: "kill signal number", builtin: Kill, args: []string{"-9", "%1"}, wantCalls: []string{"signal 1 9"}},
+		{name: "kill unknown signal", builtin: Kill, args: []string{"-NOPE", "%1"}, wantErr: ErrInvalidArgCount},
+		{name: "kill nothing", builtin: Kill, args: []string{"-KILL"}, wantErr: ErrInvalidArgCount},
+		{name: "kill no such job", builtin: Kill, args: []string{"%2"}, wantErr: ErrNoSuchJob},
+		{name: "kill bad process ID", builtin: Kill, args: []string{"sleep"}, wantErr: ErrInvalidArgCount},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			jobs := &fakeJobs{jobs: []Job{
+				{ID: 1, Command: "vi notes", Status: "Stopped"},
+				{ID: 3, Command: "sleep 10 | cat", Status: "Running"},
+			}}
+			var out bytes.Buffer
+			err := tt.builtin.Run(Context{Stdout: &out, Shell: &fakeShell{jobs: jobs}}, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.wantOut || fmt.Sprint(jobs.calls) != fmt.Sprint(tt.wantCalls) {
+				t.Errorf("Run() wrote %q and called %v, want %q and %v", out.String(), jobs.calls, tt.wantOut, tt.wantCalls)
+			}
+		})
+	}
+}
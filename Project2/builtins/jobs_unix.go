@@ -0,0 +1,24 @@
+//go:build unix
+
+package builtins
+
+import "syscall"
+
+// signals are the signals kill knows by name.
+var signals = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"TERM": syscall.SIGTERM,
+	"CONT": syscall.SIGCONT,
+	"STOP": syscall.SIGSTOP,
+	"TSTP": syscall.SIGTSTP,
+}
+
+// kill sends sig to the process pid.
+func kill(pid int, sig syscall.Signal) error {
+	return syscall.Kill(pid, sig)
+}
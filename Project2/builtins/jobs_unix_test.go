@@ -0,0 +1,26 @@
+//go:build unix
+
+package builtins
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestKillProcess(t *testing.T) {
+	t.Parallel()
+	cmd := exec.Command("sleep", "10")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	pid := strconv.Itoa(cmd.Process.Pid)
+	if err := Kill(Context{Shell: &fakeShell{jobs: &fakeJobs{}}}, "-KILL", pid); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	if err := cmd.Wait(); err == nil || cmd.ProcessState.Sys().(syscall.WaitStatus).Signal() != os.Kill {
+		t.Errorf("Wait() = %v, want killed", err)
+	}
+}
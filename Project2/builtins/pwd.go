@@ -0,0 +1,20 @@
+package builtins
+
+import (
+	"fmt"
+	"os"
+)
+
+// PrintWorkingDirectory prints the current working directory.
+func PrintWorkingDirectory(ctx Context, args ...string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: expected no arguments", ErrInvalidArgCount)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(ctx.Stdout, wd)
+
+	return err
+}
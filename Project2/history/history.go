@@ -0,0 +1,238 @@
+// Package history keeps a shell's command history, saving it to a file, and expands history references like !!.
+package history
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultSize is the number of lines a history keeps unless told otherwise.
+const DefaultSize = 1000
+
+// ErrEventNotFound is returned for a history reference to a line that isn't in the history.
+var ErrEventNotFound = errors.New("event not found")
+
+// History is a command history, oldest first, numbered from 1. It's safe for concurrent use.
+type History struct {
+	mu      sync.Mutex
+	entries []string
+	// path is the file the history is saved to, or empty when it's only kept in memory.
+	path string
+	size int
+}
+
+// New returns an empty history of up to size lines, kept only in memory.
+func New(size int) *History {
+	return &History{size: size}
+}
+
+// Load returns the history saved in the file at path, keeping the last size lines, and saves the lines added to it
+// there as they're added. A file that doesn't exist yet is created when the first line is added.
+func Load(path string, size int) (*History, error) {
+	h := &History{path: path, size: size}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		if line = strings.TrimSuffix(line, "\n"); line != "" {
+			h.entries = append(h.entries, line)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading history %s: %w", path, err)
+		}
+	}
+	if len(h.entries) > size {
+		// Lines are only ever appended to the file, so it's trimmed to size here.
+		h.entries = h.entries[len(h.entries)-size:]
+		if err := h.rewrite(); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// Add adds a line to the end of the history, dropping the oldest line when it's full, and saves it.
+func (h *History) Add(line string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.size {
+		h.entries = append([]string(nil), h.entries[len(h.entries)-h.size:]...)
+	}
+	if h.path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(h.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Clear removes every line from the history and its file.
+func (h *History) Clear() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = nil
+	if h.path == "" {
+		return nil
+	}
+
+	return h.rewrite()
+}
+
+// Entries returns the lines of the history, oldest first.
+func (h *History) Entries() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]string(nil), h.entries...)
+}
+
+// Search returns the index of the newest line before index before that contains query, and whether there is one.
+func (h *History) Search(query string, before int) (int, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if before > len(h.entries) {
+		before = len(h.entries)
+	}
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// Expand expands the history references of a line: !! for the last line, !N for line N, !-N for the Nth line
+// back, and !PREFIX for the last line starting with PREFIX. A ! followed by a space or =, or at the end of the
+// line, isn't a reference, and neither is one in single quotes or escaped with a backslash. Quotes are read as
+// the shell reads them, so a single quote inside double quotes doesn't start a quote. It returns the expanded
+// line, and whether it has any references.
+func (h *History) Expand(line string) (string, bool, error) {
+	if !strings.Contains(line, "!") {
+		return line, false, nil
+	}
+	entries := h.Entries()
+	var (
+		expanded strings.Builder
+		found    bool
+		quote    byte // the quote the line is in, if any
+	)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == quote:
+			quote = 0
+		case (c == '\'' || c == '"') && quote == 0:
+			quote = c
+		case c == '\\' && quote != '\'' && i+1 < len(line):
+			expanded.WriteByte(c)
+			i++
+			c = line[i]
+		case c == '!' && quote != '\'' && i+1 < len(line) && !strings.ContainsRune(" \t=", rune(line[i+1])):
+			ref := reference(line[i+1:])
+			entry, err := lookup(entries, ref)
+			if err != nil {
+				return "", false, err
+			}
+			expanded.WriteString(entry)
+			found = true
+			i += len(ref)
+			continue
+		}
+		expanded.WriteByte(c)
+	}
+
+	return expanded.String(), found, nil
+}
+
+// reference returns the history reference at the start of s, after its !.
+func reference(s string) string {
+	if s[0] == '!' {
+		return "!"
+	}
+	end := 1
+	if s[0] == '-' || s[0] >= '0' && s[0] <= '9' {
+		for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+			end++
+		}
+		return s[:end]
+	}
+	// A prefix runs to the end of the word.
+	for end < len(s) && !strings.ContainsRune(" \t|&<>;'\"", rune(s[end])) {
+		end++
+	}
+
+	return s[:end]
+}
+
+// lookup returns the line of entries a history reference, without its !, refers to.
+func lookup(entries []string, ref string) (string, error) {
+	n := len(entries)
+	switch {
+	case ref == "!" && n > 0:
+		return entries[n-1], nil
+	case ref[0] == '-' || ref[0] >= '0' && ref[0] <= '9':
+		i, err := strconv.Atoi(ref)
+		if err != nil {
+			break
+		}
+		if i < 0 {
+			i += n + 1
+		}
+		if i >= 1 && i <= n {
+			return entries[i-1], nil
+		}
+	default:
+		for i := n - 1; i >= 0; i-- {
+			if strings.HasPrefix(entries[i], ref) {
+				return entries[i], nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("!%s: %w", ref, ErrEventNotFound)
+}
+
+// rewrite replaces the history's file with its lines. The history must be locked.
+func (h *History) rewrite() error {
+	f, err := os.OpenFile(h.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, line := range h.entries {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
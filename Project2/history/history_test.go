@@ -0,0 +1,155 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "history")
+
+	h, err := Load(path, 3)
+	if err != nil {
+		t.Fatalf("Load() of a missing file error = %v", err)
+	}
+	for _, line := range []string{"ls", "cd /tmp", "echo 'a  b'", "pwd"} {
+		if err := h.Add(line); err != nil {
+			t.Fatalf("Add(%q) error = %v", line, err)
+		}
+	}
+	if got, want := h.Entries(), []string{"cd /tmp", "echo 'a  b'", "pwd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %q, want %q", got, want)
+	}
+	if got := readFile(t, path); got != "ls\ncd /tmp\necho 'a  b'\npwd\n" {
+		t.Errorf("file = %q, want every line added", got)
+	}
+
+	// Loading keeps the last lines, and trims the file to them.
+	h, err = Load(path, 3)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := h.Entries(), []string{"cd /tmp", "echo 'a  b'", "pwd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() after Load() = %q, want %q", got, want)
+	}
+	if got := readFile(t, path); got != "cd /tmp\necho 'a  b'\npwd\n" {
+		t.Errorf("file after Load() = %q, want the last 3 lines", got)
+	}
+
+	if err := h.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if got := readFile(t, path); len(h.Entries()) > 0 || got != "" {
+		t.Errorf("Clear() left %q and file %q", h.Entries(), got)
+	}
+}
+
+func TestLoad_errors(t *testing.T) {
+	t.Parallel()
+	if _, err := Load(t.TempDir(), DefaultSize); err == nil {
+		t.Error("Load() of a directory succeeded")
+	}
+	h := &History{path: filepath.Join(t.TempDir(), "missing", "history"), size: DefaultSize}
+	if err := h.Add("ls"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Add() error = %v, want %v", err, os.ErrNotExist)
+	}
+	if got := h.Entries(); !reflect.DeepEqual(got, []string{"ls"}) {
+		t.Errorf("Entries() = %q, want the line added though it couldn't be saved", got)
+	}
+}
+
+func TestHistory_Expand(t *testing.T) {
+	t.Parallel()
+	h := New(DefaultSize)
+	for _, line := range []string{"ls -l", "cd /tmp", "echo hello", "cat notes | wc -l"} {
+		_ = h.Add(line)
+	}
+	tests := []struct {
+		name     string
+		line     string
+		want     string
+		expanded bool
+		wantErr  error
+	}{
+		{name: "none", line: "echo hi", want: "echo hi"},
+		{name: "last", line: "!!", want: "cat notes | wc -l", expanded: true},
+		{name: "last with more", line: "!! > out", want: "cat notes | wc -l > out", expanded: true},
+		{name: "number", line: "!2", want: "cd /tmp", expanded: true},
+		{name: "back", line: "!-2", want: "echo hello", expanded: true},
+		{name: "prefix", line: "!ec", want: "echo hello", expanded: true},
+		{name: "prefix ends at the word", line: "!c|wc", want: "cat notes | wc -l|wc", expanded: true},
+		{name: "several", line: "!1; !!", want: "ls -l; cat notes | wc -l", expanded: true},
+		{name: "inside a word", line: "x!1y", want: "xls -ly", expanded: true},
+		{name: "alone", line: "echo hi !", want: "echo hi !"},
+		{name: "before a space", line: "echo ! hi", want: "echo ! hi"},
+		{name: "before =", line: "test a != b", want: "test a != b"},
+		{name: "single quoted", line: "echo '!!' !1", want: "echo '!!' ls -l", expanded: true},
+		{name: "escaped", line: `echo \!! !2`, want: `echo \!! cd /tmp`, expanded: true},
+		{name: "double quoted", line: `echo "!!"`, want: `echo "cat notes | wc -l"`, expanded: true},
+		{name: "single quote in double quotes", line: `echo "it's" !1`, want: `echo "it's" ls -l`, expanded: true},
+		{name: "single quoted after double quotes", line: `echo "'" '!!'`, want: `echo "'" '!!'`},
+		{name: "double quote in single quotes", line: `echo '"' !1 '!!'`, want: `echo '"' ls -l '!!'`, expanded: true},
+		{name: "number past the end", line: "!5", wantErr: ErrEventNotFound},
+		{name: "number 0", line: "!0", wantErr: ErrEventNotFound},
+		{name: "back past the start", line: "!-5", wantErr: ErrEventNotFound},
+		{name: "no prefix match", line: "!vi", wantErr: ErrEventNotFound},
+		{name: "bad number", line: "!-", wantErr: ErrEventNotFound},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, expanded, err := h.Expand(tt.line)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || expanded != tt.expanded {
+				t.Errorf("Expand() = %q, %v, want %q, %v", got, expanded, tt.want, tt.expanded)
+			}
+		})
+	}
+
+	if _, _, err := New(DefaultSize).Expand("!!"); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("Expand(!!) of an empty history error = %v, want %v", err, ErrEventNotFound)
+	}
+}
+
+func TestHistory_Search(t *testing.T) {
+	t.Parallel()
+	h := New(DefaultSize)
+	for _, line := range []string{"make test", "ls", "make build", "git status"} {
+		_ = h.Add(line)
+	}
+	tests := []struct {
+		query  string
+		before int
+		want   int
+		wantOK bool
+	}{
+		{query: "make", before: 4, want: 2, wantOK: true},
+		{query: "make", before: 2, want: 0, wantOK: true},
+		{query: "make", before: 0},
+		{query: "", before: 9, want: 3, wantOK: true},
+		{query: "vim", before: 4},
+	}
+	for _, tt := range tests {
+		got, ok := h.Search(tt.query, tt.before)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Search(%q, %d) = %d, %v, want %d, %v", tt.query, tt.before, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
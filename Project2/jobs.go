@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/nluthra2001/CSCE4600/Project2/builtins"
+)
+
+var (
+	// errJobFinished is returned for continuing or signaling a job that has already finished.
+	errJobFinished = errors.New("job has finished")
+	// errNoProcesses is returned for signaling a job of only builtins, which run in the shell itself.
+	errNoProcesses = errors.New("job has no processes")
+	// errJobStopped is wrapped by the error of a foreground job that is stopped, e.g. by ^Z, and so left in the
+	// background.
+	errJobStopped = errors.New("job stopped")
+)
+
+// statusStopped is the status of a stopped job.
+const statusStopped = "Stopped"
+
+// job is a pipeline running in the background, or in the foreground until it's stopped.
+type job struct {
+	id      int
+	command string
+	// pgid is the process group of the job's programs, or 0 when it's only builtins.
+	pgid    int
+	stopped bool
+	// done is closed once every stage has finished, and status and err are set.
+	done   chan struct{}
+	status string
+	err    error
+}
+
+// finished reports whether every stage of the job has finished.
+func (j *job) finished() bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// info describes the job for the builtins.
+func (j *job) info() builtins.Job {
+	status := "Running"
+	switch {
+	case j.finished():
+		status = j.status
+	case j.stopped:
+		status = statusStopped
+	}
+
+	return builtins.Job{ID: j.id, Command: j.command, Status: status}
+}
+
+// newJob returns the started stages of a pipeline as a job, waiting for them in the background.
+func newJob(command string, pgid int, stages []stage, results []chan error) *job {
+	j := &job{command: command, pgid: pgid, done: make(chan struct{})}
+	go func() {
+		errs := wait(stages, results)
+		j.status, j.err = exitStatus(errs[len(errs)-1]), combine(errs)
+		close(j.done)
+	}()
+
+	return j
+}
+
+// stoppedError is the error of a job that was stopped in the foreground, which reads like its line in jobs.
+type stoppedError struct {
+	*job
+}
+
+func (e stoppedError) Error() string {
+	return fmt.Sprintf("[%d]  %s %s", e.id, statusStopped, e.command)
+}
+
+func (e stoppedError) Unwrap() error {
+	return errJobStopped
+}
+
+// jobTable is the shell's table of background jobs. It implements builtins.Jobs.
+type jobTable struct {
+	mu   sync.Mutex
+	jobs []*job
+	// tty is the terminal jobs are handed in the foreground, or nil without job control.
+	tty *terminal
+}
+
+// add adds a job to the table, numbered one more than the newest job.
+func (t *jobTable) add(j *job) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	j.id = 1
+	if n := len(t.jobs); n > 0 {
+		j.id = t.jobs[n-1].id + 1
+	}
+	t.jobs = append(t.jobs, j)
+}
+
+// List implements builtins.Jobs.
+func (t *jobTable) List() []builtins.Job {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list := make([]builtins.Job, len(t.jobs))
+	for i, j := range t.jobs {
+		list[i] = j.info()
+	}
+
+	return list
+}
+
+// Foreground implements builtins.Jobs. The job has the terminal until it finishes or is stopped again, and is only
+// forgotten once it finishes.
+func (t *jobTable) Foreground(id int) error {
+	t.mu.Lock()
+	j, err := t.find(id)
+	if err == nil && !j.finished() {
+		err = t.tty.give(j.pgid)
+	}
+	if err == nil && j.stopped && !j.finished() {
+		err = t.cont(j)
+	}
+	t.mu.Unlock()
+	if err != nil {
+		t.tty.takeBack()
+		return err
+	}
+
+	stopped := t.tty.wait(j.pgid, j.done)
+	t.tty.takeBack()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if stopped {
+		j.stopped = true
+		return stoppedError{j}
+	}
+	t.remove(j)
+
+	return j.err
+}
+
+// Background implements builtins.Jobs.
+func (t *jobTable) Background(id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	j, err := t.find(id)
+	if err != nil {
+		return err
+	}
+	if !j.stopped {
+		return fmt.Errorf("job %d is already running in the background", id)
+	}
+
+	return t.cont(j)
+}
+
+// Signal implements builtins.Jobs.
+func (t *jobTable) Signal(id int, sig syscall.Signal) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	j, err := t.find(id)
+	if err != nil {
+		return err
+	}
+
+	return t.signal(j, sig)
+}
+
+// signal sends sig to the process group of a job.
+func (t *jobTable) signal(j *job, sig syscall.Signal) error {
+	switch {
+	case j.finished():
+		return errJobFinished
+	case j.pgid == 0:
+		return errNoProcesses
+	}
+
+	return j.signal(sig)
+}
+
+// reap removes the jobs that have finished from the table, and returns them to be reported.
+func (t *jobTable) reap() []builtins.Job {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var finished []builtins.Job
+	for _, j := range append([]*job(nil), t.jobs...) {
+		if j.finished() {
+			finished = append(finished, j.info())
+			t.remove(j)
+		}
+	}
+
+	return finished
+}
+
+// find returns the job numbered id. The table must be locked.
+func (t *jobTable) find(id int) (*job, error) {
+	for _, j := range t.jobs {
+		if j.id == id {
+			return j, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %%%d", builtins.ErrNoSuchJob, id)
+}
+
+// remove removes a job from the table, if it's still there. The table must be locked.
+func (t *jobTable) remove(j *job) {
+	for i := range t.jobs {
+		if t.jobs[i] == j {
+			t.jobs = append(t.jobs[:i], t.jobs[i+1:]...)
+			return
+		}
+	}
+}
+
+// exitStatus describes how the last command of a job finished, given its error: Done, Exit N for its exit status
+// N, or the signal that killed it.
+func exitStatus(err error) string {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return "Done"
+	case errors.As(err, &exitErr):
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			name := ws.Signal().String()
+			return strings.ToUpper(name[:1]) + name[1:]
+		}
+		return fmt.Sprintf("Exit %d", exitErr.ExitCode())
+	default:
+		// A builtin that failed, or a program that couldn't start.
+		return "Exit 1"
+	}
+}
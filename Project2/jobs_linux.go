@@ -0,0 +1,21 @@
+package main
+
+import "golang.org/x/sys/unix"
+
+// detectsStops is whether stopped can tell when a job stops.
+const detectsStops = true
+
+// stopped reports whether a process of the group pgid has stopped since it was last checked. It uses waitid, which
+// unlike wait4 reports stops without reaping the processes that have exited, so that exec.Cmd.Wait still can.
+func stopped(pgid int) bool {
+	var found bool
+	for {
+		var info unix.Siginfo
+		err := unix.Waitid(unix.P_PGID, pgid, &info, unix.WSTOPPED|unix.WNOHANG, nil)
+		// Every stopped process is reported, and forgotten, one at a time, until there are none.
+		if err != nil || info.Signo == 0 {
+			return found
+		}
+		found = true
+	}
+}
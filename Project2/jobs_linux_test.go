@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_stopped(t *testing.T) {
+	t.Parallel()
+	cmd := exec.Command("sleep", "10")
+	setpgid(cmd, 0, nil)
+	require.NoError(t, cmd.Start())
+	pgid := cmd.Process.Pid
+	defer func() {
+		_ = syscall.Kill(-pgid, syscall.SIGKILL)
+		_ = cmd.Wait()
+	}()
+
+	require.False(t, stopped(pgid))
+	require.NoError(t, syscall.Kill(-pgid, syscall.SIGSTOP))
+	require.Eventually(t, func() bool { return stopped(pgid) }, time.Second, 10*time.Millisecond)
+	// The stop is reported once.
+	require.False(t, stopped(pgid))
+}
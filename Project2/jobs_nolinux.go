@@ -0,0 +1,12 @@
+//go:build unix && !linux
+
+package main
+
+// detectsStops is whether stopped can tell when a job stops. Without waitid, a stop can't be waited for apart from
+// an exit, so there's no foreground job control.
+const detectsStops = false
+
+// stopped never reports a stop.
+func stopped(int) bool {
+	return false
+}
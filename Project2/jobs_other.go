@@ -0,0 +1,46 @@
+//go:build !unix
+
+package main
+
+import (
+	"io"
+	"os/exec"
+	"syscall"
+
+	"github.com/nluthra2001/CSCE4600/Project2/builtins"
+)
+
+// terminal would be the controlling terminal of an interactive shell, but there's none without job control.
+type terminal struct{}
+
+// newTerminal returns nil: without job control, jobs are never handed the terminal.
+func newTerminal(io.Reader) *terminal {
+	return nil
+}
+
+// give does nothing without job control.
+func (*terminal) give(int) error {
+	return nil
+}
+
+// takeBack does nothing without job control.
+func (*terminal) takeBack() {}
+
+// wait waits until done is closed: without job control no job is ever stopped.
+func (*terminal) wait(_ int, done <-chan struct{}) bool {
+	<-done
+	return false
+}
+
+// setpgid does nothing without process groups: the programs of a job are only waited for, not signaled.
+func setpgid(*exec.Cmd, int, *terminal) {}
+
+// signal can't signal a job without job control.
+func (j *job) signal(syscall.Signal) error {
+	return builtins.ErrNoJobControl
+}
+
+// cont can't continue a job without job control, though no job is ever stopped either.
+func (t *jobTable) cont(*job) error {
+	return builtins.ErrNoJobControl
+}
@@ -0,0 +1,140 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project2/builtins"
+	"github.com/stretchr/testify/require"
+)
+
+// waitJobs waits for every job of a shell to finish.
+func waitJobs(sh *shell) {
+	sh.jobs.mu.Lock()
+	jobs := append([]*job(nil), sh.jobs.jobs...)
+	sh.jobs.mu.Unlock()
+	for _, j := range jobs {
+		<-j.done
+	}
+}
+
+func Test_shell_background(t *testing.T) {
+	t.Parallel()
+	w := &bytes.Buffer{}
+	sh := newShell(strings.NewReader(""), w, &bytes.Buffer{}, make(chan struct{}, 2))
+
+	require.NoError(t, sh.handleInput("sleep 10 | cat &"))
+	require.Regexp(t, `^\[1\] \d+\n$`, w.String())
+	require.Equal(t, []builtins.Job{{ID: 1, Command: "sleep 10 | cat", Status: "Running"}}, sh.jobs.List())
+	require.Equal(t, []string{"sleep 10 | cat &"}, sh.History())
+
+	// The job's programs are in a process group of their own.
+	pgid, err := syscall.Getpgid(sh.jobs.jobs[0].pgid)
+	require.NoError(t, err)
+	require.Equal(t, sh.jobs.jobs[0].pgid, pgid)
+	require.NotEqual(t, syscall.Getpgrp(), pgid)
+
+	require.NoError(t, sh.handleInput("kill -STOP %1"))
+	require.Equal(t, "Stopped", sh.jobs.List()[0].Status)
+	require.Error(t, sh.handleInput("bg %2"))
+	require.NoError(t, sh.handleInput("bg"))
+	require.Equal(t, "Running", sh.jobs.List()[0].Status)
+	require.Error(t, sh.handleInput("bg %1"), "bg of a running job")
+
+	require.NoError(t, sh.handleInput("true &"))
+	require.NoError(t, sh.handleInput("kill -STOP %1"))
+	// A stopped job is continued after being killed, so that it terminates.
+	require.NoError(t, sh.handleInput("kill %1"))
+	waitJobs(sh)
+	w.Reset()
+	require.NoError(t, sh.handleInput("jobs"))
+	require.Equal(t, "[1]  Terminated sleep 10 | cat\n[2]  Done       true\n", w.String())
+	require.True(t, errors.Is(sh.handleInput("kill %1"), errJobFinished))
+
+	w.Reset()
+	sh.reportJobs()
+	require.Equal(t, "[1]  Terminated sleep 10 | cat\n[2]  Done       true\n", w.String())
+	require.Empty(t, sh.jobs.List())
+
+	// Job numbers start again once the table is empty.
+	w.Reset()
+	require.NoError(t, sh.handleInput("sleep 10 &"))
+	require.Regexp(t, `^\[1\] \d+\n$`, w.String())
+	require.NoError(t, sh.handleInput("kill -KILL %1"))
+	waitJobs(sh)
+	require.Equal(t, "Killed", sh.jobs.List()[0].Status)
+}
+
+func Test_shell_foreground(t *testing.T) {
+	t.Parallel()
+	w := &bytes.Buffer{}
+	sh := newShell(strings.NewReader(""), w, &bytes.Buffer{}, make(chan struct{}, 2))
+
+	require.NoError(t, sh.handleInput("sleep 0.1 &"))
+	require.NoError(t, sh.handleInput("kill -TSTP %1"))
+	// fg continues the stopped job and waits for it.
+	require.NoError(t, sh.handleInput("fg"))
+	require.Empty(t, sh.jobs.List())
+
+	require.NoError(t, sh.handleInput("sh -c 'exit 3' &"))
+	err := sh.handleInput("fg %1")
+	var exitErr *exec.ExitError
+	require.True(t, errors.As(err, &exitErr))
+	require.Equal(t, 3, exitErr.ExitCode())
+	require.True(t, errors.Is(sh.handleInput("fg"), builtins.ErrNoSuchJob))
+	require.Contains(t, w.String(), "sh -c 'exit 3'\n")
+}
+
+func Test_shell_background_builtins(t *testing.T) {
+	t.Parallel()
+	w := &bytes.Buffer{}
+	sh := newShell(strings.NewReader(""), w, &bytes.Buffer{}, make(chan struct{}, 2))
+
+	require.NoError(t, sh.handleInput(fmt.Sprintf("history > %s/out &", t.TempDir())))
+	require.Equal(t, "[1]\n", w.String())
+	require.True(t, errors.Is(sh.jobs.Signal(1, syscall.SIGTERM), errNoProcesses))
+	require.NoError(t, sh.handleInput("fg"))
+
+	require.NoError(t, sh.handleInput("cd /no/such/dir &"))
+	waitJobs(sh)
+	require.Equal(t, "Exit 1", sh.jobs.List()[0].Status)
+}
+
+func Test_exitStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		cmd  *exec.Cmd
+		want string
+	}{
+		{name: "done", cmd: exec.Command("true"), want: "Done"},
+		{name: "exit", cmd: exec.Command("sh", "-c", "exit 7"), want: "Exit 7"},
+		{name: "signal", cmd: exec.Command("sh", "-c", "kill -KILL $$"), want: "Killed"},
+		{name: "not started", cmd: exec.Command("no-such-program-here"), want: "Exit 1"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.cmd.Run()
+			if err != nil {
+				err = fmt.Errorf("%s: %w", tt.name, err)
+			}
+			require.Equal(t, tt.want, exitStatus(err))
+		})
+	}
+}
+
+func Test_stoppedError(t *testing.T) {
+	t.Parallel()
+	var err error = stoppedError{&job{id: 2, command: "vi notes"}}
+	require.Equal(t, "[2]  Stopped vi notes", err.Error())
+	require.True(t, errors.Is(err, errJobStopped))
+}
@@ -0,0 +1,132 @@
+//go:build unix
+
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// terminal is the controlling terminal of an interactive shell, which it hands to its foreground jobs.
+type terminal struct {
+	fd int
+	// pgid is the shell's own process group.
+	pgid int
+	// children is notified when a child of the shell stops or exits.
+	children chan os.Signal
+}
+
+// newTerminal returns the terminal the shell reads r from, or nil, and no job control, when r isn't a terminal the
+// shell is in the foreground of, or stops can't be told apart from exits on this platform. The shell catches and
+// drops ^C, ^\ and ^Z rather than ignoring them, so that the programs it starts get their default dispositions
+// back.
+func newTerminal(r io.Reader) *terminal {
+	f, ok := r.(*os.File)
+	if !ok || !detectsStops || !isTerminal(f) {
+		return nil
+	}
+	t := &terminal{fd: int(f.Fd()), pgid: syscall.Getpgrp(), children: make(chan os.Signal, 1)}
+	if pgid, err := t.foreground(); err != nil || pgid != t.pgid {
+		return nil
+	}
+	signal.Notify(make(chan os.Signal, 1), syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTSTP)
+	signal.Notify(t.children, syscall.SIGCHLD)
+
+	return t
+}
+
+// foreground returns the process group in the foreground of the terminal.
+func (t *terminal) foreground() (int, error) {
+	var pgid int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(t.fd), syscall.TIOCGPGRP, uintptr(unsafe.Pointer(&pgid))); errno != 0 {
+		return 0, errno
+	}
+
+	return int(pgid), nil
+}
+
+// give puts the process group pgid in the foreground of the terminal, so that it reads the terminal and gets the
+// signals typed at it.
+func (t *terminal) give(pgid int) error {
+	if t == nil || pgid == 0 {
+		return nil
+	}
+	id := int32(pgid)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(t.fd), syscall.TIOCSPGRP, uintptr(unsafe.Pointer(&id))); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// takeBack puts the shell back in the foreground of the terminal. It does so from the background, which stops the
+// shell with SIGTTOU unless it's ignored, so it is, only for as long as it takes.
+func (t *terminal) takeBack() {
+	if t == nil {
+		return
+	}
+	signal.Ignore(syscall.SIGTTOU)
+	defer signal.Reset(syscall.SIGTTOU)
+	_ = t.give(t.pgid)
+}
+
+// wait waits until done is closed, or the process group pgid is stopped, e.g. by ^Z, and reports whether it was
+// stopped.
+func (t *terminal) wait(pgid int, done <-chan struct{}) bool {
+	if t == nil || pgid == 0 {
+		<-done
+		return false
+	}
+	for {
+		select {
+		case <-done:
+			return false
+		case <-t.children:
+			if stopped(pgid) {
+				return true
+			}
+		}
+	}
+}
+
+// setpgid puts the program of cmd in the process group pgid, or in a new one it leads when pgid is 0, in the
+// foreground of tty unless it's nil.
+func setpgid(cmd *exec.Cmd, pgid int, tty *terminal) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: pgid}
+	if tty != nil {
+		// The program takes the terminal itself, before it runs, so that it can't read it while it's still the
+		// shell's.
+		cmd.SysProcAttr.Foreground, cmd.SysProcAttr.Ctty = true, tty.fd
+	}
+}
+
+// signal sends sig to the process group of the job, keeping track of whether it's stopped. A stopped job is
+// continued after any other signal, so that it can act on it, e.g. by terminating.
+func (j *job) signal(sig syscall.Signal) error {
+	// A negative process ID signals the whole process group.
+	if err := syscall.Kill(-j.pgid, sig); err != nil {
+		return err
+	}
+	switch sig {
+	case syscall.SIGSTOP, syscall.SIGTSTP, syscall.SIGTTIN, syscall.SIGTTOU:
+		j.stopped = true
+	case syscall.SIGCONT:
+		j.stopped = false
+	default:
+		if j.stopped {
+			j.stopped = false
+			return syscall.Kill(-j.pgid, syscall.SIGCONT)
+		}
+	}
+
+	return nil
+}
+
+// cont continues a stopped job.
+func (t *jobTable) cont(j *job) error {
+	return t.signal(j, syscall.SIGCONT)
+}
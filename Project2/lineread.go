@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/nluthra2001/CSCE4600/Project2/history"
+)
+
+// lineReader reads the shell's input a line at a time, after showing a prompt.
+type lineReader interface {
+	// ReadLine returns the next line, ending with a newline unless it's the last one, when it returns io.EOF.
+	ReadLine(prompt string) (string, error)
+}
+
+// newLineReader returns a lineReader for r: an editor when it's a terminal, and otherwise a plainReader.
+func newLineReader(r io.Reader, w io.Writer, h *history.History) lineReader {
+	plain := &plainReader{in: bufio.NewReader(r), out: w}
+	if f, ok := r.(*os.File); ok && isTerminal(f) {
+		return &editor{plainReader: plain, history: h, raw: func() (func(), error) { return makeRaw(f) }}
+	}
+
+	return plain
+}
+
+// plainReader reads lines as they are, as from a file or pipe.
+type plainReader struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ReadLine implements lineReader.
+func (p *plainReader) ReadLine(prompt string) (string, error) {
+	if _, err := fmt.Fprint(p.out, prompt); err != nil {
+		return "", err
+	}
+
+	return p.in.ReadString('\n')
+}
+
+// Keys the editor acts on.
+const (
+	keyInterrupt = 0x03 // ^C, which raw mode reads as a key rather than turning it into a signal
+	keyEOF       = 0x04 // ^D
+	keyCancel    = 0x07 // ^G
+	keyBackspace = 0x08 // ^H
+	keyKill      = 0x15 // ^U
+	keySearch    = 0x12 // ^R
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// editor reads lines from a terminal a key at a time, for editing at the end of the line with backspace and ^U,
+// moving through the history with the up and down arrows, and searching it backwards as the query is typed with
+// ^R, like other shells.
+type editor struct {
+	*plainReader
+	history *history.History
+	// raw puts the terminal in raw mode, returning a function that restores it.
+	raw func() (restore func(), err error)
+}
+
+// ReadLine implements lineReader. The terminal is only in raw mode while reading, and reads a line as it is when
+// it can't be put in raw mode.
+func (e *editor) ReadLine(prompt string) (string, error) {
+	restore, err := e.raw()
+	if err != nil {
+		return e.plainReader.ReadLine(prompt)
+	}
+	defer restore()
+
+	var (
+		line    string
+		entries = e.history.Entries()
+		// index is the history entry shown, or len(entries) for the line being typed, kept in pending.
+		index   = len(entries)
+		pending string
+	)
+	for {
+		if _, err := fmt.Fprintf(e.out, "\r\x1b[K%s%s", prompt, line); err != nil {
+			return "", err
+		}
+		c, err := e.in.ReadByte()
+		if err != nil {
+			return line, err
+		}
+		switch c {
+		case '\r', '\n':
+			_, err := fmt.Fprint(e.out, "\r\n")
+			return line + "\n", err
+		case keyEOF:
+			if line == "" {
+				_, _ = fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+		case keyInterrupt:
+			_, _ = fmt.Fprint(e.out, "^C\r\n")
+			line, index = "", len(entries)
+		case keyBackspace, keyDelete:
+			_, size := utf8.DecodeLastRuneInString(line)
+			line = line[:len(line)-size]
+		case keyKill:
+			line = ""
+		case keySearch:
+			var accept bool
+			if line, accept, err = e.search(line); err != nil || accept {
+				_, _ = fmt.Fprint(e.out, "\r\n")
+				return line + "\n", err
+			}
+		case keyEscape:
+			switch e.escape() {
+			case 'A':
+				if index == len(entries) {
+					pending = line
+				}
+				if index > 0 {
+					index--
+					line = entries[index]
+				}
+			case 'B':
+				if index < len(entries) {
+					index++
+					line = pending
+					if index < len(entries) {
+						line = entries[index]
+					}
+				}
+			}
+		default:
+			if c >= ' ' {
+				line += string([]byte{c})
+			}
+		}
+	}
+}
+
+// escape reads the rest of an escape sequence after the escape key, returning its final byte, e.g. A for the
+// up arrow's ESC [ A.
+func (e *editor) escape() byte {
+	if b, err := e.in.ReadByte(); err != nil || b != '[' && b != 'O' {
+		return 0
+	}
+	for {
+		b, err := e.in.ReadByte()
+		if err != nil {
+			return 0
+		}
+		// Parameters come before the final byte, e.g. ESC [ 1 ; 5 A.
+		if b >= 0x40 {
+			return b
+		}
+	}
+}
+
+// search searches the history backwards for the newest line containing the query as it's typed, starting again
+// from the match for ^R. It returns the match, or the line it started from when canceled with ^G, and whether it
+// was accepted with Enter to run it, rather than with another key to edit it.
+func (e *editor) search(line string) (string, bool, error) {
+	var (
+		query   string
+		before  = len(e.history.Entries())
+		match   = -1
+		matched string
+	)
+	find := func(before int) {
+		if i, ok := e.history.Search(query, before); ok {
+			match, matched = i, e.history.Entries()[i]
+		} else {
+			match = -1
+		}
+	}
+	for {
+		status := "reverse-i-search"
+		if match < 0 && query != "" {
+			status = "failed reverse-i-search"
+		}
+		if _, err := fmt.Fprintf(e.out, "\r\x1b[K(%s)`%s': %s", status, query, matched); err != nil {
+			return line, false, err
+		}
+		c, err := e.in.ReadByte()
+		if err != nil {
+			return line, false, err
+		}
+		switch c {
+		case '\r', '\n':
+			return matched, true, nil
+		case keyCancel, keyInterrupt:
+			return line, false, nil
+		case keySearch:
+			if match >= 0 {
+				before = match
+			}
+			find(before)
+		case keyBackspace, keyDelete:
+			if query != "" {
+				_, size := utf8.DecodeLastRuneInString(query)
+				query = query[:len(query)-size]
+				before = len(e.history.Entries())
+				find(before)
+			}
+		default:
+			if c < ' ' {
+				if c == keyEscape {
+					e.escape()
+				}
+				if matched == "" {
+					matched = line
+				}
+				return matched, false, nil
+			}
+			query += string([]byte{c})
+			// The match may still contain the longer query, so search from it.
+			if match >= 0 {
+				before = match + 1
+			}
+			find(before)
+		}
+	}
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// makeRaw puts the terminal f in raw mode, reading a key at a time without echoing it or turning keys like ^C
+// into signals, and returns a function that restores its mode.
+func makeRaw(f *os.File) (func(), error) {
+	fd := int(f.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() { _ = term.Restore(fd, state) }, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nluthra2001/CSCE4600/Project2/history"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_editor_ReadLine(t *testing.T) {
+	t.Parallel()
+	const (
+		up    = "\x1b[A"
+		down  = "\x1b[B"
+		right = "\x1b[C"
+	)
+	tests := []struct {
+		name    string
+		keys    string
+		want    string
+		wantErr error
+	}{
+		{name: "line", keys: "ls -l\r", want: "ls -l\n"},
+		{name: "newline", keys: "ls\n", want: "ls\n"},
+		{name: "backspace", keys: "lx\x7f\x08ls\r", want: "ls\n"},
+		{name: "backspace a rune", keys: "echo é\x7fe\r", want: "echo e\n"},
+		{name: "kill", keys: "rm -rf\x15ls\r", want: "ls\n"},
+		{name: "interrupt", keys: "rm\x03ls\r", want: "ls\n"},
+		{name: "control keys ignored", keys: "l\x01s" + right + "\r", want: "ls\n"},
+		{name: "up", keys: up + "\r", want: "git status\n"},
+		{name: "up twice", keys: up + up + "\r", want: "make build\n"},
+		{name: "up past the start", keys: strings.Repeat(up, 9) + "\r", want: "make test\n"},
+		{name: "up and down to the line typed", keys: "ec" + up + up + down + down + "ho\r", want: "echo\n"},
+		{name: "down past the end", keys: down + "ls\r", want: "ls\n"},
+		{name: "search", keys: "\x12mak\r", want: "make build\n"},
+		{name: "search again", keys: "\x12mak\x12\r", want: "make test\n"},
+		{name: "search narrows", keys: "\x12make t\r", want: "make test\n"},
+		{name: "search backspace", keys: "\x12make t\x7f\r", want: "make build\n"},
+		{name: "search failed", keys: "\x12make x\r", want: "make build\n"},
+		{name: "search to edit", keys: "\x12stat" + right + " -s\r", want: "git status -s\n"},
+		{name: "search cancel", keys: "ls\x12mak\x07 -l\r", want: "ls -l\n"},
+		{name: "search nothing to edit", keys: "ls\x12\x01 -l\r", want: "ls -l\n"},
+		{name: "end of input", keys: "\x04", wantErr: io.EOF},
+		{name: "end of input ignored in a line", keys: "ls\x04\r", want: "ls\n"},
+		{name: "input ends in a line", keys: "ls", want: "ls", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := history.New(history.DefaultSize)
+			for _, line := range []string{"make test", "ls", "make build", "git status"} {
+				_ = h.Add(line)
+			}
+			out := &bytes.Buffer{}
+			e := &editor{
+				plainReader: &plainReader{in: bufio.NewReader(strings.NewReader(tt.keys)), out: out},
+				history:     h,
+				raw:         func() (func(), error) { return func() {}, nil },
+			}
+
+			got, err := e.ReadLine("$ ")
+			require.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+			require.Equal(t, tt.want, got)
+			require.True(t, strings.HasPrefix(out.String(), "\r\x1b[K$ "))
+		})
+	}
+}
+
+func Test_editor_ReadLine_shows_search(t *testing.T) {
+	t.Parallel()
+	h := history.New(history.DefaultSize)
+	_ = h.Add("make test")
+	out := &bytes.Buffer{}
+	e := &editor{
+		plainReader: &plainReader{in: bufio.NewReader(strings.NewReader("\x12tes\x12x\x07\r")), out: out},
+		history:     h,
+		raw:         func() (func(), error) { return func() {}, nil },
+	}
+
+	_, err := e.ReadLine("$ ")
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "(reverse-i-search)`tes': make test")
+	require.Contains(t, out.String(), "(failed reverse-i-search)`tesx': make test")
+}
+
+func Test_editor_ReadLine_not_raw(t *testing.T) {
+	t.Parallel()
+	out := &bytes.Buffer{}
+	e := &editor{
+		plainReader: &plainReader{in: bufio.NewReader(strings.NewReader("ls\x12\n")), out: out},
+		history:     history.New(history.DefaultSize),
+		raw:         func() (func(), error) { return nil, errors.New("not a terminal") },
+	}
+
+	// The line is read as it is.
+	got, err := e.ReadLine("$ ")
+	require.NoError(t, err)
+	require.Equal(t, "ls\x12\n", got)
+	require.Equal(t, "$ ", out.String())
+}
+
+func Test_shell_history(t *testing.T) {
+	t.Parallel()
+	w := &bytes.Buffer{}
+	sh := newShell(strings.NewReader(""), w, &bytes.Buffer{}, make(chan struct{}, 2))
+
+	require.NoError(t, sh.handleInput("echo one"))
+	require.NoError(t, sh.handleInput("echo two"))
+	w.Reset()
+	require.NoError(t, sh.handleInput("!1 | tr a-z A-Z"))
+	require.Equal(t, "echo one | tr a-z A-Z\nONE\n", w.String())
+	w.Reset()
+	require.NoError(t, sh.handleInput("!!"))
+	require.Equal(t, "echo one | tr a-z A-Z\nONE\n", w.String())
+
+	w.Reset()
+	require.NoError(t, sh.handleInput(`echo "'" '!!'`))
+	require.Equal(t, "' !!\n", w.String())
+
+	require.True(t, errors.Is(sh.handleInput("!9"), history.ErrEventNotFound))
+	require.Equal(t, []string{"echo one", "echo two", "echo one | tr a-z A-Z", "echo one | tr a-z A-Z", `echo "'" '!!'`}, sh.History())
+
+	require.NoError(t, sh.handleInput("history -c"))
+	require.Empty(t, sh.History())
+}
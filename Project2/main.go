@@ -1,99 +1,208 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"os/user"
+	"path/filepath"
 	"strings"
+	"sync"
 
-	"github.com/jh125486/CSCE4600/Project2/builtins"
+	"github.com/nluthra2001/CSCE4600/Project2/builtins"
+	"github.com/nluthra2001/CSCE4600/Project2/history"
 )
 
 func main() {
 	exit := make(chan struct{}, 2) // buffer this so there's no deadlock.
-	runLoop(os.Stdin, os.Stdout, os.Stderr, exit)
+	sh := newShell(os.Stdin, os.Stdout, os.Stderr, exit)
+	// Keep the history from one session to the next.
+	if path := historyFile(); path != "" {
+		h, err := history.Load(path, history.DefaultSize)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+		} else {
+			sh.history = h
+		}
+	}
+	sh.run(exit)
+}
+
+// historyFile returns the file the history is kept in: $HISTFILE, or else .project2_history in the home
+// directory, or empty when there's neither.
+func historyFile() string {
+	if path := os.Getenv("HISTFILE"); path != "" {
+		return path
+	}
+	if builtins.HomeDir == "" {
+		return ""
+	}
+
+	return filepath.Join(builtins.HomeDir, ".project2_history")
+}
+
+// shell is the state of a running shell.
+type shell struct {
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+	exit     chan<- struct{}
+	builtins *builtins.Registry
+	history  *history.History
+	jobs     *jobTable
+}
+
+func newShell(r io.Reader, w, errW io.Writer, exit chan<- struct{}) *shell {
+	// The stages of pipelines and background jobs write the shell's output at the same time as each other and the
+	// shell, which is only safe for a file. One lock covers both, as they may well be the same writer.
+	mu := &sync.Mutex{}
+	if _, ok := w.(*os.File); !ok {
+		w = lockedWriter{mu: mu, w: w}
+	}
+	if _, ok := errW.(*os.File); !ok {
+		errW = lockedWriter{mu: mu, w: errW}
+	}
+
+	return &shell{
+		stdin:    r,
+		stdout:   w,
+		stderr:   errW,
+		exit:     exit,
+		builtins: builtins.Defaults(),
+		history:  history.New(history.DefaultSize),
+		jobs:     &jobTable{tty: newTerminal(r)},
+	}
+}
+
+// lockedWriter serializes writes to a writer that may not be safe to write concurrently.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Write(p)
+}
+
+// Exit implements builtins.Shell.
+func (s *shell) Exit() {
+	s.exit <- struct{}{}
+}
+
+// History implements builtins.Shell.
+func (s *shell) History() []string {
+	return s.history.Entries()
+}
+
+// ClearHistory implements builtins.Shell.
+func (s *shell) ClearHistory() error {
+	return s.history.Clear()
+}
+
+// Builtins implements builtins.Shell.
+func (s *shell) Builtins() *builtins.Registry {
+	return s.builtins
+}
+
+// Jobs implements builtins.Shell.
+func (s *shell) Jobs() builtins.Jobs {
+	return s.jobs
+}
+
+// reportJobs reports the background jobs that have finished since they were last reported, and forgets them.
+func (s *shell) reportJobs() {
+	for _, job := range s.jobs.reap() {
+		_, _ = fmt.Fprintf(s.stdout, "[%d]  %-10s %s\n", job.ID, job.Status, job.Command)
+	}
 }
 
 func runLoop(r io.Reader, w, errW io.Writer, exit chan struct{}) {
-	var (
-		input    string
-		err      error
-		readLoop = bufio.NewReader(r)
-	)
+	newShell(r, w, errW, exit).run(exit)
+}
+
+// run reads and runs command lines until the shell exits.
+func (s *shell) run(exit <-chan struct{}) {
+	lines := newLineReader(s.stdin, s.stdout, s.history)
 	for {
 		select {
 		case <-exit:
-			_, _ = fmt.Fprintln(w, "exiting gracefully...")
+			_, _ = fmt.Fprintln(s.stdout, "exiting gracefully...")
 			return
 		default:
-			if err := printPrompt(w); err != nil {
-				_, _ = fmt.Fprintln(errW, err)
+			s.reportJobs()
+			p, err := prompt()
+			if err != nil {
+				_, _ = fmt.Fprintln(s.stderr, err)
 				continue
 			}
-			if input, err = readLoop.ReadString('\n'); err != nil {
-				_, _ = fmt.Fprintln(errW, err)
-				continue
+			input, err := lines.ReadLine(p)
+			if input != "" {
+				if err := s.handleInput(input); err != nil {
+					_, _ = fmt.Fprintln(s.stderr, err)
+				}
 			}
-			if err = handleInput(w, input, exit); err != nil {
-				_, _ = fmt.Fprintln(errW, err)
+			switch {
+			case errors.Is(err, io.EOF):
+				// The end of the input, e.g. ^D, exits like exit, on a line of its own.
+				_, _ = fmt.Fprintln(s.stdout)
+				s.Exit()
+			case err != nil:
+				_, _ = fmt.Fprintln(s.stderr, err)
 			}
 		}
 	}
 }
 
-func printPrompt(w io.Writer) error {
+func prompt() (string, error) {
 	// Get current user.
 	// Don't prematurely memoize this because it might change due to `su`?
 	u, err := user.Current()
 	if err != nil {
-		return err
+		return "", err
 	}
 	// Get current working directory.
 	wd, err := os.Getwd()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// /home/User [Username] $
-	_, err = fmt.Fprintf(w, "%v [%v] $ ", wd, u.Username)
-
-	return err
+	return fmt.Sprintf("%v [%v] $ ", wd, u.Username), nil
 }
 
-func handleInput(w io.Writer, input string, exit chan<- struct{}) error {
+func (s *shell) handleInput(input string) error {
 	// Remove trailing spaces.
 	input = strings.TrimSpace(input)
-
-	// Split the input separate the command name and the command arguments.
-	args := strings.Split(input, " ")
-	name, args := args[0], args[1:]
-
-	// Check for built-in commands.
-	// New builtin commands should be added here. Eventually this should be refactored to its own func.
-	switch name {
-	case "cd":
-		return builtins.ChangeDirectory(args...)
-	case "env":
-		return builtins.EnvironmentVariables(w, args...)
-	case "exit":
-		exit <- struct{}{}
+	if input == "" {
 		return nil
 	}
+	// Expand history references, like !!, showing the line they expand to.
+	input, expanded, err := s.history.Expand(input)
+	if err != nil {
+		return err
+	}
+	if expanded {
+		_, _ = fmt.Fprintln(s.stdout, input)
+	}
+	if err := s.history.Add(input); err != nil {
+		// The line still runs when it can't be saved.
+		_, _ = fmt.Fprintln(s.stderr, err)
+	}
 
-	return executeCommand(name, args...)
-}
-
-func executeCommand(name string, arg ...string) error {
-	// Otherwise prep the command
-	cmd := exec.Command(name, arg...)
-
-	// Set the correct output device.
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
+	// Parse the input into the commands of a pipeline, each a builtin, which are added by registering them with
+	// the shell's registry, or a program.
+	pipeline, background, err := parsePipeline(input)
+	if err != nil || pipeline == nil {
+		return err
+	}
+	if background {
+		// The job's command is the line without the &.
+		input = strings.TrimSpace(strings.TrimSuffix(input, opBackground))
+	}
 
-	// Execute the command and return the error.
-	return cmd.Run()
+	return s.runPipeline(pipeline, background, input)
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"github.com/nluthra2001/CSCE4600/Project2/builtins"
 	"github.com/stretchr/testify/require"
 	"io"
 	"strings"
@@ -29,11 +31,17 @@ func Test_runLoop(t *testing.T) {
 			},
 		},
 		{
-			name: "read error should have no effect",
+			name: "end of input exits without an error",
 			args: args{
 				r: iotest.ErrReader(io.EOF),
 			},
-			wantErrW: "EOF",
+		},
+		{
+			name: "read error should have no effect",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErrW: "unexpected EOF",
 		},
 	}
 	for _, tt := range tests {
@@ -44,10 +52,15 @@ func Test_runLoop(t *testing.T) {
 			errW := &bytes.Buffer{}
 
 			exit := make(chan struct{}, 2)
-			// run the loop for 10ms
-			go runLoop(tt.args.r, w, errW, exit)
+			done := make(chan struct{})
+			// run the loop for 10ms, and read what it wrote once it has returned
+			go func() {
+				runLoop(tt.args.r, w, errW, exit)
+				close(done)
+			}()
 			time.Sleep(10 * time.Millisecond)
 			exit <- struct{}{}
+			<-done
 
 			require.NotEmpty(t, w.String())
 			if tt.wantErrW != "" {
@@ -58,3 +71,73 @@ func Test_runLoop(t *testing.T) {
 		})
 	}
 }
+
+func Test_runLoop_commands(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		wantW    []string
+		wantErrW string
+	}{
+		{
+			name:  "builtins",
+			input: "help\n\n   \nhistory\nexit\n",
+			wantW: []string{"bg\ncd\nenv\nexit\nfg\nhelp\nhistory\njobs\nkill\npwd\n", "    1  help\n    2  history\n", "exiting gracefully...\n"},
+		},
+		{
+			name:  "program",
+			input: "echo hello   world\nexit\n",
+			wantW: []string{"hello world\n"},
+		},
+		{
+			name:     "unknown program",
+			input:    "no-such-program-here\nexit\n",
+			wantErrW: "executable file not found",
+		},
+		{
+			name:     "builtin error",
+			input:    "exit now\nexit\n",
+			wantErrW: "invalid argument count",
+		},
+		{
+			name:  "end of input exits",
+			input: "echo last",
+			wantW: []string{"last\n", "\nexiting gracefully...\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := &bytes.Buffer{}
+			errW := &bytes.Buffer{}
+
+			// The loop returns once the input exits.
+			runLoop(strings.NewReader(tt.input), w, errW, make(chan struct{}, 2))
+
+			for _, want := range tt.wantW {
+				require.Contains(t, w.String(), want)
+			}
+			if tt.wantErrW != "" {
+				require.Contains(t, errW.String(), tt.wantErrW)
+			} else {
+				require.Empty(t, errW.String())
+			}
+		})
+	}
+}
+
+func Test_shell_register(t *testing.T) {
+	t.Parallel()
+	w := &bytes.Buffer{}
+	sh := newShell(strings.NewReader(""), w, io.Discard, make(chan struct{}, 2))
+	sh.Builtins().Register("greet", builtins.Func(func(ctx builtins.Context, args ...string) error {
+		_, err := fmt.Fprintf(ctx.Stdout, "hello, %s\n", strings.Join(args, " "))
+		return err
+	}))
+
+	require.NoError(t, sh.handleInput("greet shell users\n"))
+	require.Equal(t, "hello, shell users\n", w.String())
+	require.Equal(t, []string{"greet shell users"}, sh.History())
+}
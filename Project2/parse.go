@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSyntax is returned for a command line that can't be parsed.
+var ErrSyntax = errors.New("syntax error")
+
+// Operators.
+const (
+	opPipe        = "|"
+	opInput       = "<"
+	opOutput      = ">"
+	opAppend      = ">>"
+	opErrorOutput = "2>"
+	opErrorAppend = "2>>"
+	opBackground  = "&"
+)
+
+type (
+	// command is one stage of a pipeline: a program or builtin and its arguments, and the files its standard
+	// streams are redirected to or from.
+	command struct {
+		args []string
+		// stdin is the file the command reads instead of the previous stage's output or the shell's input.
+		stdin string
+		// stdout and stderr are the files the command writes instead of the next stage, or the shell's output.
+		stdout, stderr redirect
+	}
+	// redirect is an output redirection to a file, appending to it or truncating it first.
+	redirect struct {
+		path   string
+		append bool
+	}
+	// token is a word or, when op is set, an operator of a command line.
+	token struct {
+		text string
+		op   bool
+	}
+)
+
+// parsePipeline parses a command line into the commands of a pipeline, e.g. `grep -v "a b" < in | sort > out`,
+// and whether it ends with & to run it in the background. Words can be quoted with single quotes, or double quotes
+// in which a backslash escapes the next character, and a backslash outside quotes escapes the next character. It
+// returns nil for a blank line.
+func parsePipeline(line string) (pipeline []command, background bool, err error) {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return nil, false, err
+	}
+	if n := len(tokens); n > 0 && tokens[n-1] == (token{text: opBackground, op: true}) {
+		tokens, background = tokens[:n-1], true
+	}
+	if len(tokens) == 0 {
+		if background {
+			return nil, false, fmt.Errorf("%w: missing command before %q", ErrSyntax, opBackground)
+		}
+		return nil, false, nil
+	}
+	var current command
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if !t.op {
+			current.args = append(current.args, t.text)
+			continue
+		}
+		switch t.text {
+		case opPipe:
+			if len(current.args) == 0 {
+				return nil, false, fmt.Errorf("%w: missing command before %q", ErrSyntax, opPipe)
+			}
+			pipeline, current = append(pipeline, current), command{}
+			continue
+		case opBackground:
+			// Only the end of the line can run in the background.
+			return nil, false, fmt.Errorf("%w: unexpected %q", ErrSyntax, opBackground)
+		}
+		if i+1 == len(tokens) || tokens[i+1].op {
+			return nil, false, fmt.Errorf("%w: missing file after %q", ErrSyntax, t.text)
+		}
+		i++
+		path := tokens[i].text
+		switch t.text {
+		case opInput:
+			current.stdin = path
+		case opOutput:
+			current.stdout = redirect{path: path}
+		case opAppend:
+			current.stdout = redirect{path: path, append: true}
+		case opErrorOutput:
+			current.stderr = redirect{path: path}
+		case opErrorAppend:
+			current.stderr = redirect{path: path, append: true}
+		}
+	}
+	if len(current.args) == 0 {
+		return nil, false, fmt.Errorf("%w: missing command", ErrSyntax)
+	}
+
+	return append(pipeline, current), background, nil
+}
+
+// tokenize splits a command line into words and operators.
+func tokenize(line string) ([]token, error) {
+	var (
+		tokens []token
+		word   strings.Builder
+		inWord bool // a word has started, though it may still be empty, as with ""
+	)
+	endWord := func() {
+		if inWord {
+			tokens = append(tokens, token{text: word.String()})
+		}
+		word.Reset()
+		inWord = false
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			endWord()
+		case c == '\\':
+			if i+1 == len(line) {
+				return nil, fmt.Errorf("%w: trailing backslash", ErrSyntax)
+			}
+			i++
+			word.WriteByte(line[i])
+			inWord = true
+		case c == '\'' || c == '"':
+			end := i + 1
+			for ; end < len(line) && line[end] != c; end++ {
+				if c == '"' && line[end] == '\\' && end+1 < len(line) {
+					end++
+				}
+				word.WriteByte(line[end])
+			}
+			if end == len(line) {
+				return nil, fmt.Errorf("%w: unterminated %c quote", ErrSyntax, c)
+			}
+			i, inWord = end, true
+		case c == '|' || c == '&' || c == '<' || c == '>' || c == '2' && !inWord && strings.HasPrefix(line[i:], opErrorOutput):
+			endWord()
+			op := string(c)
+			for _, o := range []string{opErrorAppend, opErrorOutput, opAppend} {
+				if strings.HasPrefix(line[i:], o) {
+					op = o
+					break
+				}
+			}
+			tokens = append(tokens, token{text: op, op: true})
+			i += len(op) - 1
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	endWord()
+
+	return tokens, nil
+}
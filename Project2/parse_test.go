@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_tokenize(t *testing.T) {
+	t.Parallel()
+	word := func(text string) token { return token{text: text} }
+	op := func(text string) token { return token{text: text, op: true} }
+	tests := []struct {
+		name    string
+		line    string
+		want    []token
+		wantErr error
+	}{
+		{name: "blank", line: " \t\n"},
+		{name: "words", line: "  ls  -l\t/tmp\n", want: []token{word("ls"), word("-l"), word("/tmp")}},
+		{
+			name: "quotes",
+			line: `echo 'a  b' "c \"d\"" e"f"g ''`,
+			want: []token{word("echo"), word("a  b"), word(`c "d"`), word("efg"), word("")},
+		},
+		{name: "single quotes keep backslashes", line: `echo 'a\b'`, want: []token{word("echo"), word(`a\b`)}},
+		{name: "escapes", line: `echo a\ b \|`, want: []token{word("echo"), word("a b"), word("|")}},
+		{
+			name: "operators",
+			line: "sort<in|uniq>>out 2>err",
+			want: []token{
+				word("sort"), op("<"), word("in"), op("|"), word("uniq"), op(">>"), word("out"), op("2>"), word("err"),
+			},
+		},
+		{
+			name: "2 inside a word",
+			line: "echo a2>out 2>>err",
+			want: []token{word("echo"), word("a2"), op(">"), word("out"), op("2>>"), word("err")},
+		},
+		{name: "quoted operators", line: `echo '|' ">" \&`, want: []token{word("echo"), word("|"), word(">"), word("&")}},
+		{name: "background", line: "sleep 1&", want: []token{word("sleep"), word("1"), op("&")}},
+		{name: "trailing backslash", line: `echo \`, wantErr: ErrSyntax},
+		{name: "unterminated quote", line: `echo "abc`, wantErr: ErrSyntax},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tokenize(tt.line)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parsePipeline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		line           string
+		want           []command
+		wantBackground bool
+		wantErr        error
+	}{
+		{name: "blank", line: "   "},
+		{name: "command", line: "ls -l", want: []command{{args: []string{"ls", "-l"}}}},
+		{
+			name: "pipeline",
+			line: "cat < in | sort -r | uniq -c >> out 2> err",
+			want: []command{
+				{args: []string{"cat"}, stdin: "in"},
+				{args: []string{"sort", "-r"}},
+				{
+					args:   []string{"uniq", "-c"},
+					stdout: redirect{path: "out", append: true},
+					stderr: redirect{path: "err"},
+				},
+			},
+		},
+		{
+			name: "redirect before the command",
+			line: "> out 2>> err echo hi",
+			want: []command{{
+				args:   []string{"echo", "hi"},
+				stdout: redirect{path: "out"},
+				stderr: redirect{path: "err", append: true},
+			}},
+		},
+		{name: "last redirect wins", line: "echo > a > b", want: []command{{args: []string{"echo"}, stdout: redirect{path: "b"}}}},
+		{
+			name:           "background",
+			line:           "sleep 10 | cat > out &",
+			want:           []command{{args: []string{"sleep", "10"}}, {args: []string{"cat"}, stdout: redirect{path: "out"}}},
+			wantBackground: true,
+		},
+		{name: "only background", line: " & ", wantErr: ErrSyntax},
+		{name: "background before the end", line: "sleep 1 & ls", wantErr: ErrSyntax},
+		{name: "background twice", line: "sleep 1 & &", wantErr: ErrSyntax},
+		{name: "missing command before pipe", line: "| sort", wantErr: ErrSyntax},
+		{name: "empty stage", line: "ls | | sort", wantErr: ErrSyntax},
+		{name: "missing last command", line: "ls |", wantErr: ErrSyntax},
+		{name: "missing file", line: "ls >", wantErr: ErrSyntax},
+		{name: "operator for file", line: "ls > | sort", wantErr: ErrSyntax},
+		{name: "only a redirect", line: "< in", wantErr: ErrSyntax},
+		{name: "tokenize error", line: "echo 'abc", wantErr: ErrSyntax},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, background, err := parsePipeline(tt.line)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantBackground, background)
+		})
+	}
+}
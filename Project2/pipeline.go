@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/nluthra2001/CSCE4600/Project2/builtins"
+)
+
+// pipelineError holds the errors of the stages of a pipeline that failed, in order.
+type pipelineError []error
+
+func (e pipelineError) Error() string {
+	text := make([]string, len(e))
+	for i, err := range e {
+		text[i] = err.Error()
+	}
+
+	return strings.Join(text, "\n")
+}
+
+// Unwrap returns the error of the last stage that failed, which decides the pipeline's status, as in other shells.
+func (e pipelineError) Unwrap() error {
+	return e[len(e)-1]
+}
+
+// stage is a command of a pipeline with its standard streams connected.
+type stage struct {
+	command
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	// files holds the pipe ends and redirected files the stage owns, to close once it no longer needs them.
+	files []*os.File
+}
+
+// close closes the files the stage owns.
+func (st *stage) close() {
+	for _, f := range st.files {
+		_ = f.Close()
+	}
+	st.files = nil
+}
+
+// runPipeline runs the commands of a pipeline concurrently, each one's output connected to the next one's input
+// by a pipe. Redirections take the place of the pipes and the shell's own streams. In the foreground, it hands
+// them the terminal and waits for them all, and returns the error of each stage that failed, prefixed with its
+// command name; with several, a pipelineError. If they're stopped instead, e.g. by ^Z, it adds them to the job
+// table as line, stopped. In the background, it adds them to the job table as line and returns once they've
+// started.
+func (s *shell) runPipeline(pipeline []command, background bool, line string) error {
+	stages, err := s.connect(pipeline, background)
+	if err != nil {
+		return err
+	}
+	tty := s.jobs.tty
+	if background {
+		tty = nil
+	}
+	results, pids := s.start(stages, background || tty != nil, tty)
+	var pgid int
+	if len(pids) > 0 {
+		pgid = pids[0]
+	}
+	j := newJob(line, pgid, stages, results)
+	if !background {
+		stopped := tty.wait(pgid, j.done)
+		if pgid != 0 {
+			tty.takeBack()
+		}
+		if !stopped {
+			return j.err
+		}
+		j.stopped = true
+		s.jobs.add(j)
+		return stoppedError{j}
+	}
+
+	s.jobs.add(j)
+	if len(pids) == 0 {
+		_, err = fmt.Fprintf(s.stdout, "[%d]\n", j.id)
+	} else {
+		// Like other shells, report the last process, whose status is the job's.
+		_, err = fmt.Fprintf(s.stdout, "[%d] %d\n", j.id, pids[len(pids)-1])
+	}
+
+	return err
+}
+
+// connect returns the stages of a pipeline with their standard streams connected by pipes, redirected to files,
+// or else the shell's own, though in the background the first stage reads nothing rather than the shell's input.
+// It opens every redirected file first, so that none of the commands run if one can't be opened.
+func (s *shell) connect(pipeline []command, background bool) ([]stage, error) {
+	stages := make([]stage, len(pipeline))
+	closeAll := func() {
+		for i := range stages {
+			stages[i].close()
+		}
+	}
+	for i, c := range pipeline {
+		stages[i].command, stages[i].stdout, stages[i].stderr = c, s.stdout, s.stderr
+		if i == 0 {
+			stages[i].stdin = s.stdin
+			if background {
+				stages[i].stdin = strings.NewReader("")
+			}
+		}
+	}
+	for i := 0; i+1 < len(stages); i++ {
+		r, w, err := os.Pipe()
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		stages[i].stdout, stages[i+1].stdin = w, r
+		stages[i].files, stages[i+1].files = append(stages[i].files, w), append(stages[i+1].files, r)
+	}
+	for i := range stages {
+		st := &stages[i]
+		open := func(path string, flag int) (*os.File, error) {
+			f, err := os.OpenFile(path, flag, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", st.args[0], err)
+			}
+			st.files = append(st.files, f)
+			return f, nil
+		}
+		if st.command.stdin != "" {
+			f, err := open(st.command.stdin, os.O_RDONLY)
+			if err != nil {
+				closeAll()
+				return nil, err
+			}
+			st.stdin = f
+		}
+		for _, out := range []struct {
+			redirect
+			stream *io.Writer
+		}{{st.command.stdout, &st.stdout}, {st.command.stderr, &st.stderr}} {
+			if out.path == "" {
+				continue
+			}
+			flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			if out.append {
+				flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+			}
+			f, err := open(out.path, flag)
+			if err != nil {
+				closeAll()
+				return nil, err
+			}
+			*out.stream = f
+		}
+	}
+
+	return stages, nil
+}
+
+// start starts the stages of a pipeline in order, each a builtin if one is registered under its name and
+// otherwise a program, closing the files they own once they no longer need them. It returns a channel for each
+// stage that receives its error once it's done, and the process IDs of the programs. With group set, the programs
+// are put in a process group of their own, led by the first, so that they can be signaled together and don't get
+// the signals meant for the shell, like ^C, and in the foreground of tty unless it's nil.
+func (s *shell) start(stages []stage, group bool, tty *terminal) ([]chan error, []int) {
+	var (
+		results = make([]chan error, len(stages))
+		pids    []int
+	)
+	for i := range stages {
+		st, result := &stages[i], make(chan error, 1)
+		results[i] = result
+		if b, ok := s.builtins.Lookup(st.args[0]); ok {
+			ctx := builtins.Context{Stdin: st.stdin, Stdout: st.stdout, Stderr: st.stderr, Shell: s}
+			go func() {
+				defer st.close()
+				result <- b.Run(ctx, st.args[1:]...)
+			}()
+			continue
+		}
+
+		cmd := exec.Command(st.args[0], st.args[1:]...)
+		// The shell's input is only passed on when it's a file, like a terminal: any other reader would be copied
+		// to the command until it ends, taking the shell's own input.
+		if st.stdin != s.stdin {
+			cmd.Stdin = st.stdin
+		} else if f, ok := s.stdin.(*os.File); ok {
+			cmd.Stdin = f
+		}
+		cmd.Stdout, cmd.Stderr = st.stdout, st.stderr
+		if group {
+			pgid := 0
+			if len(pids) > 0 {
+				pgid = pids[0]
+			}
+			setpgid(cmd, pgid, tty)
+		}
+		err := cmd.Start()
+		// The command has its own copies of the pipe ends and files now, and ours have to be closed for the stages
+		// either side to see the end of the pipe.
+		st.close()
+		if err != nil {
+			result <- err
+			continue
+		}
+		pids = append(pids, cmd.Process.Pid)
+		go func() {
+			result <- cmd.Wait()
+		}()
+	}
+
+	return results, pids
+}
+
+// wait waits for the stages of a pipeline, returning the error of each, prefixed with its command name, or nil.
+// Waiting for a program reaps it.
+func wait(stages []stage, results []chan error) []error {
+	errs := make([]error, len(results))
+	for i, result := range results {
+		if err := <-result; err != nil {
+			errs[i] = fmt.Errorf("%s: %w", stages[i].args[0], err)
+		}
+	}
+
+	return errs
+}
+
+// combine returns the errors of the stages of a pipeline that failed: nil for none, the error for one, and a
+// pipelineError for several.
+func combine(errs []error) error {
+	var failed pipelineError
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return failed[0]
+	default:
+		return failed
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_shell_runPipeline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		// input is run with {dir} replaced by a temporary directory holding the files.
+		input     string
+		files     map[string]string
+		wantW     string
+		wantFiles map[string]string
+		wantErr   string
+	}{
+		{name: "pipe", input: "echo hello world | tr a-z A-Z", wantW: "HELLO WORLD\n"},
+		{
+			name:  "three stages",
+			input: "printf 'b\\na\\nb\\nc\\n' | sort | uniq -c",
+			wantW: "      1 a\n      2 b\n      1 c\n",
+		},
+		{
+			name:      "output",
+			input:     "echo new > {dir}/out",
+			files:     map[string]string{"out": "old\n"},
+			wantFiles: map[string]string{"out": "new\n"},
+		},
+		{
+			name:      "append",
+			input:     "echo new >> {dir}/out",
+			files:     map[string]string{"out": "old\n"},
+			wantFiles: map[string]string{"out": "old\nnew\n"},
+		},
+		{
+			name:      "input",
+			input:     "sort < {dir}/in | head -n 2 > {dir}/out",
+			files:     map[string]string{"in": "c\nb\na\n"},
+			wantFiles: map[string]string{"out": "a\nb\n"},
+		},
+		{
+			name:      "error output",
+			input:     "ls {dir}/missing 2> {dir}/err",
+			wantFiles: map[string]string{"err": "missing"},
+			wantErr:   "ls: exit status",
+		},
+		{
+			name:      "redirect overrides the pipe",
+			input:     "echo hi > {dir}/out | cat",
+			wantFiles: map[string]string{"out": "hi\n"},
+		},
+		{name: "builtin in a pipeline", input: "help | grep hist", wantW: "history\n"},
+		{
+			name:      "builtin redirected",
+			input:     "pwd > {dir}/out",
+			wantFiles: map[string]string{"out": "/"},
+		},
+		{name: "failing stage", input: "echo hi | grep nothing | cat", wantErr: "grep: exit status 1"},
+		{name: "unknown program", input: "no-such-program | cat", wantErr: "no-such-program: exec"},
+		{
+			name:    "several failing stages",
+			input:   "false | true | false",
+			wantErr: "false: exit status 1\nfalse: exit status 1",
+		},
+		{name: "unopenable file runs nothing", input: "echo hi < {dir}/missing", wantErr: "echo: open"},
+		{name: "syntax error", input: "echo hi |", wantErr: ErrSyntax.Error()},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+			w := &bytes.Buffer{}
+			sh := newShell(strings.NewReader(""), w, &bytes.Buffer{}, make(chan struct{}, 2))
+
+			err := sh.handleInput(strings.ReplaceAll(tt.input, "{dir}", dir))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantW, w.String())
+			for name, want := range tt.wantFiles {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				require.Contains(t, string(got), want)
+			}
+		})
+	}
+}
+
+func Test_shell_runPipeline_errors(t *testing.T) {
+	t.Parallel()
+	sh := newShell(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}, make(chan struct{}, 2))
+
+	// The pipeline's error unwraps to the last stage that failed.
+	err := sh.handleInput("false | ls " + filepath.Join(t.TempDir(), "missing"))
+	var exitErr *exec.ExitError
+	require.True(t, errors.As(err, &exitErr))
+	require.Contains(t, err.Error(), "false: ")
+	require.Contains(t, err.Error(), "ls: ")
+
+	err = sh.handleInput("cat < " + filepath.Join(t.TempDir(), "missing"))
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+}
@@ -6,6 +6,9 @@ go 1.19
 require (
 	github.com/olekukonko/tablewriter v0.0.5
 	github.com/stretchr/testify v1.8.1
+	golang.org/x/sys v0.15.0
+	golang.org/x/term v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,5 +16,4 @@ require (
 	github.com/mattn/go-runewidth v0.0.14 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.3 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )